@@ -0,0 +1,53 @@
+package genius_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+func TestGetAlbumTracksWithLyrics(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/albums/1/tracks", func(w http.ResponseWriter, r *http.Request) {
+		tracks := `[]`
+		if r.URL.Query().Get("page") == "1" {
+			tracks = `[
+				{"number": 1, "disc_number": 2, "song": {"id": 3}},
+				{"number": 2, "disc_number": 1, "song": {"id": 2}},
+				{"number": 1, "disc_number": 1, "song": {"id": 1}}
+			]`
+		}
+		_, _ = w.Write([]byte(`{"meta": {"status": 200}, "response": {"tracks": ` + tracks + `, "next_page": null}}`))
+	})
+	mux.HandleFunc("/songs/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		_, _ = fmt.Fprintf(w, `{"meta": {"status": 200}, "response": {"song": {"id": %s, "title": "Song %s", "url": "%s/lyrics/%s"}}}`,
+			id, id, server.URL, id)
+	})
+	mux.HandleFunc("/lyrics/{id}", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `<html><body><div id="lyrics-root"><div data-lyrics-container="true">Lyrics %s</div></div></body></html>`,
+			r.PathValue("id"))
+	})
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL), genius.WithConcurrency(3))
+
+	tracks, err := client.GetAlbumTracksWithLyrics(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tracks) != 3 {
+		t.Fatalf("got %d tracks, want 3", len(tracks))
+	}
+	for i, track := range tracks {
+		id := i + 1
+		if track.Song.ID != id || track.Song.Title != fmt.Sprintf("Song %d", id) || track.Song.Lyrics != fmt.Sprintf("Lyrics %d", id) {
+			t.Errorf("track %d: got song %d %q with lyrics %q", i, track.Song.ID, track.Song.Title, track.Song.Lyrics)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package genius_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+func TestGetAnnotation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/annotations/11101781" {
+			http.NotFound(w, req)
+			return
+		}
+
+		var body string
+		switch format := req.URL.Query().Get("text_format"); format {
+		case "dom":
+			body = `{"dom": {"tag": "root", "children": [{"tag": "p", "children": ["The ", {"tag": "em", "children": ["opening"]}, " line."]}]}}`
+		case "html":
+			body = `{"html": "<p>The <em>opening</em> line.</p>"}`
+		default:
+			t.Errorf("unexpected text_format %q", format)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"meta": {"status": 200}, "response": {"annotation": {"id": 11101781, "votes_total": 42, "body": ` + body + `}}}`))
+	}))
+	defer server.Close()
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	tests := []struct {
+		format genius.TextFormat
+		want   string
+	}{
+		{genius.FormatDOM, "The opening line."},
+		{genius.FormatMarkdown, "The *opening* line."},
+		{genius.FormatHTML, "<p>The <em>opening</em> line.</p>"},
+	}
+	for _, test := range tests {
+		annotation, err := client.GetAnnotation(context.Background(), 11101781, genius.WithTextFormat(test.format))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if annotation.ID != 11101781 || annotation.VotesTotal != 42 {
+			t.Errorf("unexpected annotation %+v", annotation)
+		}
+		if annotation.Body != test.want {
+			t.Errorf("%s: got body %q, want %q", test.format, annotation.Body, test.want)
+		}
+		if dom := test.format != genius.FormatHTML; (annotation.Dom != nil) != dom {
+			t.Errorf("%s: got dom %v", test.format, annotation.Dom)
+		}
+	}
+}
+
+func TestGetSongAnnotations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+		if req.URL.Path != "/referents" || query.Get("song_id") != "3039923" || query.Get("text_format") != "dom" {
+			t.Errorf("unexpected request %s", req.URL)
+		}
+
+		referents := `[]`
+		if query.Get("page") == "1" {
+			referents = `[
+				{"id": 1, "is_description": true, "fragment": "HUMBLE.", "annotations": []},
+				{"id": 2, "classification": "verified", "fragment": "Nobody pray for me", "range": {"content": "Nobody pray for me"},
+				 "annotations": [{"id": 20, "body": {"dom": {"tag": "root", "children": [{"tag": "p", "children": [{"tag": "strong", "children": ["Kendrick"]}, " explains"]}]}}}]},
+				{"id": 3, "classification": "needs_exegesis", "fragment": "Sit down", "range": {"content": "Sit down"}, "annotations": []}
+			]`
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"meta": {"status": 200}, "response": {"referents": ` + referents + `}}`))
+	}))
+	defer server.Close()
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	fragments, err := client.GetSongAnnotations(context.Background(), 3039923, genius.WithTextFormat(genius.FormatMarkdown))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fragments) != 2 {
+		t.Fatalf("got %d fragments, want the 2 lyrics fragments", len(fragments))
+	}
+	if got := fragments[0]; got.ReferentID != 2 || got.Range != "Nobody pray for me" || got.Classification != "verified" {
+		t.Errorf("unexpected fragment %+v", got)
+	}
+	if annotations := fragments[0].Annotations; len(annotations) != 1 || annotations[0].Body != "**Kendrick** explains" {
+		t.Errorf("unexpected annotations %+v", annotations)
+	}
+	if got := fragments[1]; got.Fragment != "Sit down" || len(got.Annotations) != 0 {
+		t.Errorf("unexpected fragment %+v", got)
+	}
+}
+
+func TestGetLyricsWithAnnotations(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/songs/3039923", func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(`{"meta": {"status": 200}, "response": {"song": {"id": 3039923, "title": "HUMBLE.", "url": "` + server.URL + `/humble"}}}`))
+	})
+	mux.HandleFunc("/humble", func(w http.ResponseWriter, req *http.Request) {
+		_, _ = w.Write([]byte(`<html><body><div id="lyrics-root"><div data-lyrics-container="true">` +
+			`[Chorus]<br/>Be humble, sit down<br/>Hol’ up, lil’ bitch<br/>Sit down<br/>Be humble, sit down</div></div></body></html>`))
+	})
+	mux.HandleFunc("/referents", func(w http.ResponseWriter, req *http.Request) {
+		referents := `[]`
+		if req.URL.Query().Get("page") == "1" {
+			referents = `[
+				{"id": 1, "fragment": "Be humble, sit down", "range": {"content": "Be humble, sit down"}, "annotations": []},
+				{"id": 2, "fragment": "be humble, sit down", "range": {"content": "be humble, sit down"}, "annotations": []},
+				{"id": 3, "fragment": "Hol' up, lil' bitch sit   down", "range": {"content": "hol' up, lil' bitch sit   down"}, "annotations": []},
+				{"id": 4, "fragment": "Alright", "range": {"content": "We gon' be alright"}, "annotations": []}
+			]`
+		}
+		_, _ = w.Write([]byte(`{"meta": {"status": 200}, "response": {"referents": ` + referents + `}}`))
+	})
+
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+	lyrics, err := client.GetLyricsWithAnnotations(context.Background(), 3039923)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		referentID int
+		text       string
+	}{
+		{1, "Be humble, sit down"},
+		{3, "Hol’ up, lil’ bitch\nSit down"},
+		{2, "Be humble, sit down"},
+	}
+	if len(lyrics.Annotations) != len(want) {
+		t.Fatalf("got %d annotations, want %d", len(lyrics.Annotations), len(want))
+	}
+	for i, annotation := range lyrics.Annotations {
+		if got := lyrics.Lyrics[annotation.Start:annotation.End]; annotation.ReferentID != want[i].referentID || got != want[i].text {
+			t.Errorf("annotation %d: got referent %d at %q, want %d at %q", i, annotation.ReferentID, got, want[i].referentID, want[i].text)
+		}
+	}
+	if len(lyrics.Unmatched) != 1 || lyrics.Unmatched[0].ReferentID != 4 {
+		t.Errorf("expected referent 4 to be unmatched, got %+v", lyrics.Unmatched)
+	}
+}
+
+func TestReferentsVerifiedOnly(t *testing.T) {
+	referents := []string{
+		`{"id": 1, "annotations": [{"id": 10, "verified": false}, {"id": 11, "verified": true}]}`,
+		`{"id": 2, "annotations": [{"id": 20, "verified": false}]}`,
+		`{"id": 3, "annotations": []}`,
+		`{"id": 4, "annotations": [{"id": 40, "verified": false}]}`,
+		`{"id": 5, "annotations": [{"id": 50, "verified": true}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		page, _ := strconv.Atoi(req.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(req.URL.Query().Get("per_page"))
+		start := min((page-1)*perPage, len(referents))
+		end := min(start+perPage, len(referents))
+		_, _ = w.Write([]byte(`{"meta": {"status": 200}, "response": {"referents": [` + strings.Join(referents[start:end], ",") + `]}}`))
+	}))
+	defer server.Close()
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	// Pages of two referents, the second of which is filtered empty.
+	var ids []int
+	opts := &genius.ReferentsOptions{ListOptions: genius.ListOptions{PerPage: 2}, VerifiedOnly: true}
+	for referent, err := range client.Referents(context.Background(), 1, opts) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, annotation := range referent.Annotations {
+			ids = append(ids, annotation.ID)
+		}
+	}
+	if want := []int{11, 50}; !slices.Equal(ids, want) {
+		t.Errorf("got annotations %v, want %v", ids, want)
+	}
+
+	fragments, err := client.GetSongAnnotations(context.Background(), 1, genius.WithVerifiedOnly())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fragments) != 2 || fragments[0].ReferentID != 1 || fragments[1].ReferentID != 5 {
+		t.Errorf("unexpected fragments %+v", fragments)
+	}
+}
+
+func TestReferentsStates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		referents := `[]`
+		if req.URL.Query().Get("page") == "1" {
+			referents = `[
+				{"id": 1, "annotations": [
+					{"id": 10, "state": "accepted", "verified": true, "votes_total": 12, "cosigned_by": [{"id": 7, "login": "kendrick"}]},
+					{"id": 11, "state": "pending", "votes_total": -1}
+				]},
+				{"id": 2, "annotations": [{"id": 20, "state": "rejected"}]}
+			]`
+		}
+		_, _ = w.Write([]byte(`{"meta": {"status": 200}, "response": {"referents": ` + referents + `}}`))
+	}))
+	defer server.Close()
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	fragments, err := client.GetSongAnnotations(context.Background(), 1,
+		genius.WithAnnotationStates(genius.AnnotationAccepted, genius.AnnotationPending))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fragments) != 1 || len(fragments[0].Annotations) != 2 {
+		t.Fatalf("unexpected fragments %+v", fragments)
+	}
+	accepted, pending := fragments[0].Annotations[0], fragments[0].Annotations[1]
+	if accepted.State != genius.AnnotationAccepted || !accepted.Verified || accepted.VotesTotal != 12 {
+		t.Errorf("unexpected annotation %+v", accepted)
+	}
+	if len(accepted.CosignedBy) != 1 || accepted.CosignedBy[0].Login != "kendrick" {
+		t.Errorf("unexpected cosigners %+v", accepted.CosignedBy)
+	}
+	if pending.State != genius.AnnotationPending || pending.VotesTotal != -1 {
+		t.Errorf("unexpected annotation %+v", pending)
+	}
+
+	opts := &genius.ReferentsOptions{States: []genius.AnnotationState{genius.AnnotationRejected}}
+	var ids []int
+	for referent, err := range client.Referents(context.Background(), 1, opts) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, referent.ID)
+	}
+	if !slices.Equal(ids, []int{2}) {
+		t.Errorf("got referents %v, want [2]", ids)
+	}
+}
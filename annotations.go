@@ -0,0 +1,215 @@
+package genius
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// AnnotatedFragment is a fragment of a song's lyrics together with its annotations.
+type AnnotatedFragment struct {
+	// ReferentID is the ID of the referent the fragment was taken from.
+	ReferentID int `json:"referent_id"`
+	// Fragment is the annotated text as shown on the song page.
+	Fragment string `json:"fragment"`
+	// Range is the content of the annotated range, which usually equals the fragment.
+	Range string `json:"range"`
+	// Classification is "accepted", "verified", "unreviewed" or "needs_exegesis" if the fragment has no annotations
+	// yet.
+	Classification string        `json:"classification"`
+	Annotations    []*Annotation `json:"annotations"`
+}
+
+// WithVerifiedOnly keeps only the annotations verified by the artist or a verified contributor in the results of
+// GetSongAnnotations and GetLyricsWithAnnotations, see ReferentsOptions.VerifiedOnly.
+func WithVerifiedOnly() RequestOption {
+	return func(o *requestOptions) {
+		o.verifiedOnly = true
+	}
+}
+
+// WithAnnotationStates keeps only the annotations in one of the states in the results of GetSongAnnotations and
+// GetLyricsWithAnnotations, see ReferentsOptions.States.
+func WithAnnotationStates(states ...AnnotationState) RequestOption {
+	return func(o *requestOptions) {
+		o.states = states
+	}
+}
+
+// GetSongAnnotations returns the annotated fragments of a song's lyrics with their annotations, fetching all pages of
+// its referents. The song's description, a referent as well, isn't included.
+//
+// Annotation bodies are rendered as plain text from the dom format unless another format is set with
+// WithTextFormat, see GetAnnotation. WithVerifiedOnly and WithAnnotationStates filter the annotations, dropping the
+// fragments left without any.
+func (c *Client) GetSongAnnotations(ctx context.Context, songID int, opts ...RequestOption) ([]*AnnotatedFragment, error) {
+	o := newRequestOptions(opts)
+
+	var fragments []*AnnotatedFragment
+	for referent, err := range c.referents(ctx, songID, o.textFormat, &ReferentsOptions{VerifiedOnly: o.verifiedOnly, States: o.states}) {
+		if err != nil {
+			return nil, err
+		}
+		if referent.IsDescription {
+			continue
+		}
+
+		fragments = append(fragments, &AnnotatedFragment{
+			ReferentID:     referent.ID,
+			Fragment:       referent.Fragment,
+			Range:          referent.Range.Content,
+			Classification: referent.Classification,
+			Annotations:    referent.Annotations,
+		})
+	}
+
+	return fragments, nil
+}
+
+// filtered reports whether the options filter referents.
+func (o *ReferentsOptions) filtered() bool {
+	return o.VerifiedOnly || len(o.States) > 0
+}
+
+// keep reports whether referent matches the options, removing the annotations that don't from it.
+func (o *ReferentsOptions) keep(referent *Referent) bool {
+	referent.Annotations = slices.DeleteFunc(referent.Annotations, func(annotation *Annotation) bool {
+		return (o.VerifiedOnly && !annotation.Verified) ||
+			(len(o.States) > 0 && !slices.Contains(o.States, annotation.State))
+	})
+	return len(referent.Annotations) > 0
+}
+
+// AnnotatedLyrics are the lyrics of a song with its annotated fragments located in them, see
+// Client.GetLyricsWithAnnotations.
+type AnnotatedLyrics struct {
+	Song   *Song  `json:"song"`
+	Lyrics string `json:"lyrics"`
+	// Annotations are the fragments found in the lyrics, ordered by their position.
+	Annotations []*LyricsAnnotation `json:"annotations"`
+	// Unmatched are the fragments that couldn't be found in the lyrics.
+	Unmatched []*AnnotatedFragment `json:"unmatched,omitempty"`
+}
+
+// LyricsAnnotation is an annotated fragment located in the lyrics.
+type LyricsAnnotation struct {
+	*AnnotatedFragment
+
+	// Start and End are the byte offsets of the fragment in the lyrics, Lyrics[Start:End] is the annotated text.
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// GetLyricsWithAnnotations returns the lyrics of the song with the ID and its annotated fragments, located in the
+// lyrics so they can be rendered together.
+//
+// Fragments are located best-effort: they are compared ignoring case, whitespace and the style of quotes, and a
+// fragment occurring multiple times is assigned the first occurrence not taken by another fragment. Fragments that
+// can't be found, e.g. because Genius edited the lyrics since, are returned as Unmatched.
+func (c *Client) GetLyricsWithAnnotations(ctx context.Context, id int, opts ...RequestOption) (*AnnotatedLyrics, error) {
+	song, err := c.GetSongWithLyrics(ctx, id, opts...)
+	if err != nil {
+		return nil, err
+	}
+	fragments, err := c.GetSongAnnotations(ctx, id, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return locateFragments(song, fragments), nil
+}
+
+func locateFragments(song *Song, fragments []*AnnotatedFragment) *AnnotatedLyrics {
+	lyrics := &AnnotatedLyrics{Song: song, Lyrics: song.Lyrics, Annotations: []*LyricsAnnotation{}}
+	text := newFoldedText(song.Lyrics)
+
+	for _, fragment := range fragments {
+		want := cmp.Or(fragment.Range, fragment.Fragment)
+		start, end, ok := text.find(want, func(start int, end int) bool {
+			return !slices.ContainsFunc(lyrics.Annotations, func(other *LyricsAnnotation) bool {
+				return start < other.End && other.Start < end
+			})
+		})
+		if !ok {
+			lyrics.Unmatched = append(lyrics.Unmatched, fragment)
+			continue
+		}
+		lyrics.Annotations = append(lyrics.Annotations, &LyricsAnnotation{AnnotatedFragment: fragment, Start: start, End: end})
+	}
+
+	slices.SortStableFunc(lyrics.Annotations, func(a, b *LyricsAnnotation) int { return cmp.Compare(a.Start, b.Start) })
+	return lyrics
+}
+
+// foldedText is a text folded for comparisons, with the offsets of its bytes in the original text.
+type foldedText struct {
+	folded string
+	// starts and ends are the offsets of the rune each byte of folded was folded from in the original text.
+	starts []int
+	ends   []int
+}
+
+func newFoldedText(text string) *foldedText {
+	t := &foldedText{}
+	var b strings.Builder
+	space := false
+	for i, r := range text {
+		_, size := utf8.DecodeRuneInString(text[i:])
+		end := i + size
+		if unicode.IsSpace(r) {
+			// Runs of whitespace are folded into a single space spanning them.
+			if space {
+				t.ends[len(t.ends)-1] = end
+				continue
+			}
+			space = true
+			r = ' '
+		} else {
+			space = false
+		}
+
+		n, _ := b.WriteString(string(foldRune(r)))
+		for range n {
+			t.starts = append(t.starts, i)
+			t.ends = append(t.ends, end)
+		}
+	}
+	t.folded = b.String()
+	return t
+}
+
+// find returns the offsets in the original text of the first occurrence of s accepted by accept.
+func (t *foldedText) find(s string, accept func(start int, end int) bool) (int, int, bool) {
+	want := newFoldedText(strings.TrimSpace(s)).folded
+	if want == "" {
+		return 0, 0, false
+	}
+
+	for offset := 0; offset < len(t.folded); {
+		i := strings.Index(t.folded[offset:], want)
+		if i < 0 {
+			return 0, 0, false
+		}
+		i += offset
+		start, end := t.starts[i], t.ends[i+len(want)-1]
+		if accept(start, end) {
+			return start, end, true
+		}
+		offset = i + 1
+	}
+	return 0, 0, false
+}
+
+// foldRune folds r to lower case and curly quotes to straight ones.
+func foldRune(r rune) rune {
+	switch r {
+	case '‘', '’', 'ʼ', '`':
+		return '\''
+	case '“', '”', '„':
+		return '"'
+	}
+	return unicode.ToLower(r)
+}
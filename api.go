@@ -0,0 +1,53 @@
+package genius
+
+import (
+	"context"
+	"iter"
+)
+
+// GeniusAPI is the Genius lookups of Client, so code using it can accept fakes in tests, see the geniustest package.
+//
+// Stats and NewWatcher aren't part of it as they are bound to the requests of a Client, nor are the downloads of
+// images, which aren't served by the API.
+type GeniusAPI interface {
+	GetAccount(ctx context.Context) (*AccountResponse, error)
+	GetArtist(ctx context.Context, id int, opts ...RequestOption) (*ArtistResponse, error)
+	GetArtists(ctx context.Context, ids []int) ([]*Artist, error)
+	GetArtistSongs(ctx context.Context, id int, sort Sort, total int) ([]*Song, error)
+	GetArtistSongsByPopularity(ctx context.Context, id int, limit int) ([]*Song, error)
+	GetArtistTopSongs(ctx context.Context, id int, n int) ([]*Song, error)
+	GetArtistBio(ctx context.Context, id int, format TextFormat) (string, error)
+	GetArtistAlbums(ctx context.Context, id int, opts *ListOptions) ([]*Album, error)
+	GetSong(ctx context.Context, id int, opts ...RequestOption) (*Song, error)
+	GetSongs(ctx context.Context, ids []int, format TextFormat) ([]*Song, error)
+	GetSongWithLyrics(ctx context.Context, id int, opts ...RequestOption) (*Song, error)
+	GetSongByPath(ctx context.Context, path string) (*Song, error)
+	GetAlbum(ctx context.Context, id int, getTracks bool, opts ...RequestOption) (*Album, error)
+	GetAlbumByPath(ctx context.Context, path string) (*Album, error)
+	GetAlbumByName(ctx context.Context, artist string, albumTitle string, opts ...RequestOption) (*Album, error)
+	GetAlbumTracks(ctx context.Context, id int, opts *ListOptions) ([]*AlbumTrack, error)
+	GetAlbumTracksWithLyrics(ctx context.Context, id int, opts ...RequestOption) ([]*AlbumTrack, error)
+	GetAnnotation(ctx context.Context, id int, opts ...RequestOption) (*Annotation, error)
+	GetSongAnnotations(ctx context.Context, songID int, opts ...RequestOption) ([]*AnnotatedFragment, error)
+	GetLyricsWithAnnotations(ctx context.Context, id int, opts ...RequestOption) (*AnnotatedLyrics, error)
+	GetLyrics(ctx context.Context, uri string) (string, error)
+	GetChart(ctx context.Context, opts *ChartOptions) ([]*ChartItem, error)
+	Search(ctx context.Context, q string) (*SearchResponse, error)
+	WebSearch(ctx context.Context, perPage int, searchTerm string) (*WebSearchResponse, error)
+	MatchTrack(ctx context.Context, title string, artist string, durationMS int) (*Song, error)
+	CheckHealth(ctx context.Context) *Health
+
+	ArtistSongs(ctx context.Context, id int, opts *ArtistSongsOptions) iter.Seq2[*Song, error]
+	ArtistAlbums(ctx context.Context, id int, opts *ListOptions) iter.Seq2[*Album, error]
+	AlbumTracks(ctx context.Context, id int, opts *ListOptions) iter.Seq2[*AlbumTrack, error]
+	Referents(ctx context.Context, songID int, opts *ReferentsOptions) iter.Seq2[*Referent, error]
+	SearchHits(ctx context.Context, q string, opts *ListOptions) iter.Seq2[*Hit, error]
+	Chart(ctx context.Context, opts *ChartOptions) iter.Seq2[*ChartItem, error]
+	SongComments(ctx context.Context, songID int, opts *CommentsOptions) iter.Seq2[*Comment, error]
+	AlbumComments(ctx context.Context, albumID int, opts *CommentsOptions) iter.Seq2[*Comment, error]
+	CommentReplies(ctx context.Context, commentID int, opts *CommentsOptions) iter.Seq2[*Comment, error]
+	StreamArtistSongs(ctx context.Context, id int, opts *ArtistSongsOptions) <-chan SongResult
+	ResumeArtistSongs(ctx context.Context, cursor Cursor, opts *ArtistSongsOptions) <-chan SongResult
+}
+
+var _ GeniusAPI = (*Client)(nil)
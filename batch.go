@@ -0,0 +1,93 @@
+package genius
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// BatchError is returned by batch lookups such as GetSongs when some of the IDs failed. The results of the other IDs
+// are returned along with it.
+type BatchError struct {
+	// Errors are the errors of the failed IDs by ID.
+	Errors map[int]error
+}
+
+func (e *BatchError) Error() string {
+	ids := make([]int, 0, len(e.Errors))
+	for id := range e.Errors {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	messages := make([]string, len(ids))
+	for i, id := range ids {
+		messages[i] = fmt.Sprintf("%d: %v", id, e.Errors[id])
+	}
+	return fmt.Sprintf("%d lookups failed: %s", len(ids), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the errors of the failed IDs, so errors.Is and errors.As match any of them.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// GetSongs fetches the songs with the IDs in parallel as far as the client's concurrency allows, see WithConcurrency,
+// with their text fields in the format, FormatDOM if empty. The songs are returned in the order of ids, nil for the
+// IDs that failed, which are reported by a *BatchError.
+func (c *Client) GetSongs(ctx context.Context, ids []int, format TextFormat) ([]*Song, error) {
+	return getBatch(ctx, c, ids, func(ctx context.Context, id int) (*Song, error) {
+		return c.GetSong(ctx, id, WithTextFormat(cmp.Or(format, FormatDOM)))
+	})
+}
+
+// GetArtists fetches the artists with the IDs like GetSongs, e.g. the primary artists collected from a crawl of
+// songs. Their text fields are in the dom format.
+func (c *Client) GetArtists(ctx context.Context, ids []int) ([]*Artist, error) {
+	return getBatch(ctx, c, ids, func(ctx context.Context, id int) (*Artist, error) {
+		response, err := c.GetArtist(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if response.Response.Artist == nil {
+			return nil, fmt.Errorf("no artist %d", id)
+		}
+		return response.Response.Artist, nil
+	})
+}
+
+// getBatch looks up the IDs with get using the client's concurrency. Unlike for other operations, a failing ID
+// doesn't cancel the others.
+func getBatch[T any](ctx context.Context, c *Client, ids []int, get func(ctx context.Context, id int) (T, error)) ([]T, error) {
+	results := make([]T, len(ids))
+	errs := make([]error, len(ids))
+
+	var g errgroup.Group
+	g.SetLimit(c.concurrency)
+	for i, id := range ids {
+		g.Go(func() error {
+			results[i], errs[i] = get(ctx, id)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	batchErr := &BatchError{Errors: map[int]error{}}
+	for i, err := range errs {
+		if err != nil {
+			batchErr.Errors[ids[i]] = err
+		}
+	}
+	if len(batchErr.Errors) > 0 {
+		return results, batchErr
+	}
+	return results, nil
+}
@@ -0,0 +1,93 @@
+package genius_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/natecham/genius"
+)
+
+func TestGetSongs(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			peak := atomic.LoadInt32(&maxInFlight)
+			if n <= peak || atomic.CompareAndSwapInt32(&maxInFlight, peak, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		var id int
+		if _, err := fmt.Sscanf(r.URL.Path, "/songs/%d", &id); err != nil || id%10 == 0 {
+			http.NotFound(w, r)
+			return
+		}
+		if format := r.URL.Query().Get("text_format"); format != "plain" {
+			t.Errorf("got text_format %q, want plain", format)
+		}
+		_, _ = fmt.Fprintf(w, `{"meta": {"status": 200}, "response": {"song": {"id": %d}}}`, id)
+	}))
+	defer server.Close()
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL), genius.WithConcurrency(4))
+
+	ids := []int{5, 3, 10, 1, 8, 2, 20, 7, 4, 6, 9, 11}
+	songs, err := client.GetSongs(context.Background(), ids, genius.FormatPlain)
+
+	var batchErr *genius.BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 2 || batchErr.Errors[10] == nil || batchErr.Errors[20] == nil {
+		t.Fatalf("got %v, want a BatchError for songs 10 and 20", err)
+	}
+	var statusErr *genius.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Errorf("got %v, want the status errors to be unwrapped", err)
+	}
+
+	if len(songs) != len(ids) {
+		t.Fatalf("got %d songs, want %d", len(songs), len(ids))
+	}
+	for i, id := range ids {
+		switch song := songs[i]; {
+		case id%10 == 0 && song != nil:
+			t.Errorf("got song %d for a failed ID", song.ID)
+		case id%10 != 0 && (song == nil || song.ID != id):
+			t.Errorf("got %v at %d, want song %d", song, i, id)
+		}
+	}
+	if peak := atomic.LoadInt32(&maxInFlight); peak < 2 || peak > 4 {
+		t.Errorf("got %d requests in parallel, want up to the concurrency of 4", peak)
+	}
+}
+
+func TestGetArtists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/artists/1", "/artists/2":
+			_, _ = fmt.Fprintf(w, `{"meta": {"status": 200}, "response": {"artist": {"id": %s, "name": "Artist %s"}}}`,
+				r.URL.Path[len("/artists/"):], r.URL.Path[len("/artists/"):])
+		case "/artists/3":
+			_, _ = w.Write([]byte(`{"meta": {"status": 200}, "response": {}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL), genius.WithConcurrency(2))
+
+	artists, err := client.GetArtists(context.Background(), []int{2, 3, 1})
+	var batchErr *genius.BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 1 || batchErr.Errors[3] == nil {
+		t.Fatalf("got %v, want a BatchError for artist 3", err)
+	}
+	if len(artists) != 3 || artists[0].Name != "Artist 2" || artists[1] != nil || artists[2].Name != "Artist 1" {
+		t.Errorf("unexpected artists %v", artists)
+	}
+}
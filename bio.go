@@ -0,0 +1,122 @@
+package genius
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// GetArtistBio returns the description of the artist with the ID rendered in the format, FormatPlain, FormatHTML or
+// FormatMarkdown. Artists without a description fall back to the body of their description annotation, "" is
+// returned if they have neither.
+func (c *Client) GetArtistBio(ctx context.Context, id int, format TextFormat) (string, error) {
+	if err := validateDescriptionFormat(format); err != nil {
+		return "", err
+	}
+
+	response, err := c.GetArtist(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return ArtistBio(response.Response.Artist, format), nil
+}
+
+// ArtistBio renders the description of artist, fetched in the dom format, see GetArtistBio.
+func ArtistBio(artist *Artist, format TextFormat) string {
+	if artist == nil {
+		return ""
+	}
+	return renderDescription(artist.Description, artist.DescriptionAnnotation, format)
+}
+
+// FetchDescription returns the description of the song rendered in the format, FormatPlain, FormatHTML or
+// FormatMarkdown, falling back to the body of its description annotation like GetArtistBio.
+//
+// The song is fetched with client unless it was fetched in the dom format, e.g. by GetSong, and the rendered
+// description is cached on songs decoded from Genius responses, so it can be called on demand, e.g. by UI code
+// rendering songs of ArtistSongs.
+func (s *Song) FetchDescription(ctx context.Context, client GeniusAPI, format TextFormat) (string, error) {
+	if err := validateDescriptionFormat(format); err != nil {
+		return "", err
+	}
+
+	if description, ok := s.descriptions.get(format); ok {
+		return description, nil
+	}
+
+	song := s
+	if s.Description == nil || s.Description.Dom == nil {
+		var err error
+		if song, err = client.GetSong(ctx, s.ID); err != nil {
+			return "", err
+		}
+	}
+	description := renderDescription(song.Description, song.DescriptionAnnotation, format)
+	s.descriptions.set(format, description)
+	return description, nil
+}
+
+// descriptionCache holds the descriptions of a song rendered by FetchDescription. It is shared by the copies of the
+// song, e.g. in the memo, and nil for songs that weren't decoded, which then aren't cached.
+type descriptionCache struct {
+	mu       sync.Mutex
+	rendered map[TextFormat]string
+}
+
+func (c *descriptionCache) get(format TextFormat) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	description, ok := c.rendered[format]
+	return description, ok
+}
+
+func (c *descriptionCache) set(format TextFormat, description string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.rendered == nil {
+		c.rendered = map[TextFormat]string{}
+	}
+	c.rendered[format] = description
+}
+
+func validateDescriptionFormat(format TextFormat) error {
+	switch format {
+	case FormatPlain, FormatHTML, FormatMarkdown:
+		return nil
+	default:
+		return fmt.Errorf("unsupported description format %q", string(format))
+	}
+}
+
+// renderDescription renders description, falling back to the bodies of the annotations of annotation. Genius
+// describes songs and artists without a description as "?", which is treated as missing.
+func renderDescription(description *Description, annotation *DescriptionAnnotation, format TextFormat) string {
+	if rendered := strings.TrimSpace(description.Render(format)); rendered != "" && rendered != "?" {
+		return rendered
+	}
+
+	if annotation == nil {
+		return ""
+	}
+	for _, annotation := range annotation.Annotations {
+		// The body is processed on a copy, the song or artist may be memoized.
+		body := annotation.WithBody
+		body.Process(FormatDOM)
+		if body.Dom == nil {
+			continue
+		}
+		if rendered := strings.TrimSpace((&Description{Dom: body.Dom}).Render(format)); rendered != "" && rendered != "?" {
+			return rendered
+		}
+	}
+	return ""
+}
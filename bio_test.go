@@ -0,0 +1,137 @@
+package genius_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+func TestGetArtistBio(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if format := req.URL.Query().Get("text_format"); format != "dom" {
+			t.Errorf("got text_format %q, want dom", format)
+		}
+		switch req.URL.Path {
+		case "/artists/1":
+			_, _ = w.Write([]byte(`{"meta": {"status": 200}, "response": {"artist": {"id": 1, "description": {"dom": {"tag": "root", "children": [
+				{"tag": "p", "children": [{"tag": "a", "attributes": {"href": "https://genius.com/artists/Kendrick-lamar"}, "children": ["Kendrick Lamar"]}, " is a rapper from ", {"tag": "em", "children": ["Compton"]}, "."]}
+			]}}}}}`))
+		case "/artists/2":
+			_, _ = w.Write([]byte(`{"meta": {"status": 200}, "response": {"artist": {"id": 2,
+				"description": {"dom": {"tag": "root", "children": ["?"]}},
+				"description_annotation": {"annotations": [{"body": {"dom": {"tag": "root", "children": [{"tag": "p", "children": [{"tag": "strong", "children": ["A band"]}]}]}}}]}}}}`))
+		case "/artists/3":
+			_, _ = w.Write([]byte(`{"meta": {"status": 200}, "response": {"artist": {"id": 3, "description": {"dom": {"tag": "root", "children": ["?"]}}}}}`))
+		default:
+			http.NotFound(w, req)
+		}
+	}))
+	defer server.Close()
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	tests := []struct {
+		id     int
+		format genius.TextFormat
+		want   string
+	}{
+		{1, genius.FormatPlain, "Kendrick Lamar is a rapper from Compton."},
+		{1, genius.FormatMarkdown, "[Kendrick Lamar](https://genius.com/artists/Kendrick-lamar) is a rapper from *Compton*."},
+		{1, genius.FormatHTML, `<p><a href="https://genius.com/artists/Kendrick-lamar">Kendrick Lamar</a> is a rapper from <em>Compton</em>.</p>`},
+		{2, genius.FormatMarkdown, "**A band**"},
+		{3, genius.FormatPlain, ""},
+	}
+	for _, test := range tests {
+		bio, err := client.GetArtistBio(context.Background(), test.id, test.format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bio != test.want {
+			t.Errorf("artist %d as %s: got %q, want %q", test.id, test.format, bio, test.want)
+		}
+	}
+
+	if _, err := client.GetArtistBio(context.Background(), 1, genius.FormatDOM); err == nil {
+		t.Error("expected an error for the dom format")
+	}
+}
+
+func TestSongFetchDescription(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/songs/1" {
+			http.NotFound(w, req)
+			return
+		}
+		requests++
+		_, _ = w.Write([]byte(`{"meta": {"status": 200}, "response": {"song": {"id": 1, "description": {"dom": {"tag": "root", "children": [
+			{"tag": "p", "children": ["The ", {"tag": "strong", "children": ["lead single"]}, "."]}
+		]}}}}}`))
+	}))
+	defer server.Close()
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	// Songs of lists come without a description.
+	song := &genius.Song{ID: 1}
+	for range 2 {
+		description, err := song.FetchDescription(context.Background(), client, genius.FormatMarkdown)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "The **lead single**."; description != want {
+			t.Errorf("got %q, want %q", description, want)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("got %d requests, want the song to be fetched once", requests)
+	}
+
+	// The song is memoized by the first call.
+	song, err := client.GetSong(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	description, err := song.FetchDescription(context.Background(), client, genius.FormatHTML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "<p>The <strong>lead single</strong>.</p>"; description != want {
+		t.Errorf("got %q, want %q", description, want)
+	}
+	if requests != 1 {
+		t.Errorf("got %d requests, want the song's description to be rendered without fetching it again", requests)
+	}
+
+	if _, err := song.FetchDescription(context.Background(), client, genius.FormatDOM); err == nil {
+		t.Error("expected an error for the dom format")
+	}
+}
+
+func TestSongFetchDescriptionConcurrently(t *testing.T) {
+	var song genius.Song
+	data := `{"id": 1, "description": {"dom": {"tag": "root", "children": ["Lead single."]}}}`
+	if err := json.Unmarshal([]byte(data), &song); err != nil {
+		t.Fatal(err)
+	}
+	// Copies of the song, like the memo's, share its cache.
+	memoized := song
+
+	var wg sync.WaitGroup
+	for _, song := range []*genius.Song{&song, &song, &memoized} {
+		for _, format := range []genius.TextFormat{genius.FormatPlain, genius.FormatHTML, genius.FormatMarkdown} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				description, err := song.FetchDescription(context.Background(), nil, format)
+				if err != nil || description != "Lead single." {
+					t.Errorf("%s: got %q, %v", format, description, err)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+}
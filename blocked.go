@@ -0,0 +1,58 @@
+package genius
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrBlocked is returned when genius.com refuses a page request or answers it with an anti-bot challenge, such as
+// Cloudflare's "Just a moment..." page, instead of the page. Use errors.As with a *BlockedError for the details.
+var ErrBlocked = errors.New("genius: blocked by anti-bot protection")
+
+// BlockedError is the error of a page request genius.com blocked, it matches ErrBlocked.
+type BlockedError struct {
+	// URL is the page that was requested.
+	URL string
+	// StatusCode is the status of the response, 200 for challenges served in place of the page.
+	StatusCode int
+}
+
+func (e *BlockedError) Error() string {
+	return fmt.Sprintf("%s: %s (status %d): slow down with WithRateLimit, spread requests over WithProxyPool, "+
+		"send browser session cookies with WithCookies or pass blocked pages to WithChallengeSolver",
+		ErrBlocked, e.URL, e.StatusCode)
+}
+
+func (e *BlockedError) Is(target error) bool {
+	return target == ErrBlocked
+}
+
+// challengeMarkers are strings found in anti-bot challenge pages. Some, like Cloudflare's challenge-platform
+// scripts, are also injected into regular pages, so they only identify challenges in pages without lyrics.
+var challengeMarkers = [][]byte{
+	[]byte("<title>Just a moment...</title>"),
+	[]byte("Attention Required! | Cloudflare"),
+	[]byte("cf_chl_opt"),
+	[]byte("cf-chl-"),
+	[]byte("cf-turnstile"),
+	[]byte("/cdn-cgi/challenge-platform/"),
+}
+
+// isChallenge reports whether page contains one of the challengeMarkers.
+func isChallenge(page []byte) bool {
+	for _, marker := range challengeMarkers {
+		if bytes.Contains(page, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// isBlocked reports whether a failed page request with statusCode and body was blocked: always for 403 Forbidden,
+// which genius.com only answers page requests with when it blocks them, and for challenges served with other
+// statuses, such as 503 Service Unavailable.
+func isBlocked(statusCode int, body []byte) bool {
+	return statusCode == http.StatusForbidden || isChallenge(body)
+}
@@ -0,0 +1,76 @@
+package genius_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+const challengePage = `<!DOCTYPE html><html lang="en-US"><head><title>Just a moment...</title></head><body>` +
+	`<div id="challenge-stage"></div><script>window._cf_chl_opt={cType: 'managed'};</script>` +
+	`<script src="/cdn-cgi/challenge-platform/h/g/orchestrate/chl_page/v1"></script></body></html>`
+
+func TestGetLyricsBlocked(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		blocked    bool
+	}{
+		{"forbidden", http.StatusForbidden, "Forbidden", true},
+		{"challenge", http.StatusOK, challengePage, true},
+		{"unavailable challenge", http.StatusServiceUnavailable, challengePage, true},
+		{"unavailable", http.StatusServiceUnavailable, "Service Unavailable", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			t.Cleanup(server.Close)
+
+			_, err := genius.NewClient(nil, "token").GetLyrics(context.Background(), server.URL+"/Kendrick-lamar-humble-lyrics")
+			if errors.Is(err, genius.ErrBlocked) != tt.blocked {
+				t.Fatalf("got error %v, blocked %t", err, tt.blocked)
+			}
+
+			var blocked *genius.BlockedError
+			if tt.blocked && (!errors.As(err, &blocked) || blocked.StatusCode != tt.statusCode) {
+				t.Errorf("expected a BlockedError with status %d, got %v", tt.statusCode, err)
+			}
+			if tt.blocked && !strings.Contains(err.Error(), "WithProxyPool") {
+				t.Errorf("expected guidance in %q", err)
+			}
+		})
+	}
+}
+
+func TestGetLyricsChallengeMarkersInPage(t *testing.T) {
+	page, err := os.ReadFile("testdata/extractor/verses.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	page = []byte(strings.Replace(string(page), "</body>",
+		`<script src="/cdn-cgi/challenge-platform/scripts/jsd/main.js"></script></body>`, 1))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(page)
+	}))
+	t.Cleanup(server.Close)
+
+	lyrics, err := genius.NewClient(nil, "token").GetLyrics(context.Background(), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(lyrics, "[Intro]") {
+		t.Errorf("unexpected lyrics %q", lyrics)
+	}
+}
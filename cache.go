@@ -0,0 +1,50 @@
+package genius
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Cache is a persistent lookup of artists, albums and songs the client consults before requesting them from Genius,
+// after its memoized results. Every store.Store is a Cache, so entities archived locally are served without requests.
+//
+// Lookups of entities that aren't cached return an error. A failing cache doesn't fail requests: lookup errors are
+// treated as misses and save errors are logged, see WithLogger.
+type Cache interface {
+	Song(ctx context.Context, id int) (*Song, error)
+	Album(ctx context.Context, id int) (*Album, error)
+	Artist(ctx context.Context, id int) (*Artist, error)
+
+	SaveSong(ctx context.Context, song *Song) error
+	SaveAlbum(ctx context.Context, album *Album) error
+	SaveArtist(ctx context.Context, artist *Artist) error
+}
+
+// WithCache makes GetArtist, GetAlbum and GetSong look up entities in cache before requesting them, and save the
+// ones they request to it.
+//
+// Only entities with text fields in the default dom format are cached, as the format isn't stored with them.
+// GetAlbum only serves cached albums with tracks when tracks are requested. Requests with WithRefresh skip the cache.
+func WithCache(cache Cache) ClientOption {
+	return func(client *Client) {
+		client.cache = cache
+	}
+}
+
+// cacheable reports whether the result of the memoized lookup is looked up in and saved to the cache.
+func (c *Client) cacheable(key memoKey) bool {
+	return c.cache != nil && key.textFormat == FormatDOM
+}
+
+// logCacheError logs failures to save to the cache.
+func (c *Client) logCacheError(ctx context.Context, key memoKey, err error) {
+	if err == nil || c.logger == nil {
+		return
+	}
+
+	c.logger.LogAttrs(ctx, slog.LevelWarn, "genius cache error",
+		slog.String("endpoint", key.endpoint),
+		slog.Int("id", key.id),
+		slog.String("error", err.Error()),
+	)
+}
@@ -0,0 +1,90 @@
+package genius_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+// mapCache is a genius.Cache of songs, it doesn't cache artists or albums.
+type mapCache struct {
+	mu    sync.Mutex
+	songs map[int]*genius.Song
+}
+
+var errNotCached = errors.New("not cached")
+
+func (c *mapCache) Song(ctx context.Context, id int) (*genius.Song, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	song, ok := c.songs[id]
+	if !ok {
+		return nil, errNotCached
+	}
+	return song, nil
+}
+
+func (c *mapCache) SaveSong(ctx context.Context, song *genius.Song) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.songs[song.ID] = song
+	return nil
+}
+
+func (c *mapCache) Album(ctx context.Context, id int) (*genius.Album, error) {
+	return nil, errNotCached
+}
+func (c *mapCache) Artist(ctx context.Context, id int) (*genius.Artist, error) {
+	return nil, errNotCached
+}
+func (c *mapCache) SaveAlbum(ctx context.Context, album *genius.Album) error    { return nil }
+func (c *mapCache) SaveArtist(ctx context.Context, artist *genius.Artist) error { return nil }
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	server, requests := newCountingSongServer(t)
+	cache := &mapCache{songs: map[int]*genius.Song{2: {ID: 2, Title: "Cached"}}}
+
+	newClient := func() *genius.Client {
+		return genius.NewClient(nil, "token", genius.WithBaseURL(server.URL), genius.WithCache(cache))
+	}
+	client := newClient()
+
+	song, err := client.GetSong(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if song.Title != "Cached" || atomic.LoadInt32(requests) != 0 {
+		t.Fatalf("cached song not served: %+v after %d requests", song, atomic.LoadInt32(requests))
+	}
+
+	if _, err = client.GetSong(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadInt32(requests) != 1 {
+		t.Fatalf("expected a request for the uncached song, got %d", atomic.LoadInt32(requests))
+	}
+
+	// A new client has no memoized results, the song saved to the cache is served.
+	client = newClient()
+	if song, err = client.GetSong(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if song.Title != "HUMBLE." || atomic.LoadInt32(requests) != 1 {
+		t.Fatalf("saved song not served: %+v after %d requests", song, atomic.LoadInt32(requests))
+	}
+
+	// The cache only holds the default text format.
+	if _, err = client.GetSong(ctx, 1, genius.WithTextFormat(genius.FormatPlain)); err != nil {
+		t.Fatal(err)
+	}
+	if atomic.LoadInt32(requests) != 2 {
+		t.Fatalf("expected a request for another text format, got %d", atomic.LoadInt32(requests))
+	}
+}
@@ -0,0 +1,41 @@
+package genius
+
+import (
+	"context"
+	"fmt"
+)
+
+// ChallengeSolver fetches pages genius.com blocked, see ErrBlocked, by means outside of this package, such as a
+// FlareSolverr instance or a headless browser that passes the anti-bot challenge. Solve returns the HTML of the page
+// at blocked.URL, whose lyrics are then extracted as if it had been fetched directly.
+type ChallengeSolver interface {
+	Solve(ctx context.Context, blocked *BlockedError) (string, error)
+}
+
+// ChallengeSolverFunc adapts a function to a ChallengeSolver.
+type ChallengeSolverFunc func(ctx context.Context, blocked *BlockedError) (string, error)
+
+// Solve calls f.
+func (f ChallengeSolverFunc) Solve(ctx context.Context, blocked *BlockedError) (string, error) {
+	return f(ctx, blocked)
+}
+
+// WithChallengeSolver makes GetLyrics pass song pages that are blocked to solver, if falling back to the AMP
+// page didn't help. The desktop page is passed if it was blocked, else the AMP page. Solve is called with the context
+// of GetLyrics, so the solver stops when the caller cancels.
+func WithChallengeSolver(solver ChallengeSolver) ClientOption {
+	return func(client *Client) {
+		client.solver = solver
+	}
+}
+
+// solveChallenge extracts the lyrics of the page of blocked from the HTML of the client's ChallengeSolver. Errors of
+// the solver are returned together with blocked.
+func (c *Client) solveChallenge(ctx context.Context, blocked *BlockedError) (string, error) {
+	page, err := c.solver.Solve(ctx, blocked)
+	if err != nil {
+		return "", fmt.Errorf("%w: challenge solver: %w", blocked, err)
+	}
+
+	return extractLyrics(blocked.URL, blocked.StatusCode, []byte(page))
+}
@@ -0,0 +1,130 @@
+package genius_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+func newBlockedServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(challengePage))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestChallengeSolver(t *testing.T) {
+	page, err := os.ReadFile("testdata/extractor/verses.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := newBlockedServer(t)
+	uri := server.URL + "/Kendrick-lamar-humble-lyrics"
+
+	var solved []string
+	solver := genius.ChallengeSolverFunc(func(ctx context.Context, blocked *genius.BlockedError) (string, error) {
+		solved = append(solved, blocked.URL)
+		return string(page), nil
+	})
+
+	lyrics, err := genius.NewClient(nil, "token", genius.WithChallengeSolver(solver)).GetLyrics(context.Background(), uri)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(lyrics, "[Intro]\nNobody pray for me") {
+		t.Errorf("unexpected lyrics %q", lyrics)
+	}
+	if len(solved) != 1 || solved[0] != uri {
+		t.Errorf("expected the desktop page to be solved, got %q", solved)
+	}
+}
+
+func TestChallengeSolverFails(t *testing.T) {
+	server := newBlockedServer(t)
+	errSolver := errors.New("solver unavailable")
+
+	tests := map[string]genius.ChallengeSolverFunc{
+		"error": func(ctx context.Context, blocked *genius.BlockedError) (string, error) {
+			return "", errSolver
+		},
+		"challenge": func(ctx context.Context, blocked *genius.BlockedError) (string, error) {
+			return challengePage, nil
+		},
+	}
+
+	for name, solver := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := genius.NewClient(nil, "token", genius.WithChallengeSolver(solver))
+
+			_, err := client.GetLyrics(context.Background(), server.URL+"/Kendrick-lamar-humble-lyrics")
+			if !errors.Is(err, genius.ErrBlocked) {
+				t.Fatalf("expected ErrBlocked, got %v", err)
+			}
+			if name == "error" && !errors.Is(err, errSolver) {
+				t.Errorf("expected the solver's error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestChallengeSolverContext(t *testing.T) {
+	server := newBlockedServer(t)
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "caller")
+
+	solver := genius.ChallengeSolverFunc(func(ctx context.Context, blocked *genius.BlockedError) (string, error) {
+		if ctx.Value(key{}) != "caller" {
+			t.Error("solver not called with the caller's context")
+		}
+		return "", errors.New("solver failed")
+	})
+	client := genius.NewClient(nil, "token", genius.WithChallengeSolver(solver))
+
+	if _, err := client.GetLyrics(ctx, server.URL+"/Kendrick-lamar-humble-lyrics"); !errors.Is(err, genius.ErrBlocked) {
+		t.Fatalf("expected ErrBlocked, got %v", err)
+	}
+}
+
+func TestChallengeSolverBlockedAMP(t *testing.T) {
+	page, err := os.ReadFile("testdata/extractor/verses.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/amp/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	t.Cleanup(server.Close)
+
+	var solved []string
+	solver := genius.ChallengeSolverFunc(func(ctx context.Context, blocked *genius.BlockedError) (string, error) {
+		solved = append(solved, blocked.URL)
+		return string(page), nil
+	})
+	client := genius.NewClient(nil, "token", genius.WithChallengeSolver(solver))
+
+	lyrics, err := client.GetLyrics(context.Background(), server.URL+"/Kendrick-lamar-humble-lyrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(lyrics, "[Intro]") {
+		t.Errorf("unexpected lyrics %q", lyrics)
+	}
+	if want := server.URL + "/amp/Kendrick-lamar-humble-lyrics"; len(solved) != 1 || solved[0] != want {
+		t.Errorf("expected the blocked AMP page to be solved, got %q", solved)
+	}
+}
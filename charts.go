@@ -0,0 +1,166 @@
+package genius
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/url"
+)
+
+// ChartType is what a chart ranks.
+type ChartType string
+
+const (
+	ChartSongs     ChartType = "songs"
+	ChartAlbums    ChartType = "albums"
+	ChartArtists   ChartType = "artists"
+	ChartReferents ChartType = "referents"
+)
+
+// ChartPeriod is the time period a chart ranks by.
+type ChartPeriod string
+
+const (
+	ChartDay     ChartPeriod = "day"
+	ChartWeek    ChartPeriod = "week"
+	ChartMonth   ChartPeriod = "month"
+	ChartAllTime ChartPeriod = "all_time"
+)
+
+// ChartGenre is the genre a chart is limited to.
+type ChartGenre string
+
+const (
+	ChartAllGenres ChartGenre = "all"
+	ChartRap       ChartGenre = "rap"
+	ChartPop       ChartGenre = "pop"
+	ChartRB        ChartGenre = "rb"
+	ChartRock      ChartGenre = "rock"
+	ChartCountry   ChartGenre = "country"
+	ChartNonMusic  ChartGenre = "non-music"
+)
+
+// ErrInvalidChart is returned for chart options the API doesn't support.
+var ErrInvalidChart = errors.New("unsupported chart")
+
+// ChartOptions configure fetching a chart.
+type ChartOptions struct {
+	ListOptions
+
+	// Type is what the chart ranks, ChartSongs when empty.
+	Type ChartType
+	// Period is the time period the chart ranks by, ChartDay when empty.
+	Period ChartPeriod
+	// Genre limits the chart to a genre, ChartAllGenres when empty.
+	Genre ChartGenre
+}
+
+// validate reports unsupported values and fills in the defaults.
+func (o *ChartOptions) validate() error {
+	if o.Type == "" {
+		o.Type = ChartSongs
+	}
+	if o.Period == "" {
+		o.Period = ChartDay
+	}
+	if o.Genre == "" {
+		o.Genre = ChartAllGenres
+	}
+
+	switch o.Type {
+	case ChartSongs, ChartAlbums, ChartArtists, ChartReferents:
+	default:
+		return fmt.Errorf("%w type %q", ErrInvalidChart, string(o.Type))
+	}
+	switch o.Period {
+	case ChartDay, ChartWeek, ChartMonth, ChartAllTime:
+	default:
+		return fmt.Errorf("%w period %q", ErrInvalidChart, string(o.Period))
+	}
+	switch o.Genre {
+	case ChartAllGenres, ChartRap, ChartPop, ChartRB, ChartRock, ChartCountry, ChartNonMusic:
+	default:
+		return fmt.Errorf("%w genre %q", ErrInvalidChart, string(o.Genre))
+	}
+	return nil
+}
+
+// ChartItemReferent is the type of the items of referent charts.
+const ChartItemReferent = "referent"
+
+// ChartItem is an entry of a chart, Item depends on Type, use the As* accessors to decode it.
+type ChartItem struct {
+	// Type is the type of the item, a hit type such as HitTypeSong or ChartItemReferent.
+	Type string          `json:"type"`
+	Item json.RawMessage `json:"item"`
+}
+
+// AsSong decodes the item of a song chart.
+func (i *ChartItem) AsSong() (*Song, error) {
+	if i.Type != HitTypeSong {
+		return nil, fmt.Errorf("%w: %s, not song", ErrHitType, i.Type)
+	}
+	return decodeResult[Song](i.Item)
+}
+
+// AsAlbum decodes the item of an album chart.
+func (i *ChartItem) AsAlbum() (*Album, error) {
+	if i.Type != HitTypeAlbum {
+		return nil, fmt.Errorf("%w: %s, not album", ErrHitType, i.Type)
+	}
+	return decodeResult[Album](i.Item)
+}
+
+// AsArtist decodes the item of an artist chart.
+func (i *ChartItem) AsArtist() (*Artist, error) {
+	if i.Type != HitTypeArtist {
+		return nil, fmt.Errorf("%w: %s, not artist", ErrHitType, i.Type)
+	}
+	return decodeResult[Artist](i.Item)
+}
+
+// AsReferent decodes the item of a referent chart.
+func (i *ChartItem) AsReferent() (*Referent, error) {
+	if i.Type != ChartItemReferent {
+		return nil, fmt.Errorf("%w: %s, not referent", ErrHitType, i.Type)
+	}
+	return decodeResult[Referent](i.Item)
+}
+
+// Chart lazily iterates over the items of a Genius chart in rank order, opts may be nil for the daily song chart.
+// Charts are served by the unofficial genius.com API.
+func (c *Client) Chart(ctx context.Context, opts *ChartOptions) iter.Seq2[*ChartItem, error] {
+	var chart ChartOptions
+	if opts != nil {
+		chart = *opts
+	}
+	if err := chart.validate(); err != nil {
+		return func(yield func(*ChartItem, error) bool) {
+			yield(nil, err)
+		}
+	}
+
+	return paginate(ctx, c.listOptions(&chart.ListOptions), defaultPerPage, func(ctx context.Context, page int, perPage int) (*Page[*ChartItem], error) {
+		return c.getChartPage(ctx, &chart, perPage, page)
+	})
+}
+
+// GetChart returns the items of a Genius chart, see Chart.
+func (c *Client) GetChart(ctx context.Context, opts *ChartOptions) ([]*ChartItem, error) {
+	var items []*ChartItem
+	for item, err := range c.Chart(ctx, opts) {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func (c *Client) getChartPage(ctx context.Context, opts *ChartOptions, perPage int, page int) (*Page[*ChartItem], error) {
+	params := url.Values{"time_period": {string(opts.Period)}, "chart_genre": {string(opts.Genre)}}
+	return getPage[*ChartItem](ctx, c, c.unofficialUrl+"/"+string(opts.Type)+"/chart", params, "chart_items", perPage, page)
+}
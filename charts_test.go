@@ -0,0 +1,69 @@
+package genius_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+func TestChart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/songs/chart" || query.Get("time_period") != "week" || query.Get("chart_genre") != "rap" {
+			http.Error(w, "unexpected request "+r.URL.String(), http.StatusBadRequest)
+			return
+		}
+
+		items := []map[string]any{}
+		if query.Get("page") == "1" {
+			for id := 1; id <= 3; id++ {
+				items = append(items, map[string]any{"type": "song", "item": map[string]any{"id": id}})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"meta":     map[string]any{"status": 200},
+			"response": map[string]any{"chart_items": items, "next_page": nil},
+		})
+	}))
+	t.Cleanup(server.Close)
+	client := genius.NewClient(nil, "token", genius.WithUnofficialURL(server.URL))
+
+	items, err := client.GetChart(context.Background(), &genius.ChartOptions{Period: genius.ChartWeek, Genre: genius.ChartRap})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 3 {
+		t.Fatalf("got %d items, want 3", len(items))
+	}
+	for i, item := range items {
+		song, err := item.AsSong()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if song.ID != i+1 {
+			t.Errorf("item %d is song %d, want %d", i, song.ID, i+1)
+		}
+		if _, err = item.AsAlbum(); !errors.Is(err, genius.ErrHitType) {
+			t.Errorf("expected ErrHitType decoding a song as an album, got %v", err)
+		}
+	}
+}
+
+func TestChartInvalidOptions(t *testing.T) {
+	client := genius.NewClient(nil, "token", genius.WithUnofficialURL("http://127.0.0.1:0"))
+
+	for _, opts := range []genius.ChartOptions{
+		{Type: "playlists"},
+		{Period: "year"},
+		{Genre: "jazz"},
+	} {
+		if _, err := client.GetChart(context.Background(), &opts); !errors.Is(err, genius.ErrInvalidChart) {
+			t.Errorf("expected ErrInvalidChart for %+v, got %v", opts, err)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package genius
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// latencySamples is how many of the most recent latencies per endpoint percentiles are computed from.
+const latencySamples = 1024
+
+// EndpointStats are the request statistics of one endpoint, see Client.Stats.
+type EndpointStats struct {
+	// Requests is the number of requests made, retries of a request aren't counted separately.
+	Requests int
+	// Errors is the number of requests that failed.
+	Errors int
+	// P50 and P95 are the median and 95th percentile latency of recent requests, including time spent on retries.
+	P50 time.Duration
+	P95 time.Duration
+}
+
+type endpointCounters struct {
+	requests  int
+	errors    int
+	latencies []time.Duration
+	next      int
+}
+
+// requestStats collects EndpointStats in memory.
+type requestStats struct {
+	mu        sync.Mutex
+	endpoints map[string]*endpointCounters
+}
+
+func newRequestStats() *requestStats {
+	return &requestStats{endpoints: make(map[string]*endpointCounters)}
+}
+
+func (s *requestStats) record(endpoint string, duration time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counters, ok := s.endpoints[endpoint]
+	if !ok {
+		counters = &endpointCounters{}
+		s.endpoints[endpoint] = counters
+	}
+
+	counters.requests++
+	if err != nil {
+		counters.errors++
+	}
+
+	// Latencies are kept in a ring buffer, overwriting the oldest sample once it is full.
+	if len(counters.latencies) < latencySamples {
+		counters.latencies = append(counters.latencies, duration)
+	} else {
+		counters.latencies[counters.next] = duration
+		counters.next = (counters.next + 1) % latencySamples
+	}
+}
+
+// Stats returns request statistics of the client by endpoint, with IDs in paths replaced by ":id", e.g.
+// "/artists/:id/songs". They are kept in memory since the client was created and are useful to spot slow endpoints in
+// batch jobs.
+func (c *Client) Stats() map[string]EndpointStats {
+	c.stats.mu.Lock()
+	defer c.stats.mu.Unlock()
+
+	stats := make(map[string]EndpointStats, len(c.stats.endpoints))
+	for endpoint, counters := range c.stats.endpoints {
+		latencies := slices.Clone(counters.latencies)
+		slices.Sort(latencies)
+
+		stats[endpoint] = EndpointStats{
+			Requests: counters.requests,
+			Errors:   counters.errors,
+			P50:      percentile(latencies, 0.5),
+			P95:      percentile(latencies, 0.95),
+		}
+	}
+
+	return stats
+}
+
+// percentile returns the p-th percentile of sorted latencies using the nearest rank.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := int(p*float64(len(sorted))+0.5) - 1
+	return sorted[max(0, min(rank, len(sorted)-1))]
+}
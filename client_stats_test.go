@@ -0,0 +1,34 @@
+package genius_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+func TestClientStats(t *testing.T) {
+	server := newFlakyServer(t, http.StatusNotFound, 1)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	if _, err := client.GetSong(context.Background(), 1); err == nil {
+		t.Fatal("expected the first request to fail")
+	}
+	for id := 2; id <= 3; id++ {
+		if _, err := client.GetSong(context.Background(), id); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stats, ok := client.Stats()["/songs/:id"]
+	if !ok {
+		t.Fatalf("no stats for /songs/:id in %v", client.Stats())
+	}
+	if stats.Requests != 3 || stats.Errors != 1 {
+		t.Errorf("got %d requests and %d errors, want 3 and 1", stats.Requests, stats.Errors)
+	}
+	if stats.P50 <= 0 || stats.P95 < stats.P50 {
+		t.Errorf("unexpected latencies p50 %s, p95 %s", stats.P50, stats.P95)
+	}
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/export"
+	"golang.org/x/sync/errgroup"
+)
+
+var albumCommand = &command{
+	name:   "album",
+	usage:  "[-out dir] [-format txt|json|lrc] [-concurrency n] [-json | -ndjson] <id|url>",
+	short:  "Download the lyrics of an album's tracks to files",
+	output: true,
+	run:    runAlbum,
+}
+
+// albumFile is a file written by album as printed with -json.
+type albumFile struct {
+	Path string `json:"path"`
+}
+
+// jsonPathTemplate lays out JSON files like export.DefaultPathTemplate, with the .json extension.
+const jsonPathTemplate = `{{.Artist}}/{{.Album}}/{{if .Number}}{{printf "%02d" .Number}} - {{end}}{{.Title}}.json`
+
+func runAlbum(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	out := fs.String("out", ".", "the `dir` the files are written to")
+	format := fs.String("format", "txt", "the `format` of the files, txt, json or lrc")
+	defaultConcurrency := 4
+	if a.config.Concurrency > 0 {
+		defaultConcurrency = a.config.Concurrency
+	}
+	concurrency := fs.Int("concurrency", defaultConcurrency, "fetch the lyrics of `n` tracks at a time")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 || *concurrency < 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	var write func(tracks []export.Track) ([]string, error)
+	switch *format {
+	case "txt":
+		write = func(tracks []export.Track) ([]string, error) { return export.Text(*out, tracks, nil) }
+	case "lrc":
+		write = func(tracks []export.Track) ([]string, error) { return export.LRCFiles(*out, tracks, nil) }
+	case "json":
+		write = func(tracks []export.Track) ([]string, error) {
+			return export.TemplateFiles(*out, jsonSong{}, tracks, &export.TextOptions{PathTemplate: jsonPathTemplate})
+		}
+	default:
+		fs.Usage()
+		return errUsage
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	album, err := getAlbum(ctx, client, args[0])
+	if err != nil {
+		return err
+	}
+
+	tracks := export.FromAlbum(album)
+	failed := fetchLyrics(ctx, client, tracks, *concurrency, newProgress(a.stderr, "Fetching lyrics", len(tracks)))
+	for _, err := range failed {
+		fmt.Fprintln(a.stderr, "genius:", err)
+	}
+
+	paths, err := write(tracks)
+	files := make([]albumFile, len(paths))
+	for i, path := range paths {
+		files[i] = albumFile{Path: path}
+	}
+	printErr := printList(a, files, func(table *tabwriter.Writer) {
+		for _, file := range files {
+			fmt.Fprintln(table, file.Path)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if printErr != nil {
+		return printErr
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("fetching the lyrics of %d of %d tracks failed", len(failed), len(tracks))
+	}
+	return nil
+}
+
+// getAlbum returns the album with its tracks, arg is its ID or genius.com URL.
+func getAlbum(ctx context.Context, client *genius.Client, arg string) (*genius.Album, error) {
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		album, err := client.GetAlbumByPath(ctx, arg)
+		if err != nil {
+			return nil, err
+		}
+		id = album.ID
+	}
+	return client.GetAlbum(ctx, id, true)
+}
+
+// fetchLyrics sets the lyrics of tracks, concurrency at a time, and returns the errors of tracks whose lyrics
+// couldn't be fetched. Those are left without lyrics.
+func fetchLyrics(ctx context.Context, client *genius.Client, tracks []export.Track, concurrency int, p *progress) []error {
+	defer p.finish()
+
+	var g errgroup.Group
+	g.SetLimit(concurrency)
+
+	errs := make([]error, len(tracks))
+	for i, track := range tracks {
+		g.Go(func() error {
+			defer p.add(track.Title)
+			if err := ctx.Err(); err != nil {
+				errs[i] = err
+				return nil
+			}
+
+			lyrics, err := client.GetLyrics(ctx, track.URL)
+			if err != nil {
+				errs[i] = fmt.Errorf("song %d %q: %w", track.ID, track.Title, err)
+				return nil
+			}
+			track.Lyrics = lyrics
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	var failed []error
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	return failed
+}
+
+// jsonSong is an export.Executor writing a track's song, with its lyrics, as indented JSON.
+type jsonSong struct{}
+
+func (jsonSong) Execute(w io.Writer, data any) error {
+	d, ok := data.(export.TemplateData)
+	if !ok {
+		return errors.New("not export.TemplateData")
+	}
+	return writeJSON(w, d.Song)
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// albumHandler serves DAMN. with two tracks, the lyrics of the second failing if failSecond is set.
+func albumHandler(t *testing.T, failSecond bool) http.Handler {
+	t.Helper()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		track := func(number int, title string) map[string]any {
+			return map[string]any{"number": number, "song": map[string]any{
+				"id":             number,
+				"title":          title,
+				"primary_artist": map[string]any{"name": "Kendrick Lamar"},
+				"url":            fmt.Sprintf("http://%s/lyrics/%d", r.Host, number),
+			}}
+		}
+
+		switch r.URL.Path {
+		case "/page_data/album":
+			if r.URL.Query().Get("page_path") != "/albums/Kendrick-lamar/Damn" {
+				http.NotFound(w, r)
+				return
+			}
+			writeResponse(w, map[string]any{"page_data": map[string]any{"album": map[string]any{"id": 7}}})
+		case "/albums/7":
+			writeResponse(w, map[string]any{"album": map[string]any{"id": 7, "name": "DAMN."}})
+		case "/albums/7/tracks":
+			writeResponse(w, map[string]any{"tracks": []any{track(1, "BLOOD."), track(2, "DNA.")}, "next_page": nil})
+		case "/lyrics/1":
+			fmt.Fprint(w, `<div id="lyrics-root"><div data-lyrics-container="true">Is it wickedness?</div></div>`)
+		case "/lyrics/2":
+			if failSecond {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+				return
+			}
+			fmt.Fprint(w, `<div id="lyrics-root"><div data-lyrics-container="true">I got loyalty, got royalty</div></div>`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+func TestAlbum(t *testing.T) {
+	ta := newTestApp(t, albumHandler(t, false))
+	out := t.TempDir()
+
+	if err := ta.run(t, "album", "-out", out, "7"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(out, "Kendrick Lamar", "DAMN", "02 - DNA.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "I got loyalty, got royalty\n" {
+		t.Errorf("unexpected lyrics %q", data)
+	}
+	if paths := strings.Split(strings.TrimSpace(ta.stdout.String()), "\n"); len(paths) != 2 {
+		t.Errorf("expected the 2 written paths printed, got %q", ta.stdout)
+	}
+}
+
+func TestAlbumJSONByURL(t *testing.T) {
+	ta := newTestApp(t, albumHandler(t, false))
+	out := t.TempDir()
+
+	if err := ta.run(t, "album", "-format", "json", "-out", out, "https://genius.com/albums/Kendrick-lamar/Damn"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(out, "Kendrick Lamar", "DAMN", "01 - BLOOD.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var song struct {
+		ID     int    `json:"id"`
+		Lyrics string `json:"lyrics"`
+	}
+	if err = json.Unmarshal(data, &song); err != nil {
+		t.Fatal(err)
+	}
+	if song.ID != 1 || song.Lyrics != "Is it wickedness?" {
+		t.Errorf("unexpected song %+v", song)
+	}
+}
+
+func TestAlbumFailedTracks(t *testing.T) {
+	ta := newTestApp(t, albumHandler(t, true))
+	out := t.TempDir()
+
+	if err := ta.run(t, "album", "-out", out, "7"); err == nil {
+		t.Fatal("expected an error for the failed track")
+	}
+	if _, err := os.Stat(filepath.Join(out, "Kendrick Lamar", "DAMN", "01 - BLOOD.txt")); err != nil {
+		t.Errorf("expected the other tracks to be written: %v", err)
+	}
+	if !strings.Contains(ta.stderr.String(), `"DNA."`) {
+		t.Errorf("expected the failed track to be reported, got %q", ta.stderr)
+	}
+}
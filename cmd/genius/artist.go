@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/store"
+)
+
+var artistCommand = &command{
+	name:   "artist",
+	usage:  "sync -store path [-json | -ndjson] <id|name>",
+	short:  "Sync an artist's songs and lyrics into a local store",
+	output: true,
+	run:    runArtist,
+}
+
+// syncResult is the report of an artist sync as printed by artist sync with -json.
+type syncResult struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Listed int    `json:"listed"`
+	// New and Updated are the songs the sync stored, without a title if fetching them failed.
+	New       []syncedSong `json:"new"`
+	Updated   []syncedSong `json:"updated"`
+	Unchanged int          `json:"unchanged"`
+	// Error is why the sync stopped early.
+	Error string `json:"error,omitempty"`
+}
+
+type syncedSong struct {
+	ID    int    `json:"id"`
+	Title string `json:"title,omitempty"`
+}
+
+func runArtist(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	path := fs.String("store", "genius.db", "the `path` of the store, bbolt for .bolt files and SQLite otherwise")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) < 2 || args[0] != "sync" {
+		fs.Usage()
+		return errUsage
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	artist, err := findArtist(ctx, client, strings.Join(args[1:], " "))
+	if err != nil {
+		return err
+	}
+
+	s, err := openStore(*path)
+	if err != nil {
+		return err
+	}
+	defer s.Close()
+
+	report, syncErr := store.SyncArtist(ctx, client, s, artist.ID)
+	if err = printSyncReport(ctx, a, s, artist, report, syncErr); err != nil {
+		return err
+	}
+	if syncErr != nil {
+		return fmt.Errorf("sync stopped, run it again to continue: %w", syncErr)
+	}
+	return nil
+}
+
+// findArtist returns the artist arg is the ID or name of.
+func findArtist(ctx context.Context, client *genius.Client, arg string) (*genius.Artist, error) {
+	if id, err := strconv.Atoi(arg); err == nil {
+		response, err := client.GetArtist(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if response.Response.Artist == nil {
+			return nil, fmt.Errorf("no artist %d", id)
+		}
+		return response.Response.Artist, nil
+	}
+
+	response, err := client.WebSearch(ctx, 5, arg)
+	if err != nil {
+		return nil, err
+	}
+	return genius.GetArtistFromSearchResponse(response, arg)
+}
+
+// printSyncReport prints what the sync of artist changed, with the titles of the synced songs.
+func printSyncReport(ctx context.Context, a *app, s store.Store, artist *genius.Artist, report *store.SyncReport, syncErr error) error {
+	result := syncResult{ID: artist.ID, Name: artist.Name, Listed: report.Listed, Unchanged: report.Unchanged}
+	var err error
+	if result.New, err = syncedSongs(ctx, s, report.New); err != nil {
+		return err
+	}
+	if result.Updated, err = syncedSongs(ctx, s, report.Updated); err != nil {
+		return err
+	}
+	if syncErr != nil {
+		result.Error = syncErr.Error()
+	}
+
+	return printValue(a, result, func(w io.Writer) error {
+		fmt.Fprintf(w, "%s (%d): %d songs listed, %d new, %d updated, %d unchanged\n",
+			result.Name, result.ID, result.Listed, len(result.New), len(result.Updated), result.Unchanged)
+
+		table := newTable(w)
+		for _, changes := range []struct {
+			name  string
+			songs []syncedSong
+		}{{"new", result.New}, {"updated", result.Updated}} {
+			for _, song := range changes.songs {
+				title := song.Title
+				if title == "" {
+					title = "(failed)"
+				}
+				fmt.Fprintf(table, "%s\t%d\t%s\n", changes.name, song.ID, title)
+			}
+		}
+		if result.Error != "" {
+			fmt.Fprintf(table, "failed\t\t%s\n", result.Error)
+		}
+		return table.Flush()
+	})
+}
+
+// syncedSongs returns the songs with ids from s, without a title if they aren't stored.
+func syncedSongs(ctx context.Context, s store.Store, ids []int) ([]syncedSong, error) {
+	songs := []syncedSong{}
+	for _, id := range ids {
+		song, err := s.Song(ctx, id)
+		switch {
+		case err == nil:
+			songs = append(songs, syncedSong{ID: id, Title: song.Title})
+		case errors.Is(err, store.ErrNotFound):
+			songs = append(songs, syncedSong{ID: id})
+		default:
+			return nil, err
+		}
+	}
+	return songs, nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// artistHandler serves an artist with two songs whose lyrics can be fetched.
+func artistHandler(t *testing.T) http.Handler {
+	t.Helper()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		song := func(id int, title string) map[string]any {
+			return map[string]any{"id": id, "title": title, "url": fmt.Sprintf("http://%s/lyrics/%d", r.Host, id)}
+		}
+
+		switch r.URL.Path {
+		case "/search/multi":
+			writeResponse(w, map[string]any{"sections": []map[string]any{{"type": "artist", "hits": []map[string]any{
+				{"type": "artist", "result": map[string]any{"id": 1, "name": "Kendrick Lamar"}},
+			}}}})
+		case "/artists/1":
+			writeResponse(w, map[string]any{"artist": map[string]any{"id": 1, "name": "Kendrick Lamar"}})
+		case "/artists/1/songs":
+			writeResponse(w, map[string]any{"songs": []any{song(1, "HUMBLE."), song(2, "DNA.")}, "next_page": nil})
+		case "/songs/1":
+			writeResponse(w, map[string]any{"song": song(1, "HUMBLE.")})
+		case "/songs/2":
+			writeResponse(w, map[string]any{"song": song(2, "DNA.")})
+		case "/lyrics/1", "/lyrics/2":
+			fmt.Fprint(w, `<div id="lyrics-root"><div data-lyrics-container="true">Lyrics</div></div>`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+func TestArtistSync(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "genius.db")
+
+	ta := newTestApp(t, artistHandler(t))
+	if err := ta.run(t, "artist", "sync", "-store", path, "Kendrick Lamar"); err != nil {
+		t.Fatal(err)
+	}
+	out := ta.stdout.String()
+	if !strings.HasPrefix(out, "Kendrick Lamar (1): 2 songs listed, 2 new, 0 updated, 0 unchanged\n") {
+		t.Errorf("unexpected report %q", out)
+	}
+	if !strings.Contains(out, "HUMBLE.") || !strings.Contains(out, "DNA.") {
+		t.Errorf("expected the new songs' titles, got %q", out)
+	}
+
+	ta = newTestApp(t, artistHandler(t))
+	if err := ta.run(t, "artist", "sync", "-store", path, "1"); err != nil {
+		t.Fatal(err)
+	}
+	if want := "Kendrick Lamar (1): 2 songs listed, 0 new, 0 updated, 2 unchanged\n"; ta.stdout.String() != want {
+		t.Errorf("expected %q on the second sync, got %q", want, ta.stdout)
+	}
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+var authCommand = &command{
+	name:   "auth",
+	usage:  "login [-client-id id -client-secret secret [-redirect-uri uri]] | logout | status [-json | -ndjson]",
+	short:  "Store the access token the other commands use",
+	output: true,
+	run:    runAuth,
+}
+
+// authResult is the status printed by auth status with -json.
+type authResult struct {
+	LoggedIn bool `json:"logged_in"`
+	// Source is where the token comes from, -token, GENIUS_TOKEN or the path of the stored token.
+	Source string `json:"source,omitempty"`
+}
+
+// defaultOAuthURL is the base URL of the Genius OAuth endpoints.
+const defaultOAuthURL = "https://api.genius.com/oauth"
+
+func runAuth(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	clientID := fs.String("client-id", "", "log in with the OAuth flow of the API client with `id`")
+	clientSecret := fs.String("client-secret", "", "the `secret` of the API client")
+	redirectURI := fs.String("redirect-uri", "http://127.0.0.1:8085/callback", "the redirect `uri` registered for the API client")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 || (*clientID == "") != (*clientSecret == "") {
+		fs.Usage()
+		return errUsage
+	}
+
+	tokens := newTokenStore(a.configDir)
+	switch args[0] {
+	case "login":
+		var token string
+		if *clientID != "" {
+			token, err = a.oauthLogin(ctx, *clientID, *clientSecret, *redirectURI, func(authorizeURL string) {
+				fmt.Fprintf(a.stderr, "Open this URL in a browser to log in:\n\n  %s\n\n", authorizeURL)
+			})
+		} else {
+			token, err = a.readToken()
+		}
+		if err != nil {
+			return err
+		}
+		if err = tokens.SaveToken(token); err != nil {
+			return err
+		}
+		fmt.Fprintln(a.stderr, "Token saved to", tokens.path)
+		return nil
+	case "logout":
+		return tokens.DeleteToken()
+	case "status":
+		return a.authStatus(tokens)
+	default:
+		fs.Usage()
+		return errUsage
+	}
+}
+
+// readToken reads a pasted token from stdin, without echoing it on terminals.
+func (a *app) readToken() (string, error) {
+	fmt.Fprint(a.stderr, "Paste an access token from https://genius.com/api-clients: ")
+
+	var token string
+	if in, ok := a.stdin.(*os.File); ok && term.IsTerminal(int(in.Fd())) {
+		b, err := term.ReadPassword(int(in.Fd()))
+		fmt.Fprintln(a.stderr)
+		if err != nil {
+			return "", err
+		}
+		token = string(b)
+	} else {
+		scanner := bufio.NewScanner(a.stdin)
+		scanner.Scan()
+		if err := scanner.Err(); err != nil {
+			return "", err
+		}
+		token = scanner.Text()
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", errors.New("no token given")
+	}
+	return token, nil
+}
+
+// oauthLogin runs the OAuth authorization code flow and returns the access token. open is called with the URL the
+// user authorizes the API client at, Genius then redirects to the redirect URI, which is served on its host while
+// waiting.
+func (a *app) oauthLogin(ctx context.Context, clientID string, clientSecret string, redirectURI string, open func(authorizeURL string)) (string, error) {
+	redirect, err := url.Parse(redirectURI)
+	if err != nil {
+		return "", err
+	}
+
+	listener, err := net.Listen("tcp", redirect.Host)
+	if err != nil {
+		return "", err
+	}
+
+	state, err := randomState()
+	if err != nil {
+		return "", err
+	}
+
+	type callback struct {
+		code string
+		err  error
+	}
+	callbacks := make(chan callback, 1)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != redirect.Path {
+			http.NotFound(w, r)
+			return
+		}
+
+		query := r.URL.Query()
+		var c callback
+		switch {
+		case query.Get("state") != state:
+			c.err = errors.New("oauth: state mismatch")
+		case query.Get("error") != "":
+			c.err = fmt.Errorf("oauth: %s: %s", query.Get("error"), query.Get("error_description"))
+		default:
+			c.code = query.Get("code")
+		}
+
+		if c.err != nil {
+			http.Error(w, c.err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "Logged in to Genius, you can close this window.")
+		}
+		select {
+		case callbacks <- c:
+		default:
+		}
+	})}
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	open(a.oauthURL + "/authorize?" + url.Values{
+		"client_id":     {clientID},
+		"redirect_uri":  {redirectURI},
+		"scope":         {"me"},
+		"state":         {state},
+		"response_type": {"code"},
+	}.Encode())
+
+	var c callback
+	select {
+	case c = <-callbacks:
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+	if c.err != nil {
+		return "", c.err
+	}
+
+	return a.exchangeCode(ctx, clientID, clientSecret, redirectURI, c.code)
+}
+
+// exchangeCode exchanges an authorization code for an access token.
+func (a *app) exchangeCode(ctx context.Context, clientID string, clientSecret string, redirectURI string, code string) (string, error) {
+	form := url.Values{
+		"code":          {code},
+		"client_id":     {clientID},
+		"client_secret": {clientSecret},
+		"redirect_uri":  {redirectURI},
+		"response_type": {"code"},
+		"grant_type":    {"authorization_code"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.oauthURL+"/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var token struct {
+		AccessToken      string `json:"access_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("oauth: token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("oauth: no token: %s %s", token.Error, token.ErrorDescription)
+	}
+	return token.AccessToken, nil
+}
+
+func randomState() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// authStatus prints where the token used comes from.
+func (a *app) authStatus(tokens tokenStore) error {
+	stored, err := tokens.Token()
+	if err != nil {
+		return err
+	}
+
+	var result authResult
+	switch {
+	case a.tokenSource != "":
+		result = authResult{LoggedIn: true, Source: a.tokenSource}
+	case stored != "":
+		result = authResult{LoggedIn: true, Source: tokens.path}
+	}
+	return printValue(a, result, func(w io.Writer) error {
+		switch {
+		case a.tokenSource != "":
+			fmt.Fprintln(w, "Using the token from", a.tokenSource)
+		case result.LoggedIn:
+			fmt.Fprintln(w, "Using the token stored in", tokens.path)
+		default:
+			fmt.Fprintln(w, "Not logged in, run genius auth login")
+		}
+		return nil
+	})
+}
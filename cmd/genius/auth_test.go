@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAuthLoginPaste(t *testing.T) {
+	t.Setenv("GENIUS_TOKEN", "")
+	ta := newTestApp(t, http.NotFoundHandler())
+	ta.stdin = strings.NewReader("  pasted-token \n")
+
+	if err := ta.app.run(context.Background(), []string{"auth", "login"}); err != nil {
+		t.Fatal(err)
+	}
+	if token, err := newTokenStore(ta.configDir).Token(); err != nil || token != "pasted-token" {
+		t.Fatalf("expected the pasted token stored, got %q, %v", token, err)
+	}
+	info, err := os.Stat(filepath.Join(ta.configDir, tokenFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("expected the token file to be private, got %v", perm)
+	}
+
+	ta.stdout.Reset()
+	if err = ta.app.run(context.Background(), []string{"auth", "status"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(ta.stdout.String(), "Using the token stored in") {
+		t.Errorf("unexpected status %q", ta.stdout)
+	}
+	if ta.token != "pasted-token" {
+		t.Errorf("expected the stored token to be used, got %q", ta.token)
+	}
+
+	if err = ta.app.run(context.Background(), []string{"auth", "logout"}); err != nil {
+		t.Fatal(err)
+	}
+	if token, _ := newTokenStore(ta.configDir).Token(); token != "" {
+		t.Errorf("expected no token after logout, got %q", token)
+	}
+}
+
+func TestOAuthLogin(t *testing.T) {
+	ta := newTestApp(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/oauth/token" || r.FormValue("code") != "the-code" || r.FormValue("client_secret") != "secret" {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": "oauth-token"})
+	}))
+	if err := ta.setup(); err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	redirectURI := "http://" + listener.Addr().String() + "/callback"
+	listener.Close()
+
+	// The browser is sent to the redirect URI once the user authorized the API client.
+	browser := func(authorizeURL string) {
+		u, err := url.Parse(authorizeURL)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		query := u.Query()
+		if query.Get("client_id") != "id" || query.Get("redirect_uri") != redirectURI {
+			t.Errorf("unexpected authorize URL %s", authorizeURL)
+		}
+
+		resp, err := http.Get(redirectURI + "?" + url.Values{"code": {"the-code"}, "state": {query.Get("state")}}.Encode())
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		resp.Body.Close()
+	}
+
+	token, err := ta.oauthLogin(context.Background(), "id", "secret", redirectURI, browser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "oauth-token" {
+		t.Errorf("expected oauth-token, got %q", token)
+	}
+}
+
+func TestConfigDefaults(t *testing.T) {
+	ta := newTestApp(t, searchHandler(t))
+	if err := os.WriteFile(filepath.Join(ta.configDir, configFile), []byte(`{"output": "json"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ta.run(t, "search", "humble"); err != nil {
+		t.Fatal(err)
+	}
+	var results []searchResult
+	if err := json.Unmarshal(ta.stdout.Bytes(), &results); err != nil {
+		t.Fatalf("expected JSON output by default: %v", err)
+	}
+}
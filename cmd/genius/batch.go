@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/natecham/genius"
+	"golang.org/x/sync/errgroup"
+)
+
+var batchCommand = &command{
+	name:  "batch",
+	usage: "[-input file] [-format jsonl] [-concurrency n] [-retries n] [-resume] [-checkpoint file]",
+	short: "Fetch the songs and lyrics of queries read one per line as JSON lines",
+	run:   runBatch,
+}
+
+// batchRetryBackoff is the wait before retrying a failed query, doubling with every further retry.
+const batchRetryBackoff = time.Second
+
+// batchResult is the output line of a query.
+type batchResult struct {
+	// Line is the line of the query in the input, from 1.
+	Line  int    `json:"line"`
+	Query string `json:"query"`
+	// Song is the song found for the query, with its lyrics.
+	Song  *genius.Song `json:"song,omitempty"`
+	Error string       `json:"error,omitempty"`
+}
+
+// batch is a run of the batch command.
+type batch struct {
+	client  *genius.Client
+	retries int
+	backoff time.Duration
+
+	mu         sync.Mutex
+	encoder    *json.Encoder
+	checkpoint io.Writer
+	failed     int
+}
+
+func runBatch(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	input := fs.String("input", "-", "read queries from `file`, - for stdin")
+	format := fs.String("format", "jsonl", "the output `format`, jsonl")
+	defaultConcurrency := 4
+	if a.config.Concurrency > 0 {
+		defaultConcurrency = a.config.Concurrency
+	}
+	concurrency := fs.Int("concurrency", defaultConcurrency, "fetch `n` queries at a time")
+	retries := fs.Int("retries", 2, "retry failed queries `n` times")
+	resume := fs.Bool("resume", false, "skip the queries the checkpoint file lists as done")
+	checkpointPath := fs.String("checkpoint", "", "record done queries in `file`, the input file with .checkpoint appended by default")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 0 || *format != "jsonl" || *concurrency < 1 || *retries < 0 {
+		fs.Usage()
+		return errUsage
+	}
+	if *checkpointPath == "" && *input != "-" {
+		*checkpointPath = *input + ".checkpoint"
+	}
+	if *resume && *checkpointPath == "" {
+		return errors.New("-resume needs -checkpoint when reading stdin")
+	}
+
+	queries, err := readQueries(a.stdin, *input)
+	if err != nil {
+		return err
+	}
+
+	done := map[int]bool{}
+	if *resume {
+		if done, err = readCheckpoint(*checkpointPath); err != nil {
+			return err
+		}
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+	b := &batch{client: client, retries: *retries, backoff: batchRetryBackoff, encoder: json.NewEncoder(a.stdout)}
+
+	if *checkpointPath != "" {
+		flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+		if !*resume {
+			flags |= os.O_TRUNC
+		}
+		f, err := os.OpenFile(*checkpointPath, flags, 0o644)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		b.checkpoint = f
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(*concurrency)
+	skipped := 0
+	for i, query := range queries {
+		line := i + 1
+		if query == "" {
+			continue
+		}
+		if done[line] {
+			skipped++
+			continue
+		}
+		g.Go(func() error { return b.run(ctx, line, query) })
+	}
+	if err = g.Wait(); err != nil {
+		return err
+	}
+
+	if skipped > 0 {
+		fmt.Fprintf(a.stderr, "Skipped %d queries done before\n", skipped)
+	}
+	if b.failed > 0 {
+		return fmt.Errorf("%d queries failed", b.failed)
+	}
+	return nil
+}
+
+// readQueries reads the lines of the file at path, or r if path is -.
+func readQueries(r io.Reader, path string) ([]string, error) {
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var queries []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		queries = append(queries, strings.TrimSpace(scanner.Text()))
+	}
+	return queries, scanner.Err()
+}
+
+// readCheckpoint returns the lines listed in the checkpoint file at path, none if it doesn't exist.
+func readCheckpoint(path string) (map[int]bool, error) {
+	done := map[int]bool{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return done, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range strings.Fields(string(data)) {
+		// A line cut off by a crash is dropped, its query is run again.
+		if line, err := strconv.Atoi(field); err == nil {
+			done[line] = true
+		}
+	}
+	return done, nil
+}
+
+// run fetches the song of query, retrying failures, and writes its result. Only writing errors are returned, failed
+// queries are written as results with an error.
+func (b *batch) run(ctx context.Context, line int, query string) error {
+	song, err := b.fetch(ctx, query)
+	for retry := 0; err != nil && retry < b.retries && !errors.Is(err, genius.ErrNoMatch); retry++ {
+		select {
+		case <-time.After(b.backoff << retry):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		song, err = b.fetch(ctx, query)
+	}
+
+	result := batchResult{Line: line, Query: query, Song: song}
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err = b.encoder.Encode(result); err != nil {
+		return err
+	}
+	if result.Error != "" {
+		b.failed++
+		return nil
+	}
+	if b.checkpoint != nil {
+		_, err = fmt.Fprintln(b.checkpoint, line)
+	}
+	return err
+}
+
+// fetch returns the song query is the ID, genius.com URL or "Artist - Title" of, or else the first song found for
+// it, with its lyrics.
+func (b *batch) fetch(ctx context.Context, query string) (*genius.Song, error) {
+	var song *genius.Song
+	var err error
+	if id, atoiErr := strconv.Atoi(query); atoiErr == nil {
+		song, err = b.client.GetSong(ctx, id)
+	} else if strings.HasPrefix(query, "http://") || strings.HasPrefix(query, "https://") {
+		song, err = b.client.GetSongByPath(ctx, query)
+	} else if artist, title, ok := strings.Cut(query, " - "); ok {
+		song, err = b.client.MatchTrack(ctx, strings.TrimSpace(title), strings.TrimSpace(artist), 0)
+	} else {
+		song, err = firstSong(ctx, b.client, query)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Songs are memoized by the client, the lyrics are set on a copy.
+	withLyrics := *song
+	if withLyrics.Lyrics, err = b.client.GetLyrics(ctx, song.URL); err != nil {
+		return nil, err
+	}
+	return &withLyrics, nil
+}
+
+// firstSong returns the first song found for q.
+func firstSong(ctx context.Context, client *genius.Client, q string) (*genius.Song, error) {
+	for hit, err := range client.SearchHits(ctx, q, &genius.ListOptions{MaxItems: 10}) {
+		if err != nil {
+			return nil, err
+		}
+		if song, err := hit.AsSong(); err == nil {
+			return song, nil
+		}
+	}
+	return nil, genius.ErrNoMatch
+}
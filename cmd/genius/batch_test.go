@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func readResults(t *testing.T, out string) map[int]batchResult {
+	t.Helper()
+
+	results := map[int]batchResult{}
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		var result batchResult
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		results[result.Line] = result
+	}
+	return results
+}
+
+func TestBatch(t *testing.T) {
+	input := filepath.Join(t.TempDir(), "queries.txt")
+	if err := os.WriteFile(input, []byte("1\n\nKendrick Lamar - HUMBLE.\nNobody - Nothing\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ta := newTestApp(t, lyricsHandler(t))
+	if err := ta.run(t, "batch", "-input", input, "-retries", "0"); err == nil {
+		t.Fatal("expected an error for the failed query")
+	}
+
+	results := readResults(t, ta.stdout.String())
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %+v", results)
+	}
+	for _, line := range []int{1, 3} {
+		if result := results[line]; result.Song == nil || result.Song.ID != 1 || result.Song.Lyrics == "" {
+			t.Errorf("expected line %d to be HUMBLE. with lyrics, got %+v", line, result)
+		}
+	}
+	if results[4].Error == "" {
+		t.Errorf("expected line 4 to fail, got %+v", results[4])
+	}
+
+	checkpoint, err := os.ReadFile(input + ".checkpoint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Fields(string(checkpoint)); !slices.Equal(slices.Sorted(slices.Values(lines)), []string{"1", "3"}) {
+		t.Errorf("expected lines 1 and 3 checkpointed, got %q", lines)
+	}
+
+	ta = newTestApp(t, lyricsHandler(t))
+	if err = ta.run(t, "batch", "-input", input, "-retries", "0", "-resume"); err == nil {
+		t.Fatal("expected an error for the failed query")
+	}
+	if results = readResults(t, ta.stdout.String()); len(results) != 1 || results[4].Query != "Nobody - Nothing" {
+		t.Errorf("expected only the failed query to run again, got %+v", results)
+	}
+}
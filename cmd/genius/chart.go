@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/natecham/genius"
+)
+
+var chartCommand = &command{
+	name:   "chart",
+	usage:  "[-type songs|albums|artists|referents] [-genre genre] [-period day|week|month|all_time] [-n items] [-json | -ndjson]",
+	short:  "Print the current Genius charts",
+	output: true,
+	run:    runChart,
+}
+
+// chartEntry is a chart item as printed by chart.
+type chartEntry struct {
+	// Rank is the position of the item in the chart, from 1.
+	Rank   int    `json:"rank"`
+	Type   string `json:"type"`
+	ID     int    `json:"id"`
+	Title  string `json:"title"`
+	Artist string `json:"artist,omitempty"`
+	URL    string `json:"url"`
+}
+
+func runChart(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	chartType := fs.String("type", string(genius.ChartSongs), "the `type` of chart, songs, albums, artists or referents")
+	genre := fs.String("genre", string(genius.ChartAllGenres), "limit the chart to `genre`, all, rap, pop, rb, rock, country or non-music")
+	period := fs.String("period", string(genius.ChartDay), "rank by `period`, day, week, month or all_time")
+	n := fs.Int("n", 10, "print the top `n` items")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 0 || *n < 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	opts := &genius.ChartOptions{
+		ListOptions: genius.ListOptions{MaxItems: *n},
+		Type:        genius.ChartType(*chartType),
+		Period:      genius.ChartPeriod(*period),
+		Genre:       genius.ChartGenre(*genre),
+	}
+	var entries []chartEntry
+	for item, err := range client.Chart(ctx, opts) {
+		if err != nil {
+			return err
+		}
+		entry, err := newChartEntry(item)
+		if err != nil {
+			return err
+		}
+		entry.Rank = len(entries) + 1
+		entries = append(entries, entry)
+	}
+
+	return printList(a, entries, func(table *tabwriter.Writer) {
+		fmt.Fprintln(table, "RANK\tID\tTITLE\tARTIST")
+		for _, entry := range entries {
+			fmt.Fprintf(table, "%d\t%d\t%s\t%s\n", entry.Rank, entry.ID, entry.Title, entry.Artist)
+		}
+	})
+}
+
+func newChartEntry(item *genius.ChartItem) (chartEntry, error) {
+	switch item.Type {
+	case genius.HitTypeArtist:
+		artist, err := item.AsArtist()
+		if err != nil {
+			return chartEntry{}, err
+		}
+		return chartEntry{Type: item.Type, ID: artist.ID, Title: artist.Name, URL: artist.URL}, nil
+	case genius.HitTypeAlbum:
+		album, err := item.AsAlbum()
+		if err != nil {
+			return chartEntry{}, err
+		}
+		entry := chartEntry{Type: item.Type, ID: album.ID, Title: album.Name, URL: album.URL}
+		if album.Artist != nil {
+			entry.Artist = album.Artist.Name
+		}
+		return entry, nil
+	case genius.ChartItemReferent:
+		referent, err := item.AsReferent()
+		if err != nil {
+			return chartEntry{}, err
+		}
+		return chartEntry{Type: item.Type, ID: referent.ID, Title: referent.Fragment, URL: referent.URL}, nil
+	default:
+		song, err := item.AsSong()
+		if err != nil {
+			return chartEntry{}, err
+		}
+		return chartEntry{Type: item.Type, ID: song.ID, Title: song.Title, Artist: song.ArtistNames, URL: song.URL}, nil
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func chartHandler(t *testing.T) http.Handler {
+	t.Helper()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/songs/chart" || query.Get("chart_genre") != "rap" || query.Get("time_period") != "week" {
+			http.NotFound(w, r)
+			return
+		}
+		writeResponse(w, map[string]any{"chart_items": []map[string]any{
+			{"type": "song", "item": map[string]any{"id": 1, "title": "HUMBLE.", "artist_names": "Kendrick Lamar"}},
+			{"type": "song", "item": map[string]any{"id": 2, "title": "DNA.", "artist_names": "Kendrick Lamar"}},
+		}, "next_page": nil})
+	})
+}
+
+func TestChart(t *testing.T) {
+	ta := newTestApp(t, chartHandler(t))
+
+	if err := ta.run(t, "chart", "-genre", "rap", "-period", "week"); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(ta.stdout.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected a header and 2 items, got %q", lines)
+	}
+	if fields := strings.Fields(lines[2]); strings.Join(fields, " ") != "2 2 DNA. Kendrick Lamar" {
+		t.Errorf("unexpected second item %q", lines[2])
+	}
+}
+
+func TestChartJSON(t *testing.T) {
+	ta := newTestApp(t, chartHandler(t))
+
+	if err := ta.run(t, "chart", "-genre", "rap", "-period", "week", "-n", "1", "-json"); err != nil {
+		t.Fatal(err)
+	}
+
+	var entries []chartEntry
+	if err := json.Unmarshal(ta.stdout.Bytes(), &entries); err != nil {
+		t.Fatal(err)
+	}
+	want := chartEntry{Rank: 1, Type: "song", ID: 1, Title: "HUMBLE.", Artist: "Kendrick Lamar"}
+	if len(entries) != 1 || entries[0] != want {
+		t.Errorf("expected %+v, got %+v", want, entries)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Files in the config directory.
+const (
+	configFile = "config.json"
+	tokenFile  = "token"
+)
+
+// config are the defaults of the commands, read from config.json in the config directory:
+//
+//	{"output": "json", "concurrency": 4, "cache_dir": "/home/me/.cache/genius"}
+type config struct {
+	// Output is the default output of commands printing results, "table", "json" or "ndjson".
+	Output string `json:"output,omitempty"`
+	// Concurrency is the number of requests made in parallel, see genius.WithConcurrency.
+	Concurrency int `json:"concurrency,omitempty"`
+	// CacheDir is the directory of a store caching songs, albums and artists between runs, see genius.WithCache.
+	CacheDir string `json:"cache_dir,omitempty"`
+}
+
+// defaultConfigDir returns $GENIUS_CONFIG_DIR, or the genius directory in the user's config directory.
+func defaultConfigDir() (string, error) {
+	if dir := os.Getenv("GENIUS_CONFIG_DIR"); dir != "" {
+		return dir, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "genius"), nil
+}
+
+// loadConfig reads the config in dir, the zero config if there is none.
+func loadConfig(dir string) (*config, error) {
+	c := &config{}
+	data, err := os.ReadFile(filepath.Join(dir, configFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Join(dir, configFile), err)
+	}
+	return c, nil
+}
+
+// tokenStore keeps the access token in a file only the user can read.
+type tokenStore struct {
+	path string
+}
+
+func newTokenStore(dir string) tokenStore {
+	return tokenStore{path: filepath.Join(dir, tokenFile)}
+}
+
+// Token returns the stored token, empty if there is none.
+func (s tokenStore) Token() (string, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	return strings.TrimSpace(string(data)), err
+}
+
+// SaveToken stores token, replacing the stored one.
+func (s tokenStore) SaveToken(token string) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, []byte(token+"\n"), 0o600)
+}
+
+// DeleteToken removes the stored token, if there is one.
+func (s tokenStore) DeleteToken() error {
+	err := os.Remove(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
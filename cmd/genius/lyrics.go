@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/natecham/genius"
+)
+
+var lyricsCommand = &command{
+	name:   "lyrics",
+	usage:  `[-plain | -no-headers | -sections] [-json | -ndjson] ("Artist - Title" | -id id | -url url)`,
+	short:  "Print the lyrics of a song",
+	output: true,
+	run:    runLyrics,
+}
+
+// lyricsResult is the lyrics of a song as printed by lyrics with -json, the song is only known by its URL with -url.
+type lyricsResult struct {
+	ID     int    `json:"id,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Artist string `json:"artist,omitempty"`
+	URL    string `json:"url"`
+	Lyrics string `json:"lyrics"`
+}
+
+func runLyrics(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	id := fs.Int("id", 0, "the Genius `id` of the song")
+	url := fs.String("url", "", "the genius.com `url` of the song")
+	plain := fs.Bool("plain", false, "print the lyrics without section headers and empty lines")
+	noHeaders := fs.Bool("no-headers", false, "print the lyrics without section headers")
+	sections := fs.Bool("sections", false, "print the sections of the lyrics as JSON, a section per line with -ndjson")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if countSet(len(args) > 0, *id != 0, *url != "") != 1 || countSet(*plain, *noHeaders, *sections) > 1 {
+		fs.Usage()
+		return errUsage
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	result := lyricsResult{URL: *url}
+	switch {
+	case *id != 0:
+		var song *genius.Song
+		if song, err = client.GetSongWithLyrics(ctx, *id); err == nil {
+			result = lyricsResult{ID: song.ID, Title: song.Title, Artist: song.ArtistNames, URL: song.URL, Lyrics: song.Lyrics}
+		}
+	case *url != "":
+		result.Lyrics, err = client.GetLyrics(ctx, *url)
+	default:
+		result, err = matchLyrics(ctx, client, strings.Join(args, " "))
+	}
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case *sections:
+		lyricsSections := genius.ParseSections(result.Lyrics)
+		if a.output != outputNDJSON {
+			return writeJSON(a.stdout, lyricsSections)
+		}
+		return printList(a, lyricsSections, nil)
+	case *plain:
+		result.Lyrics = genius.CleanLyrics(result.Lyrics)
+	case *noHeaders:
+		result.Lyrics = withoutHeaders(result.Lyrics)
+	}
+	result.Lyrics = strings.TrimSpace(result.Lyrics)
+	return printValue(a, result, func(w io.Writer) error {
+		_, err := io.WriteString(w, result.Lyrics+"\n")
+		return err
+	})
+}
+
+// matchLyrics returns the lyrics of the song query names as "Artist - Title", see Client.MatchTrack.
+func matchLyrics(ctx context.Context, client *genius.Client, query string) (lyricsResult, error) {
+	artist, title, ok := strings.Cut(query, " - ")
+	if !ok {
+		return lyricsResult{}, fmt.Errorf("%q isn't Artist - Title", query)
+	}
+
+	song, err := client.MatchTrack(ctx, strings.TrimSpace(title), strings.TrimSpace(artist), 0)
+	if errors.Is(err, genius.ErrNoMatch) {
+		return lyricsResult{}, fmt.Errorf("no song found for %q", query)
+	}
+	if err != nil {
+		return lyricsResult{}, err
+	}
+
+	result := lyricsResult{ID: song.ID, Title: song.Title, Artist: song.ArtistNames, URL: song.URL}
+	result.Lyrics, err = client.GetLyrics(ctx, song.URL)
+	return result, err
+}
+
+// withoutHeaders returns lyrics without their section headers, sections separated by empty lines.
+func withoutHeaders(lyrics string) string {
+	var parts []string
+	for _, section := range genius.ParseSections(lyrics) {
+		if len(section.Lines) == 0 {
+			continue
+		}
+		parts = append(parts, strings.Join(section.Lines, "\n"))
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// countSet returns how many of conditions are true, for flags excluding each other.
+func countSet(conditions ...bool) int {
+	n := 0
+	for _, condition := range conditions {
+		if condition {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+// lyricsHandler serves a search for and the song and lyrics page of HUMBLE.
+func lyricsHandler(t *testing.T) http.Handler {
+	t.Helper()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		song := map[string]any{
+			"id":             1,
+			"title":          "HUMBLE.",
+			"artist_names":   "Kendrick Lamar",
+			"primary_artist": map[string]any{"name": "Kendrick Lamar"},
+			"url":            "http://" + r.Host + "/humble-lyrics",
+		}
+		switch r.URL.Path {
+		case "/search":
+			writeResponse(w, map[string]any{"hits": []map[string]any{{"index": "song", "type": "song", "result": song}}})
+		case "/songs/1":
+			writeResponse(w, map[string]any{"song": song})
+		case "/humble-lyrics":
+			fmt.Fprint(w, `<div id="lyrics-root"><div data-lyrics-container="true">[Intro]<br/>Nobody pray for me<br/><br/>[Chorus]<br/>Sit down<br/>Be humble</div></div>`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+func TestLyrics(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"Kendrick Lamar - HUMBLE."}, "[Intro]\nNobody pray for me\n[Chorus]\nSit down\nBe humble\n"},
+		{[]string{"-id", "1", "-no-headers"}, "Nobody pray for me\n\nSit down\nBe humble\n"},
+		{[]string{"-plain", "Kendrick Lamar - HUMBLE."}, "Nobody pray for me\nSit down\nBe humble\n"},
+	}
+
+	for _, tt := range tests {
+		ta := newTestApp(t, lyricsHandler(t))
+		args := append([]string{"lyrics"}, tt.args...)
+		if err := ta.run(t, args...); err != nil {
+			t.Fatalf("%q failed: %v", args, err)
+		}
+		if got := ta.stdout.String(); got != tt.want {
+			t.Errorf("%q printed %q, want %q", args, got, tt.want)
+		}
+	}
+}
+
+func TestLyricsSections(t *testing.T) {
+	ta := newTestApp(t, lyricsHandler(t))
+
+	if err := ta.run(t, "lyrics", "-sections", "-url", ta.server.URL+"/humble-lyrics"); err != nil {
+		t.Fatal(err)
+	}
+
+	var sections []genius.LyricsSection
+	if err := json.Unmarshal(ta.stdout.Bytes(), &sections); err != nil {
+		t.Fatal(err)
+	}
+	if len(sections) != 2 || sections[1].Type != "Chorus" || len(sections[1].Lines) != 2 {
+		t.Errorf("unexpected sections %+v", sections)
+	}
+}
+
+func TestLyricsUsage(t *testing.T) {
+	ta := newTestApp(t, lyricsHandler(t))
+
+	if err := ta.run(t, "lyrics", "-id", "1", "Kendrick Lamar - HUMBLE."); !errors.Is(err, errUsage) {
+		t.Errorf("expected errUsage for a song given twice, got %v", err)
+	}
+}
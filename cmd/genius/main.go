@@ -0,0 +1,266 @@
+// Command genius looks up songs, artists and albums on Genius from the command line.
+//
+// Usage:
+//
+//	genius [-token token] <command> [flags] [arguments]
+//
+// Commands printing results print tables for humans by default, -json prints them as JSON and -ndjson as JSON lines
+// with a result per line, before or after the command. The JSON of a result has the fields of its table columns.
+//
+// The token is read from the GENIUS_TOKEN environment variable when -token isn't set, else the token stored by genius
+// auth login is used. Defaults of the commands are read from config.json in the config directory, $GENIUS_CONFIG_DIR
+// or genius in the user's config directory. Run genius help for the commands.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/store"
+)
+
+// command is a subcommand of genius.
+type command struct {
+	name  string
+	usage string
+	short string
+	// output is whether the command prints results in the output format, it then has the -json and -ndjson flags.
+	output bool
+	// run runs the command with its flag set, to which it adds its flags before parsing args.
+	run func(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error
+}
+
+// commands are the subcommands of genius, kept in the order of the help.
+var commands = []*command{
+	searchCommand,
+	lyricsCommand,
+	albumCommand,
+	artistCommand,
+	tuiCommand,
+	authCommand,
+	serveCommand,
+	batchCommand,
+	chartCommand,
+}
+
+// errUsage is returned for invalid arguments, after the usage has been printed.
+var errUsage = errors.New("invalid usage")
+
+// app is the state shared by the subcommands.
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	token string
+	// output is the output format, outputTable, outputJSON or outputNDJSON once set up.
+	output string
+	// tokenSource describes where token comes from, empty if it isn't set yet.
+	tokenSource string
+	configDir   string
+	config      *config
+	// cache is the store the client caches in if the config sets a cache directory, opened with the client.
+	cache store.Store
+
+	// clientOptions are the options of the client and oauthURL the base URL of the OAuth endpoints, set by tests to
+	// use a fake Genius.
+	clientOptions []genius.ClientOption
+	oauthURL      string
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	a := &app{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}
+	if err := a.run(ctx, os.Args[1:]); err != nil {
+		if !errors.Is(err, errUsage) {
+			fmt.Fprintln(os.Stderr, "genius:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+// run runs the command line args, without the program name.
+func (a *app) run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("genius", flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.StringVar(&a.token, "token", "", "Genius API access `token`, defaults to $GENIUS_TOKEN or the stored token")
+	a.outputFlags(fs)
+	fs.Usage = func() { a.usage(fs) }
+	if err := fs.Parse(args); err != nil {
+		return helpOK(flagError(err))
+	}
+	if err := a.setup(); err != nil {
+		return err
+	}
+	defer a.close()
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	name := fs.Arg(0)
+	if name == "help" {
+		a.usage(fs)
+		return nil
+	}
+
+	i := slices.IndexFunc(commands, func(c *command) bool { return c.name == name })
+	if i < 0 {
+		fmt.Fprintf(a.stderr, "genius: unknown command %q\n", name)
+		fs.Usage()
+		return errUsage
+	}
+	c := commands[i]
+	return helpOK(c.run(ctx, a, a.flagSet(c), fs.Args()[1:]))
+}
+
+func (a *app) usage(fs *flag.FlagSet) {
+	fmt.Fprintln(a.stderr, "Usage: genius [flags] <command> [arguments]")
+	fmt.Fprintln(a.stderr, "\nCommands:")
+	for _, c := range commands {
+		fmt.Fprintf(a.stderr, "  %-10s %s\n", c.name, c.short)
+	}
+	fmt.Fprintln(a.stderr, "\nFlags:")
+	fs.PrintDefaults()
+}
+
+// flagSet returns the flag set of the command c.
+func (a *app) flagSet(c *command) *flag.FlagSet {
+	fs := flag.NewFlagSet(c.name, flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	if c.output {
+		a.outputFlags(fs)
+	}
+	fs.Usage = func() {
+		fmt.Fprintf(a.stderr, "Usage: genius %s %s\n\n%s.\n", c.name, c.usage, strings.TrimSuffix(c.short, "."))
+		if hasFlags(fs) {
+			fmt.Fprintln(a.stderr, "\nFlags:")
+			fs.PrintDefaults()
+		}
+	}
+	return fs
+}
+
+// parse parses the args of a command, which may mix flags and arguments, and returns the arguments.
+func parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, flagError(err)
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		if args[0] == "--" {
+			return append(positional, args[1:]...), nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func hasFlags(fs *flag.FlagSet) bool {
+	has := false
+	fs.VisitAll(func(*flag.Flag) { has = true })
+	return has
+}
+
+// flagError returns the error of parsing flags: flag.ErrHelp if help was requested, else errUsage as the flag package
+// already printed the error.
+func flagError(err error) error {
+	if errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+	return errUsage
+}
+
+// helpOK returns nil for flag.ErrHelp, help is printed on request and not an error.
+func helpOK(err error) error {
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	return err
+}
+
+// setup loads the config and finds the token, after the flags are parsed.
+func (a *app) setup() error {
+	var err error
+	if a.configDir == "" {
+		if a.configDir, err = defaultConfigDir(); err != nil {
+			return err
+		}
+	}
+	if a.config, err = loadConfig(a.configDir); err != nil {
+		return err
+	}
+	if a.output == "" {
+		a.output = a.config.Output
+	}
+	if !validOutput(a.output) {
+		return fmt.Errorf("unknown output %q, want table, json or ndjson", a.output)
+	}
+	if a.output == "" {
+		a.output = outputTable
+	}
+	if a.oauthURL == "" {
+		a.oauthURL = defaultOAuthURL
+	}
+
+	switch {
+	case a.token != "":
+		a.tokenSource = "-token"
+	case os.Getenv("GENIUS_TOKEN") != "":
+		a.token, a.tokenSource = os.Getenv("GENIUS_TOKEN"), "GENIUS_TOKEN"
+	default:
+		a.token, err = newTokenStore(a.configDir).Token()
+	}
+	return err
+}
+
+// close closes what the commands opened.
+func (a *app) close() {
+	if a.cache != nil {
+		a.cache.Close()
+	}
+}
+
+// client returns a client authenticated with the token, configured by the config and extra.
+func (a *app) client(extra ...genius.ClientOption) (*genius.Client, error) {
+	if a.token == "" {
+		return nil, errors.New("missing token, run genius auth login or set -token or GENIUS_TOKEN")
+	}
+
+	var opts []genius.ClientOption
+	if a.config.Concurrency > 0 {
+		opts = append(opts, genius.WithConcurrency(a.config.Concurrency))
+	}
+	if a.config.CacheDir != "" && a.cache == nil {
+		if err := os.MkdirAll(a.config.CacheDir, 0o755); err != nil {
+			return nil, err
+		}
+		cache, err := openStore(filepath.Join(a.config.CacheDir, "cache.db"))
+		if err != nil {
+			return nil, err
+		}
+		a.cache = cache
+	}
+	if a.cache != nil {
+		opts = append(opts, genius.WithCache(a.cache))
+	}
+
+	opts = append(opts, extra...)
+	return genius.NewClient(nil, a.token, append(opts, a.clientOptions...)...), nil
+}
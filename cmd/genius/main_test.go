@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+// testApp is an app talking to a fake Genius, capturing its output.
+type testApp struct {
+	*app
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+	server *httptest.Server
+}
+
+func newTestApp(t *testing.T, handler http.Handler) *testApp {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	ta := &testApp{stdout: &bytes.Buffer{}, stderr: &bytes.Buffer{}, server: server}
+	ta.app = &app{
+		stdin:         strings.NewReader(""),
+		stdout:        ta.stdout,
+		stderr:        ta.stderr,
+		configDir:     t.TempDir(),
+		clientOptions: []genius.ClientOption{genius.WithBaseURL(server.URL), genius.WithUnofficialURL(server.URL)},
+		oauthURL:      server.URL + "/oauth",
+	}
+	return ta
+}
+
+// run runs the command line args with a token.
+func (ta *testApp) run(t *testing.T, args ...string) error {
+	t.Helper()
+	return ta.app.run(context.Background(), append([]string{"-token", "token"}, args...))
+}
+
+func writeResponse(w http.ResponseWriter, response any) {
+	_ = json.NewEncoder(w).Encode(map[string]any{"meta": map[string]any{"status": 200}, "response": response})
+}
+
+func TestUnknownCommand(t *testing.T) {
+	ta := newTestApp(t, http.NotFoundHandler())
+
+	if err := ta.run(t, "frobnicate"); !errors.Is(err, errUsage) {
+		t.Fatalf("expected errUsage, got %v", err)
+	}
+	if !strings.Contains(ta.stderr.String(), `unknown command "frobnicate"`) {
+		t.Errorf("expected the unknown command to be reported, got %q", ta.stderr)
+	}
+}
+
+func TestMissingToken(t *testing.T) {
+	t.Setenv("GENIUS_TOKEN", "")
+	ta := newTestApp(t, http.NotFoundHandler())
+
+	if err := ta.app.run(context.Background(), []string{"search", "humble"}); err == nil {
+		t.Fatal("expected an error without a token")
+	}
+}
+
+func TestOutputFlags(t *testing.T) {
+	ta := newTestApp(t, searchHandler(t))
+
+	if err := ta.run(t, "-ndjson", "search", "humble"); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(ta.stdout.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected a line for each of the 4 hits, got %q", lines)
+	}
+	var result searchResult
+	if err := json.Unmarshal([]byte(lines[3]), &result); err != nil {
+		t.Fatal(err)
+	}
+	if want := (searchResult{Type: "album", Rank: 1, ID: 4, Title: "DAMN.", Artist: "Kendrick Lamar"}); result != want {
+		t.Errorf("expected %+v, got %+v", want, result)
+	}
+
+	ta = newTestApp(t, lyricsHandler(t))
+	if err := ta.run(t, "-json", "lyrics", "-id", "1"); err != nil {
+		t.Fatal(err)
+	}
+	var lyrics lyricsResult
+	if err := json.Unmarshal(ta.stdout.Bytes(), &lyrics); err != nil {
+		t.Fatal(err)
+	}
+	if lyrics.ID != 1 || lyrics.Title != "HUMBLE." || !strings.Contains(lyrics.Lyrics, "[Chorus]") {
+		t.Errorf("unexpected lyrics %+v", lyrics)
+	}
+}
+
+func TestOutputFlagOverridesConfig(t *testing.T) {
+	ta := newTestApp(t, searchHandler(t))
+	if err := os.WriteFile(filepath.Join(ta.configDir, configFile), []byte(`{"output": "ndjson"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ta.run(t, "search", "-ndjson=false", "humble"); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(ta.stdout.String(), "TYPE") {
+		t.Errorf("expected a table, got %q", ta.stdout)
+	}
+}
+
+func TestInvalidOutputConfig(t *testing.T) {
+	ta := newTestApp(t, searchHandler(t))
+	if err := os.WriteFile(filepath.Join(ta.configDir, configFile), []byte(`{"output": "xml"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ta.run(t, "search", "humble"); err == nil {
+		t.Fatal("expected an error for an unknown output")
+	}
+}
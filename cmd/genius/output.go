@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"strconv"
+	"text/tabwriter"
+)
+
+// Output formats of the commands printing results, selected with -json and -ndjson or the output of the config.
+const (
+	// outputTable prints results for humans, usually as a table.
+	outputTable = "table"
+	// outputJSON prints results as indented JSON, lists as an array.
+	outputJSON = "json"
+	// outputNDJSON prints results as JSON lines, every result of a list on a line.
+	outputNDJSON = "ndjson"
+)
+
+// outputFlag is a boolean flag selecting format as the output format.
+type outputFlag struct {
+	output *string
+	format string
+}
+
+func (f outputFlag) String() string {
+	// The flag package calls String on a zero value for the defaults.
+	if f.output == nil {
+		return "false"
+	}
+	return strconv.FormatBool(*f.output == f.format)
+}
+
+func (f outputFlag) Set(s string) error {
+	on, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	switch {
+	case on:
+		*f.output = f.format
+	case *f.output == f.format:
+		*f.output = outputTable
+	}
+	return nil
+}
+
+func (f outputFlag) IsBoolFlag() bool { return true }
+
+// outputFlags adds the -json and -ndjson flags to fs.
+func (a *app) outputFlags(fs *flag.FlagSet) {
+	fs.Var(outputFlag{&a.output, outputJSON}, "json", "print results as JSON")
+	fs.Var(outputFlag{&a.output, outputNDJSON}, "ndjson", "print results as JSON lines, one result per line")
+}
+
+// validOutput reports whether output is an output format, empty for the default.
+func validOutput(output string) bool {
+	switch output {
+	case "", outputTable, outputJSON, outputNDJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+// printList prints results in the output format, with table for humans. JSON lists are never null.
+func printList[T any](a *app, results []T, table func(w *tabwriter.Writer)) error {
+	switch a.output {
+	case outputJSON:
+		if results == nil {
+			results = []T{}
+		}
+		return writeJSON(a.stdout, results)
+	case outputNDJSON:
+		encoder := json.NewEncoder(a.stdout)
+		for _, result := range results {
+			if err := encoder.Encode(result); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		t := newTable(a.stdout)
+		table(t)
+		return t.Flush()
+	}
+}
+
+// printValue prints the single result v in the output format, with text for humans.
+func printValue(a *app, v any, text func(w io.Writer) error) error {
+	switch a.output {
+	case outputJSON:
+		return writeJSON(a.stdout, v)
+	case outputNDJSON:
+		return json.NewEncoder(a.stdout).Encode(v)
+	default:
+		return text(a.stdout)
+	}
+}
+
+// writeJSON writes v to w as indented JSON.
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// newTable returns a writer aligning tab separated columns, it needs to be flushed.
+func newTable(w io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// progress displays the progress of a number of tasks on a terminal, redrawing a single line. It displays nothing
+// when the writer isn't a terminal, so that logs and pipes aren't cluttered.
+type progress struct {
+	mu    sync.Mutex
+	w     io.Writer
+	label string
+	total int
+	done  int
+}
+
+func newProgress(w io.Writer, label string, total int) *progress {
+	if !isTerminal(w) {
+		w = nil
+	}
+	p := &progress{w: w, label: label, total: total}
+	p.draw("")
+	return p
+}
+
+// add counts a finished task, item names it.
+func (p *progress) add(item string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.done++
+	p.draw(item)
+}
+
+// finish ends the progress line.
+func (p *progress) finish() {
+	if p.w != nil {
+		fmt.Fprintln(p.w)
+	}
+}
+
+func (p *progress) draw(item string) {
+	if p.w == nil {
+		return
+	}
+	// \r returns to the start of the line and \033[K clears it.
+	fmt.Fprintf(p.w, "\r\033[K%s %d/%d %s", p.label, p.done, p.total, item)
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
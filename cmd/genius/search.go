@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/natecham/genius"
+)
+
+var searchCommand = &command{
+	name:   "search",
+	usage:  "[-type song|artist|album] [-n results] [-json | -ndjson] <query>",
+	short:  "Search songs, artists and albums",
+	output: true,
+	run:    runSearch,
+}
+
+// searchTypes are the hit types search prints, in order.
+var searchTypes = []string{genius.HitTypeSong, genius.HitTypeArtist, genius.HitTypeAlbum}
+
+// searchResult is a search hit as printed by search.
+type searchResult struct {
+	Type string `json:"type"`
+	// Rank is the position of the hit among the hits of its type, from 1.
+	Rank   int    `json:"rank"`
+	ID     int    `json:"id"`
+	Title  string `json:"title"`
+	Artist string `json:"artist,omitempty"`
+	URL    string `json:"url"`
+}
+
+func runSearch(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	hitType := fs.String("type", "", "only print hits of `type` song, artist or album")
+	n := fs.Int("n", 5, "print up to `n` hits of each type")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 || (*hitType != "" && !slices.Contains(searchTypes, *hitType)) {
+		fs.Usage()
+		return errUsage
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+	response, err := client.WebSearch(ctx, *n, strings.Join(args, " "))
+	if err != nil {
+		return err
+	}
+
+	results, err := searchResults(response, *hitType, *n)
+	if err != nil {
+		return err
+	}
+
+	return printList(a, results, func(table *tabwriter.Writer) {
+		fmt.Fprintln(table, "TYPE\tRANK\tID\tTITLE\tARTIST")
+		for _, result := range results {
+			fmt.Fprintf(table, "%s\t%d\t%d\t%s\t%s\n", result.Type, result.Rank, result.ID, result.Title, result.Artist)
+		}
+	})
+}
+
+// searchResults returns up to n hits of each type in searchTypes, or only of hitType if it is set.
+func searchResults(response *genius.WebSearchResponse, hitType string, n int) ([]searchResult, error) {
+	results := []searchResult{}
+	for _, t := range searchTypes {
+		if hitType != "" && t != hitType {
+			continue
+		}
+
+		i := slices.IndexFunc(response.Response.Sections, func(s genius.Sections) bool { return s.Type == t })
+		if i < 0 {
+			continue
+		}
+		for rank, hit := range response.Response.Sections[i].Hits {
+			if rank == n {
+				break
+			}
+			result, err := newSearchResult(&hit)
+			if err != nil {
+				return nil, err
+			}
+			result.Rank = rank + 1
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+func newSearchResult(hit *genius.Hit) (searchResult, error) {
+	switch hit.Type {
+	case genius.HitTypeArtist:
+		artist, err := hit.AsArtist()
+		if err != nil {
+			return searchResult{}, err
+		}
+		return searchResult{Type: hit.Type, ID: artist.ID, Title: artist.Name, URL: artist.URL}, nil
+	case genius.HitTypeAlbum:
+		album, err := hit.AsAlbum()
+		if err != nil {
+			return searchResult{}, err
+		}
+		result := searchResult{Type: hit.Type, ID: album.ID, Title: album.Name, URL: album.URL}
+		if album.Artist != nil {
+			result.Artist = album.Artist.Name
+		}
+		return result, nil
+	default:
+		song, err := hit.AsSong()
+		if err != nil {
+			return searchResult{}, err
+		}
+		return searchResult{Type: genius.HitTypeSong, ID: song.ID, Title: song.Title, Artist: song.ArtistNames, URL: song.URL}, nil
+	}
+}
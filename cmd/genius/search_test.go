@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func searchHandler(t *testing.T) http.Handler {
+	t.Helper()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search/multi" {
+			http.NotFound(w, r)
+			return
+		}
+		writeResponse(w, map[string]any{"sections": []map[string]any{
+			{"type": "song", "hits": []map[string]any{
+				{"type": "song", "result": map[string]any{"id": 1, "title": "HUMBLE.", "artist_names": "Kendrick Lamar"}},
+				{"type": "song", "result": map[string]any{"id": 2, "title": "DNA.", "artist_names": "Kendrick Lamar"}},
+			}},
+			{"type": "artist", "hits": []map[string]any{
+				{"type": "artist", "result": map[string]any{"id": 3, "name": "Kendrick Lamar"}},
+			}},
+			{"type": "album", "hits": []map[string]any{
+				{"type": "album", "result": map[string]any{"id": 4, "name": "DAMN.", "artist": map[string]any{"name": "Kendrick Lamar"}}},
+			}},
+		}})
+	})
+}
+
+func TestSearch(t *testing.T) {
+	ta := newTestApp(t, searchHandler(t))
+
+	if err := ta.run(t, "search", "humble"); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(ta.stdout.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("expected a header and 4 hits, got %q", lines)
+	}
+	if fields := strings.Fields(lines[1]); strings.Join(fields, " ") != "song 1 1 HUMBLE. Kendrick Lamar" {
+		t.Errorf("unexpected first hit %q", lines[1])
+	}
+}
+
+func TestSearchJSON(t *testing.T) {
+	ta := newTestApp(t, searchHandler(t))
+
+	if err := ta.run(t, "search", "-type", "album", "-json", "damn"); err != nil {
+		t.Fatal(err)
+	}
+
+	var results []searchResult
+	if err := json.Unmarshal(ta.stdout.Bytes(), &results); err != nil {
+		t.Fatal(err)
+	}
+	want := searchResult{Type: "album", Rank: 1, ID: 4, Title: "DAMN.", Artist: "Kendrick Lamar"}
+	if len(results) != 1 || results[0] != want {
+		t.Errorf("expected %+v, got %+v", want, results)
+	}
+}
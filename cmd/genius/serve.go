@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/httpapi"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var serveCommand = &command{
+	name:  "serve",
+	usage: "[-addr addr] [-rate n] [-cache-ttl duration] [-metrics]",
+	short: "Serve lyrics, songs and search results over HTTP as JSON",
+	run:   runServe,
+}
+
+func runServe(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	addr := fs.String("addr", ":8080", "the `address` to listen on")
+	rate := fs.Float64("rate", 5, "make up to `n` requests per second to Genius")
+	ttl := fs.Duration("cache-ttl", time.Hour, "keep responses for `duration`, 0 disables caching")
+	metrics := fs.Bool("metrics", false, "serve Prometheus metrics of the requests to Genius at /metrics")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 0 || *rate <= 0 {
+		fs.Usage()
+		return errUsage
+	}
+
+	opts := &httpapi.Options{CacheTTL: *ttl}
+	clientOptions := []genius.ClientOption{genius.WithRateLimit(*rate, max(1, int(*rate)))}
+	if *metrics {
+		registry := prometheus.NewRegistry()
+		opts.Metrics = registry
+		clientOptions = append(clientOptions, genius.WithMetrics(registry))
+	}
+	client, err := a.client(clientOptions...)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{Addr: *addr, Handler: httpapi.NewHandler(client, opts), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdown)
+	}()
+
+	fmt.Fprintln(a.stderr, "Serving on", *addr)
+	if err = server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/natecham/genius/store"
+	"github.com/natecham/genius/store/bolt"
+	"github.com/natecham/genius/store/sqlite"
+)
+
+// openStore opens the store at path, a bbolt database if its extension is .bolt or .bbolt and SQLite otherwise.
+func openStore(path string) (store.Store, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".bolt", ".bbolt":
+		return bolt.Open(path)
+	default:
+		return sqlite.Open(path)
+	}
+}
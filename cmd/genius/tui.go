@@ -0,0 +1,337 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/export"
+	"golang.org/x/term"
+)
+
+var tuiCommand = &command{
+	name:  "tui",
+	usage: "[-out dir] [query]",
+	short: "Search songs and preview their lyrics interactively",
+	run:   runTUI,
+}
+
+// tuiHelp is the status line shown when there is nothing else to report.
+const tuiHelp = "Enter search  ↑/↓ select  PgUp/PgDn scroll  Ctrl-S export  Esc quit"
+
+// maxTUIHits is the number of search hits listed.
+const maxTUIHits = 20
+
+func runTUI(ctx context.Context, a *app, fs *flag.FlagSet, args []string) error {
+	out := fs.String("out", ".", "the `dir` exported lyrics are written to")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+
+	in, ok := a.stdin.(*os.File)
+	if !ok || !term.IsTerminal(int(in.Fd())) {
+		return errors.New("tui needs a terminal")
+	}
+
+	client, err := a.client()
+	if err != nil {
+		return err
+	}
+
+	t := newTUI(ctx, client, *out)
+	if len(args) > 0 {
+		t.query = strings.Join(args, " ")
+		t.search()
+	}
+
+	state, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		return err
+	}
+	defer term.Restore(int(in.Fd()), state)
+	// The alternate screen keeps the terminal's contents, which are restored on exit.
+	fmt.Fprint(a.stdout, "\x1b[?1049h")
+	defer fmt.Fprint(a.stdout, "\x1b[?1049l")
+
+	buf := make([]byte, 256)
+	for {
+		if t.width, t.height, err = term.GetSize(int(in.Fd())); err != nil {
+			return err
+		}
+		if _, err = io.WriteString(a.stdout, t.render()); err != nil {
+			return err
+		}
+
+		n, err := in.Read(buf)
+		if err != nil {
+			return err
+		}
+		for _, key := range parseKeys(buf[:n]) {
+			if t.handle(key) {
+				return nil
+			}
+		}
+	}
+}
+
+// tui is the state of the interactive search, separate from the terminal so that it can be tested.
+type tui struct {
+	ctx    context.Context
+	client *genius.Client
+	out    string
+
+	query    string
+	songs    []*genius.Song
+	selected int
+	// scroll is the first line of the lyrics shown.
+	scroll int
+	lyrics map[int]string
+	status string
+
+	width  int
+	height int
+}
+
+func newTUI(ctx context.Context, client *genius.Client, out string) *tui {
+	return &tui{ctx: ctx, client: client, out: out, lyrics: map[int]string{}, width: 80, height: 24}
+}
+
+// Key names, other keys are the text they type.
+const (
+	keyUp        = "up"
+	keyDown      = "down"
+	keyPageUp    = "pgup"
+	keyPageDown  = "pgdn"
+	keyEnter     = "enter"
+	keyBackspace = "backspace"
+	keyEscape    = "esc"
+	keyCtrlC     = "ctrl+c"
+	keyCtrlS     = "ctrl+s"
+)
+
+// escapeKeys are the escape sequences of keys, as sent by terminals.
+var escapeKeys = map[string]string{
+	"\x1b[A":  keyUp,
+	"\x1b[B":  keyDown,
+	"\x1bOA":  keyUp,
+	"\x1bOB":  keyDown,
+	"\x1b[5~": keyPageUp,
+	"\x1b[6~": keyPageDown,
+}
+
+// parseKeys splits the input read from a terminal in raw mode into keys.
+func parseKeys(b []byte) []string {
+	var keys []string
+	for len(b) > 0 {
+		switch b[0] {
+		case '\r', '\n':
+			keys = append(keys, keyEnter)
+		case 0x7f, 0x08:
+			keys = append(keys, keyBackspace)
+		case 0x03:
+			keys = append(keys, keyCtrlC)
+		case 0x13:
+			keys = append(keys, keyCtrlS)
+		case 0x1b:
+			n := 1
+			for sequence, key := range escapeKeys {
+				if strings.HasPrefix(string(b), sequence) {
+					keys = append(keys, key)
+					n = len(sequence)
+					break
+				}
+			}
+			if n == 1 {
+				// A lone escape is the escape key, unknown sequences are dropped.
+				if len(b) == 1 {
+					keys = append(keys, keyEscape)
+				}
+				if len(b) > 1 && b[1] == '[' {
+					n = len(b)
+				}
+			}
+			b = b[n:]
+			continue
+		default:
+			r, size := utf8.DecodeRune(b)
+			if unicode.IsPrint(r) {
+				keys = append(keys, string(r))
+			}
+			b = b[size:]
+			continue
+		}
+		b = b[1:]
+	}
+	return keys
+}
+
+// handle updates the state for key and reports whether to quit.
+func (t *tui) handle(key string) bool {
+	t.status = ""
+
+	switch key {
+	case keyEscape, keyCtrlC:
+		return true
+	case keyEnter:
+		t.search()
+	case keyUp:
+		t.choose(t.selected - 1)
+	case keyDown:
+		t.choose(t.selected + 1)
+	case keyPageUp:
+		t.scroll = max(0, t.scroll-t.paneHeight())
+	case keyPageDown:
+		if lines := t.lyricsLines(); t.scroll+t.paneHeight() < len(lines) {
+			t.scroll += t.paneHeight()
+		}
+	case keyBackspace:
+		if _, size := utf8.DecodeLastRuneInString(t.query); size > 0 {
+			t.query = t.query[:len(t.query)-size]
+		}
+	case keyCtrlS:
+		t.export()
+	default:
+		t.query += key
+	}
+
+	return false
+}
+
+func (t *tui) search() {
+	t.songs = nil
+	t.selected = 0
+	t.scroll = 0
+	if strings.TrimSpace(t.query) == "" {
+		return
+	}
+
+	for hit, err := range t.client.SearchHits(t.ctx, t.query, &genius.ListOptions{PerPage: maxTUIHits, MaxItems: maxTUIHits}) {
+		if err != nil {
+			t.status = err.Error()
+			return
+		}
+		if song, err := hit.AsSong(); err == nil {
+			t.songs = append(t.songs, song)
+		}
+	}
+
+	if len(t.songs) == 0 {
+		t.status = "No songs found"
+		return
+	}
+	t.choose(0)
+}
+
+// choose selects the song i, if there is one, and fetches its lyrics.
+func (t *tui) choose(i int) {
+	if i < 0 || i >= len(t.songs) {
+		return
+	}
+	t.selected = i
+	t.scroll = 0
+
+	song := t.songs[i]
+	if _, ok := t.lyrics[song.ID]; ok {
+		return
+	}
+	lyrics, err := t.client.GetLyrics(t.ctx, song.URL)
+	if err != nil {
+		t.status = err.Error()
+		return
+	}
+	t.lyrics[song.ID] = lyrics
+}
+
+// export writes the lyrics of the selected song to a text file, see export.Text.
+func (t *tui) export() {
+	if len(t.songs) == 0 {
+		return
+	}
+	song := *t.songs[t.selected]
+	lyrics, ok := t.lyrics[song.ID]
+	if !ok {
+		t.status = "No lyrics to export"
+		return
+	}
+	song.Lyrics = lyrics
+
+	paths, err := export.Text(t.out, export.FromSongs(&song), nil)
+	if err != nil {
+		t.status = err.Error()
+		return
+	}
+	t.status = "Exported " + paths[0]
+}
+
+func (t *tui) lyricsLines() []string {
+	if len(t.songs) == 0 {
+		return nil
+	}
+	return strings.Split(t.lyrics[t.songs[t.selected].ID], "\n")
+}
+
+// paneHeight is the number of lines of the hits and lyrics panes, below the query and above the status.
+func (t *tui) paneHeight() int {
+	return max(1, t.height-3)
+}
+
+// render returns the screen, drawn from the top left corner.
+func (t *tui) render() string {
+	var b strings.Builder
+	b.WriteString("\x1b[H\x1b[2J")
+
+	b.WriteString(fit("Search: "+t.query, t.width))
+	b.WriteString("\r\n")
+	b.WriteString(strings.Repeat("─", t.width))
+	b.WriteString("\r\n")
+
+	listWidth := t.width * 2 / 5
+	lyrics := t.lyricsLines()
+	for row := range t.paneHeight() {
+		item := ""
+		if row < len(t.songs) {
+			item = "  " + t.songs[row].Title + " - " + t.songs[row].ArtistNames
+		}
+		item = fit(item, listWidth)
+		if row < len(t.songs) && row == t.selected {
+			item = "\x1b[7m" + item + "\x1b[0m"
+		}
+		b.WriteString(item)
+		b.WriteString("│ ")
+
+		if line := t.scroll + row; line < len(lyrics) {
+			b.WriteString(fit(lyrics[line], t.width-listWidth-2))
+		}
+		b.WriteString("\r\n")
+	}
+
+	status := t.status
+	if status == "" {
+		status = tuiHelp
+	}
+	b.WriteString(fit(status, t.width))
+
+	// The cursor is left at the end of the query.
+	fmt.Fprintf(&b, "\x1b[1;%dH", min(t.width, utf8.RuneCountInString("Search: "+t.query)+1))
+
+	return b.String()
+}
+
+// fit pads or truncates s to width runes.
+func fit(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if n := utf8.RuneCountInString(s); n <= width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return string([]rune(s)[:width-1]) + "…"
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+func TestParseKeys(t *testing.T) {
+	got := parseKeys([]byte("hé\x1b[A\x1b[B\x1b[6~\x7f\r\x13\x03"))
+	want := []string{"h", "é", keyUp, keyDown, keyPageDown, keyBackspace, keyEnter, keyCtrlS, keyCtrlC}
+	if !slices.Equal(got, want) {
+		t.Errorf("parseKeys() = %q, want %q", got, want)
+	}
+
+	if got := parseKeys([]byte("\x1b")); !slices.Equal(got, []string{keyEscape}) {
+		t.Errorf("expected a lone escape to be the escape key, got %q", got)
+	}
+}
+
+func TestTUI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		song := func(id int, title string) map[string]any {
+			return map[string]any{"index": "song", "type": "song", "result": map[string]any{
+				"id": id, "title": title, "artist_names": "Kendrick Lamar", "url": fmt.Sprintf("http://%s/lyrics/%d", r.Host, id),
+			}}
+		}
+		switch r.URL.Path {
+		case "/search":
+			writeResponse(w, map[string]any{"hits": []any{song(1, "HUMBLE."), song(2, "DNA.")}})
+		case "/lyrics/1":
+			fmt.Fprint(w, `<div id="lyrics-root"><div data-lyrics-container="true">Sit down<br/>Be humble</div></div>`)
+		case "/lyrics/2":
+			fmt.Fprint(w, `<div id="lyrics-root"><div data-lyrics-container="true">I got loyalty, got royalty</div></div>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	out := t.TempDir()
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+	ui := newTUI(context.Background(), client, out)
+
+	for _, key := range []string{"k", "d", "o", "t", keyBackspace, keyEnter} {
+		if ui.handle(key) {
+			t.Fatalf("%q quit", key)
+		}
+	}
+	if ui.query != "kdo" || len(ui.songs) != 2 {
+		t.Fatalf("expected 2 songs found for %q, got %d", ui.query, len(ui.songs))
+	}
+	if screen := ui.render(); !strings.Contains(screen, "Sit down") || !strings.Contains(screen, "DNA. - Kendrick Lamar") {
+		t.Errorf("expected the hits and the first song's lyrics on screen, got %q", screen)
+	}
+
+	ui.handle(keyDown)
+	if screen := ui.render(); !strings.Contains(screen, "I got loyalty") {
+		t.Errorf("expected the second song's lyrics on screen, got %q", screen)
+	}
+
+	ui.handle(keyCtrlS)
+	data, err := os.ReadFile(filepath.Join(out, "Kendrick Lamar", "Unknown Album", "DNA.txt"))
+	if err != nil {
+		t.Fatalf("expected the lyrics to be exported: %v (%s)", err, ui.status)
+	}
+	if string(data) != "I got loyalty, got royalty\n" {
+		t.Errorf("unexpected exported lyrics %q", data)
+	}
+
+	if !ui.handle(keyEscape) {
+		t.Error("expected escape to quit")
+	}
+}
+
+func TestFit(t *testing.T) {
+	if got := fit("héllo", 7); got != "héllo  " {
+		t.Errorf("fit() = %q", got)
+	}
+	if got := fit("héllo", 3); got != "hé…" {
+		t.Errorf("fit() = %q", got)
+	}
+}
@@ -0,0 +1,116 @@
+package genius
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"iter"
+)
+
+// Comment is a comment on a song or album page.
+type Comment struct {
+	WithBody
+	ID         int    `json:"id"`
+	APIPath    string `json:"api_path"`
+	Author     *User  `json:"author"`
+	CreatedAt  int64  `json:"created_at"`
+	VotesTotal int    `json:"votes_total"`
+	// ReplyCount is the number of replies to the comment.
+	ReplyCount int `json:"reply_count"`
+	// Replies are the replies to the comment, only fetched if CommentsOptions.ExpandReplies is set.
+	Replies []*Comment `json:"replies,omitempty"`
+}
+
+// CommentsOptions configure fetching comments. Popular songs have thousands of comments, MaxItems limits how many are
+// fetched.
+type CommentsOptions struct {
+	ListOptions
+
+	// TextFormat is the format comment bodies are rendered in, FormatPlain when empty, see WithBody.Process.
+	TextFormat TextFormat
+	// ExpandReplies fetches the replies of comments into their Replies, with a request per page of replies of each
+	// comment that has replies.
+	ExpandReplies bool
+	// MaxReplies caps the number of replies fetched per comment, all replies are fetched when 0.
+	MaxReplies int
+}
+
+// SongComments lazily iterates over the comments of a song, newest first. Comments are served by the unofficial
+// genius.com API.
+func (c *Client) SongComments(ctx context.Context, songID int, opts *CommentsOptions) iter.Seq2[*Comment, error] {
+	return c.comments(ctx, fmt.Sprintf(c.unofficialUrl+"/songs/%d/comments", songID), opts)
+}
+
+// AlbumComments lazily iterates over the comments of an album, see SongComments.
+func (c *Client) AlbumComments(ctx context.Context, albumID int, opts *CommentsOptions) iter.Seq2[*Comment, error] {
+	return c.comments(ctx, fmt.Sprintf(c.unofficialUrl+"/albums/%d/comments", albumID), opts)
+}
+
+// CommentReplies lazily iterates over the replies to a comment. Replies have no replies themselves, so
+// ExpandReplies and MaxReplies of opts are ignored.
+func (c *Client) CommentReplies(ctx context.Context, commentID int, opts *CommentsOptions) iter.Seq2[*Comment, error] {
+	var o CommentsOptions
+	if opts != nil {
+		o = *opts
+	}
+	o.ExpandReplies = false
+	return c.comments(ctx, fmt.Sprintf(c.unofficialUrl+"/comments/%d/replies", commentID), &o)
+}
+
+func (c *Client) comments(ctx context.Context, endpoint string, opts *CommentsOptions) iter.Seq2[*Comment, error] {
+	var o CommentsOptions
+	if opts != nil {
+		o = *opts
+	}
+	o.TextFormat = cmp.Or(o.TextFormat, FormatPlain)
+
+	comments := paginate(ctx, c.listOptions(&o.ListOptions), defaultPerPage, func(ctx context.Context, page int, perPage int) (*Page[*Comment], error) {
+		return c.getCommentsPage(ctx, endpoint, o.TextFormat, perPage, page)
+	})
+	if !o.ExpandReplies {
+		return comments
+	}
+
+	return func(yield func(*Comment, error) bool) {
+		for comment, err := range comments {
+			if err == nil && comment.ReplyCount > 0 {
+				err = c.expandReplies(ctx, comment, &o)
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(comment, nil) {
+				return
+			}
+		}
+	}
+}
+
+// expandReplies fetches the replies of comment into its Replies.
+func (c *Client) expandReplies(ctx context.Context, comment *Comment, opts *CommentsOptions) error {
+	replyOpts := &CommentsOptions{
+		ListOptions: ListOptions{MaxItems: opts.MaxReplies, Concurrency: opts.Concurrency},
+		TextFormat:  opts.TextFormat,
+	}
+
+	comment.Replies = nil
+	for reply, err := range c.CommentReplies(ctx, comment.ID, replyOpts) {
+		if err != nil {
+			return fmt.Errorf("replies of comment %d: %w", comment.ID, err)
+		}
+		comment.Replies = append(comment.Replies, reply)
+	}
+	return nil
+}
+
+func (c *Client) getCommentsPage(ctx context.Context, endpoint string, textFormat TextFormat, perPage int, page int) (*Page[*Comment], error) {
+	comments, err := getPage[*Comment](ctx, c, endpoint, textFormatParams(textFormat), "comments", perPage, page)
+	if err != nil {
+		return nil, err
+	}
+	for _, comment := range comments.Items {
+		comment.Process(textFormat)
+	}
+	return comments, nil
+}
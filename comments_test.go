@@ -0,0 +1,97 @@
+package genius_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+// newCommentsServer serves count comments of song 1 in pages, comment 2 has 3 replies.
+func newCommentsServer(t *testing.T, count int) *httptest.Server {
+	t.Helper()
+
+	page := func(w http.ResponseWriter, req *http.Request, total int, id func(i int) int, replies func(id int) int) {
+		if format := req.URL.Query().Get("text_format"); format != "plain" {
+			t.Errorf("got text_format %q, want plain", format)
+		}
+		perPage, _ := strconv.Atoi(req.URL.Query().Get("per_page"))
+		p, _ := strconv.Atoi(req.URL.Query().Get("page"))
+
+		comments := "["
+		for i := (p - 1) * perPage; i < min(p*perPage, total); i++ {
+			if comments != "[" {
+				comments += ","
+			}
+			comments += fmt.Sprintf(`{"id": %d, "reply_count": %d, "body": {"plain": "Comment %d"}}`, id(i), replies(id(i)), id(i))
+		}
+		next := "null"
+		if p*perPage < total {
+			next = strconv.Itoa(p + 1)
+		}
+		_, _ = fmt.Fprintf(w, `{"meta": {"status": 200}, "response": {"comments": %s], "next_page": %s}}`, comments, next)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/songs/1/comments", func(w http.ResponseWriter, req *http.Request) {
+		page(w, req, count, func(i int) int { return i + 1 }, func(id int) int {
+			if id == 2 {
+				return 3
+			}
+			return 0
+		})
+	})
+	mux.HandleFunc("/comments/2/replies", func(w http.ResponseWriter, req *http.Request) {
+		page(w, req, 3, func(i int) int { return 100 + i }, func(int) int { return 0 })
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSongComments(t *testing.T) {
+	server := newCommentsServer(t, 5)
+	client := genius.NewClient(nil, "token", genius.WithUnofficialURL(server.URL))
+
+	opts := &genius.CommentsOptions{ListOptions: genius.ListOptions{PerPage: 2, MaxItems: 4}}
+	var ids []int
+	for comment, err := range client.SongComments(context.Background(), 1, opts) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := fmt.Sprintf("Comment %d", comment.ID); comment.Body != want {
+			t.Errorf("got body %q, want %q", comment.Body, want)
+		}
+		if comment.Replies != nil {
+			t.Errorf("expected replies not to be expanded, got %v", comment.Replies)
+		}
+		ids = append(ids, comment.ID)
+	}
+	if len(ids) != 4 {
+		t.Errorf("got comments %v, want the first 4", ids)
+	}
+}
+
+func TestSongCommentsExpandReplies(t *testing.T) {
+	server := newCommentsServer(t, 3)
+	client := genius.NewClient(nil, "token", genius.WithUnofficialURL(server.URL))
+
+	opts := &genius.CommentsOptions{ListOptions: genius.ListOptions{PerPage: 2}, ExpandReplies: true, MaxReplies: 2}
+	for comment, err := range client.SongComments(context.Background(), 1, opts) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch {
+		case comment.ID == 2 && len(comment.Replies) != 2:
+			t.Errorf("got %d replies to comment 2, want 2", len(comment.Replies))
+		case comment.ID == 2 && comment.Replies[1].Body != "Comment 101":
+			t.Errorf("unexpected reply %+v", comment.Replies[1])
+		case comment.ID != 2 && len(comment.Replies) != 0:
+			t.Errorf("got replies %v to comment %d without replies", comment.Replies, comment.ID)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package genius
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// acceptGzip asks for a gzip compressed response. Setting the header explicitly turns off the transparent
+// decompression of http.Transport, which custom transports may not provide anyway, so responses are decompressed by
+// gunzip instead.
+func acceptGzip(req *http.Request) {
+	req.Header.Set("Accept-Encoding", "gzip")
+}
+
+// gunzip replaces the body of a gzip encoded response with its decompressed content. Size limits apply to the
+// decompressed body, so compressed responses can't get around them.
+func gunzip(resp *http.Response) error {
+	if resp.Uncompressed || !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+
+	reader, err := gzip.NewReader(resp.Body)
+	if errors.Is(err, io.EOF) {
+		// Empty bodies aren't compressed.
+		return nil
+	}
+	if err != nil {
+		resp.Body.Close()
+		return err
+	}
+
+	resp.Body = &gzipBody{Reader: reader, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+
+	return nil
+}
+
+// gzipBody closes both the gzip reader and the underlying response body.
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b *gzipBody) Close() error {
+	_ = b.Reader.Close()
+	return b.body.Close()
+}
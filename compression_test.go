@@ -0,0 +1,35 @@
+package genius_test
+
+import (
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+func TestGzipResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			t.Errorf("gzip not accepted, Accept-Encoding %q", r.Header.Get("Accept-Encoding"))
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+		writer := gzip.NewWriter(w)
+		_, _ = writer.Write([]byte(`{"meta":{"status":200},"response":{"song":{"id":1,"title":"HUMBLE."}}}`))
+		_ = writer.Close()
+	}))
+	t.Cleanup(server.Close)
+
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	song, err := client.GetSong(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if song.Title != "HUMBLE." {
+		t.Errorf("unexpected title %q", song.Title)
+	}
+}
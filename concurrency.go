@@ -0,0 +1,38 @@
+package genius
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// defaultConcurrency keeps the client to one request at a time unless WithConcurrency allows more.
+const defaultConcurrency = 1
+
+// WithConcurrency sets how many requests the client makes in parallel for a single operation, such as fetching the
+// pages of a list or an album together with its tracks. It is the default for ListOptions.Concurrency.
+func WithConcurrency(n int) ClientOption {
+	return func(client *Client) {
+		client.concurrency = max(1, n)
+	}
+}
+
+// group returns an errgroup running at most the client's concurrency goroutines at once. Each operation uses its own
+// group, so nested operations can't starve each other.
+func (c *Client) group(ctx context.Context) (*errgroup.Group, context.Context) {
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(c.concurrency)
+	return g, ctx
+}
+
+// listOptions returns opts with the client's concurrency unless opts set their own.
+func (c *Client) listOptions(opts *ListOptions) *ListOptions {
+	resolved := ListOptions{}
+	if opts != nil {
+		resolved = *opts
+	}
+	if resolved.Concurrency < 1 {
+		resolved.Concurrency = c.concurrency
+	}
+	return &resolved
+}
@@ -0,0 +1,41 @@
+package genius
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+)
+
+// WithCookieJar keeps the cookies of genius.com page requests, lyrics pages and the unofficial API, in jar, so they
+// are made as one session. Requests to the official API don't use it.
+func WithCookieJar(jar http.CookieJar) ClientOption {
+	return func(client *Client) {
+		client.jar = jar
+	}
+}
+
+// WithCookies sends cookies with genius.com page requests, e.g. the session cookies of a browser. They are added to
+// the jar of WithCookieJar, or to an in-memory jar if none is set, for the host of the unofficial API, see
+// WithUnofficialURL.
+func WithCookies(cookies ...*http.Cookie) ClientOption {
+	return func(client *Client) {
+		client.cookies = append(client.cookies, cookies...)
+	}
+}
+
+// cookieJar returns the jar for page requests with the cookies of WithCookies added, nil if neither is set.
+func (c *Client) cookieJar() http.CookieJar {
+	jar := c.jar
+	if len(c.cookies) == 0 {
+		return jar
+	}
+
+	if jar == nil {
+		// cookiejar.New only fails for invalid options.
+		jar, _ = cookiejar.New(nil)
+	}
+	if u, err := url.Parse(c.unofficialUrl); err == nil {
+		jar.SetCookies(&url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/"}, c.cookies)
+	}
+	return jar
+}
@@ -0,0 +1,67 @@
+package genius_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+func TestCookies(t *testing.T) {
+	page, err := os.ReadFile("testdata/extractor/verses.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var pageCookies []string
+	pages := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pageCookies = append(pageCookies, r.Header.Get("Cookie"))
+		http.SetCookie(w, &http.Cookie{Name: "visited", Value: "1", Path: "/"})
+		_, _ = w.Write(page)
+	}))
+	t.Cleanup(pages.Close)
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if cookie := r.Header.Get("Cookie"); cookie != "" {
+			t.Errorf("official API request sent cookies %q", cookie)
+		}
+		_, _ = w.Write([]byte(`{"meta":{"status":200},"response":{"song":{"id":1,"title":"HUMBLE."}}}`))
+	}))
+	t.Cleanup(api.Close)
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := genius.NewClient(nil, "token",
+		genius.WithBaseURL(api.URL),
+		genius.WithUnofficialURL(pages.URL+"/api"),
+		genius.WithCookieJar(jar),
+		genius.WithCookies(&http.Cookie{Name: "session", Value: "abc"}),
+	)
+
+	for range 2 {
+		if _, err := client.GetLyrics(context.Background(), pages.URL+"/Kendrick-lamar-humble-lyrics"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := client.GetSong(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"session=abc", "session=abc; visited=1"}
+	if !slices.Equal(pageCookies, want) {
+		t.Errorf("got page cookies %q, want %q", pageCookies, want)
+	}
+
+	u, _ := url.Parse(pages.URL)
+	if cookies := jar.Cookies(u); len(cookies) != 2 {
+		t.Errorf("expected the session in the jar, got %v", cookies)
+	}
+}
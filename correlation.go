@@ -0,0 +1,63 @@
+package genius
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+)
+
+// CorrelationIDHeader is the header the correlation ID of a request is sent in.
+const CorrelationIDHeader = "X-Correlation-ID"
+
+type correlationIDKey struct{}
+
+// ContextWithCorrelationID returns a context whose requests are sent with correlation ID id, so that requests made
+// for one operation can be traced together. A random ID is generated if id is empty.
+//
+// The ID is included in request logs and in errors of requests made with the context.
+func ContextWithCorrelationID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		id = newCorrelationID()
+	}
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationID returns the correlation ID of ctx, if it has one.
+func CorrelationID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(correlationIDKey{}).(string)
+	return id, ok
+}
+
+// WithCorrelationIDs makes the client generate a correlation ID for every request whose context has none.
+func WithCorrelationIDs() ClientOption {
+	return func(client *Client) {
+		client.generateCorrelationIDs = true
+	}
+}
+
+func newCorrelationID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// correlationID returns the correlation ID to send req with, empty if there is none.
+func (c *Client) correlationID(req *http.Request) string {
+	if id, ok := CorrelationID(req.Context()); ok {
+		return id
+	}
+	if c.generateCorrelationIDs {
+		return newCorrelationID()
+	}
+	return ""
+}
+
+// correlationError adds the correlation ID of the failed request to err.
+func correlationError(id string, err error) error {
+	if err == nil || id == "" {
+		return err
+	}
+	return fmt.Errorf("%w (correlation ID %s)", err, id)
+}
@@ -0,0 +1,49 @@
+package genius_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+func TestCorrelationID(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get(genius.CorrelationIDHeader))
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	ctx := genius.ContextWithCorrelationID(context.Background(), "sync-42")
+	_, err := client.GetSong(ctx, 1)
+	if err == nil || !strings.Contains(err.Error(), "sync-42") {
+		t.Errorf("correlation ID missing from error %v", err)
+	}
+	_, _ = client.GetSong(context.Background(), 1)
+
+	if len(received) != 2 || received[0] != "sync-42" || received[1] != "" {
+		t.Errorf("unexpected correlation headers %q", received)
+	}
+}
+
+func TestGeneratedCorrelationID(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(genius.CorrelationIDHeader)
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL), genius.WithCorrelationIDs())
+	_, _ = client.GetSong(context.Background(), 1)
+
+	if len(received) != 32 {
+		t.Errorf("expected a generated correlation ID, got %q", received)
+	}
+}
@@ -0,0 +1,56 @@
+package genius
+
+// ArtistRef is the short form artists are embedded in as song credits.
+type ArtistRef struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	APIPath        string `json:"api_path,omitempty"`
+	URL            string `json:"url,omitempty"`
+	ImageURL       string `json:"image_url,omitempty"`
+	HeaderImageURL string `json:"header_image_url,omitempty"`
+	IsVerified     bool   `json:"is_verified,omitempty"`
+	IsMemeVerified bool   `json:"is_meme_verified,omitempty"`
+}
+
+// AllCreditedArtists returns the primary, featured, producer and writer artists of the song in that order. An artist
+// credited in several roles is only returned once, at its first position.
+func (s *Song) AllCreditedArtists() []ArtistRef {
+	var primary []ArtistRef
+	for _, artist := range s.PrimaryArtists {
+		primary = appendArtistRef(primary, artist)
+	}
+	if len(primary) == 0 {
+		primary = appendArtistRef(primary, s.PrimaryArtist)
+	}
+
+	seen := make(map[int]bool)
+	var credited []ArtistRef
+	for _, group := range [][]ArtistRef{primary, s.FeaturedArtists, s.ProducerArtists, s.WriterArtists} {
+		for _, artist := range group {
+			if seen[artist.ID] {
+				continue
+			}
+			seen[artist.ID] = true
+			credited = append(credited, artist)
+		}
+	}
+
+	return credited
+}
+
+func appendArtistRef(refs []ArtistRef, artist *Artist) []ArtistRef {
+	if artist == nil {
+		return refs
+	}
+
+	return append(refs, ArtistRef{
+		ID:             artist.ID,
+		Name:           artist.Name,
+		APIPath:        artist.APIPath,
+		URL:            artist.URL,
+		ImageURL:       artist.ImageURL,
+		HeaderImageURL: artist.HeaderImageURL,
+		IsVerified:     artist.IsVerified,
+		IsMemeVerified: artist.IsMemeVerified,
+	})
+}
@@ -0,0 +1,30 @@
+package genius_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+func TestAllCreditedArtists(t *testing.T) {
+	var song genius.Song
+	if err := json.Unmarshal(entityJSON(t, "testdata/song.json", "song"), &song); err != nil {
+		t.Fatal(err)
+	}
+
+	credited := song.AllCreditedArtists()
+
+	want := []int{1421, 12415}
+	if len(credited) != len(want) {
+		t.Fatalf("got %d credited artists %+v, want %d", len(credited), credited, len(want))
+	}
+	for i, artist := range credited {
+		if artist.ID != want[i] {
+			t.Errorf("credited artist %d has ID %d, want %d", i, artist.ID, want[i])
+		}
+	}
+	if credited[1].Name != "Mike WiLL Made-It" || credited[1].APIPath != "/artists/12415" {
+		t.Errorf("producer credit not decoded: %+v", credited[1])
+	}
+}
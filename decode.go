@@ -0,0 +1,282 @@
+package genius
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FieldError is a value that doesn't fit the type of the field it is decoded into.
+type FieldError struct {
+	// Path is the location of the value in the response, e.g. "response.song.stats.pageviews".
+	Path string
+	// Value is the JSON value that was dropped.
+	Value any
+	// Type is the Go type of the field.
+	Type reflect.Type
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: cannot decode %s into %s", e.Path, jsonKind(e.Value), e.Type)
+}
+
+// DecodeError lists the fields of a response that were left empty in lenient decoding mode.
+type DecodeError struct {
+	Fields []*FieldError
+}
+
+func (e *DecodeError) Error() string {
+	if len(e.Fields) == 1 {
+		return "genius: " + e.Fields[0].Error()
+	}
+	return fmt.Sprintf("genius: %d fields could not be decoded, first %s", len(e.Fields), e.Fields[0])
+}
+
+// WithLenientDecoding makes the client tolerate values of unexpected types, as the unofficial endpoints change shape
+// occasionally. Such fields are left empty instead of failing the whole response, and report is called with a
+// *DecodeError listing them, if it isn't nil.
+//
+// Note that the dropped values are missing from the Raw JSON of decoded entities as well.
+func WithLenientDecoding(report func(err error)) ClientOption {
+	return func(client *Client) {
+		client.lenient = true
+		client.reportDecodeError = report
+	}
+}
+
+// decodeAt decodes a part of a response found at path, which prefixes the paths of fields reported in lenient mode.
+func (c *Client) decodeAt(path string, data []byte, v any) error {
+	return c.decodeFrom(path, bytes.NewReader(data), v)
+}
+
+// decodeFrom decodes the JSON read from r into v, in lenient mode values that don't fit their field are dropped and
+// reported.
+func (c *Client) decodeFrom(path string, r io.Reader, v any) error {
+	decoder := json.NewDecoder(r)
+	if !c.lenient {
+		return decoder.Decode(v)
+	}
+
+	decoder.UseNumber()
+
+	var generic any
+	if err := decoder.Decode(&generic); err != nil {
+		return err
+	}
+
+	var fieldErrors []*FieldError
+	generic = sanitize(generic, reflect.TypeOf(v), path, &fieldErrors)
+
+	data, err := json.Marshal(generic)
+	if err != nil {
+		return err
+	}
+	if len(fieldErrors) > 0 && c.reportDecodeError != nil {
+		c.reportDecodeError(&DecodeError{Fields: fieldErrors})
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// ErrResponseTooLarge is returned for responses whose body exceeds the limit set with WithMaxResponseSize.
+var ErrResponseTooLarge = errors.New("genius: response body too large")
+
+// WithMaxResponseSize limits response bodies to size bytes, larger responses fail with ErrResponseTooLarge. The
+// default is 32 MiB, a size of 0 or less removes the limit.
+func WithMaxResponseSize(size int64) ClientOption {
+	return func(client *Client) {
+		client.maxResponseSize = size
+	}
+}
+
+// limitBody limits the bytes read from body to the maximum response size, if any.
+func (c *Client) limitBody(body io.Reader) io.Reader {
+	if c.maxResponseSize <= 0 {
+		return body
+	}
+	return &limitedReader{r: body, remaining: c.maxResponseSize}
+}
+
+// limitedReader is io.LimitReader failing with ErrResponseTooLarge instead of io.EOF at the limit, so that truncated
+// responses aren't mistaken for malformed ones.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, ErrResponseTooLarge
+	}
+	// A byte past the limit is read, so that bodies of exactly the limit end with io.EOF.
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	if int64(n) > l.remaining {
+		n, l.remaining = int(l.remaining), -1
+		return n, ErrResponseTooLarge
+	}
+	l.remaining -= int64(n)
+	return n, err
+}
+
+var (
+	rawMessageType  = reflect.TypeOf(json.RawMessage(nil))
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// sanitize returns value with all parts that can't be decoded into t replaced by null, recording them in errs.
+func sanitize(value any, t reflect.Type, path string, errs *[]*FieldError) any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if value == nil || t == rawMessageType || t.Kind() == reflect.Interface {
+		return value
+	}
+
+	mismatch := func() any {
+		*errs = append(*errs, &FieldError{Path: path, Value: value, Type: t})
+		return nil
+	}
+
+	// Types decoding themselves may accept other shapes, e.g. Dom text nodes are strings. Only objects are checked
+	// further, against the fields of the type.
+	if reflect.PointerTo(t).Implements(unmarshalerType) {
+		if _, ok := value.(map[string]any); !ok || t.Kind() != reflect.Struct {
+			return value
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return mismatch()
+		}
+		for name, field := range jsonFields(t) {
+			if v, ok := object[name]; ok {
+				object[name] = sanitize(v, field, joinPath(path, name), errs)
+			}
+		}
+		return object
+	case reflect.Map:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return mismatch()
+		}
+		for name, v := range object {
+			object[name] = sanitize(v, t.Elem(), joinPath(path, name), errs)
+		}
+		return object
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			if _, ok := value.(string); !ok {
+				return mismatch()
+			}
+			return value
+		}
+		array, ok := value.([]any)
+		if !ok {
+			return mismatch()
+		}
+		for i, v := range array {
+			array[i] = sanitize(v, t.Elem(), fmt.Sprintf("%s[%d]", path, i), errs)
+		}
+		return array
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			return mismatch()
+		}
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			return mismatch()
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := value.(json.Number)
+		if !ok {
+			return mismatch()
+		}
+		if _, err := strconv.ParseInt(n.String(), 10, t.Bits()); err != nil {
+			return mismatch()
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := value.(json.Number)
+		if !ok {
+			return mismatch()
+		}
+		if _, err := strconv.ParseUint(n.String(), 10, t.Bits()); err != nil {
+			return mismatch()
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := value.(json.Number); !ok {
+			return mismatch()
+		}
+	}
+
+	return value
+}
+
+// jsonFields maps the JSON names of the fields of struct type t to their types, including promoted fields of
+// embedded structs.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			for embeddedName, embeddedType := range jsonFields(fieldType) {
+				if _, ok := fields[embeddedName]; !ok {
+					fields[embeddedName] = embeddedType
+				}
+			}
+			continue
+		}
+		if !field.IsExported() || strings.Contains(options, "string") {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+func joinPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func jsonKind(v any) string {
+	switch v.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "bool"
+	default:
+		return "null"
+	}
+}
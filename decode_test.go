@@ -0,0 +1,123 @@
+package genius_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+// mistypedSong has a string where Genius normally sends a number, and a number where it sends a string.
+const mistypedSong = `{"meta":{"status":200},"response":{"song":{
+	"id": 1,
+	"title": "HUMBLE.",
+	"stats": {"pageviews": "many", "hot": true},
+	"primary_artist": {"id": 1421, "name": 42}
+}}}`
+
+func newSongServer(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestStrictDecoding(t *testing.T) {
+	server := newSongServer(t, mistypedSong)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	if _, err := client.GetSong(context.Background(), 1); err == nil {
+		t.Fatal("expected a decoding error")
+	}
+}
+
+func TestLenientDecoding(t *testing.T) {
+	server := newSongServer(t, mistypedSong)
+
+	var reported error
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL), genius.WithLenientDecoding(func(err error) {
+		reported = err
+	}))
+
+	song, err := client.GetSong(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if song.Title != "HUMBLE." || !song.Stats.Hot || song.PrimaryArtist.ID != 1421 {
+		t.Errorf("well-typed fields not decoded: %+v", song)
+	}
+
+	var decodeErr *genius.DecodeError
+	if !errors.As(reported, &decodeErr) {
+		t.Fatalf("expected a DecodeError to be reported, got %v", reported)
+	}
+
+	paths := make(map[string]bool)
+	for _, field := range decodeErr.Fields {
+		paths[field.Path] = true
+	}
+	for _, want := range []string{"response.song.stats.pageviews", "response.song.primary_artist.name"} {
+		if !paths[want] {
+			t.Errorf("%s not reported in %v", want, decodeErr)
+		}
+	}
+}
+
+func TestMaxResponseSize(t *testing.T) {
+	body := `{"meta":{"status":200},"response":{"song":{"id":1,"title":"` + strings.Repeat("a", 4096) + `"}}}`
+	server := newSongServer(t, body)
+
+	tests := []struct {
+		name     string
+		size     int64
+		tooLarge bool
+	}{
+		{"larger", 1024, true},
+		{"one byte larger", int64(len(body)) - 1, true},
+		{"exactly the limit", int64(len(body)), false},
+		{"no limit", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL), genius.WithMaxResponseSize(tt.size))
+			_, err := client.GetSong(context.Background(), 1)
+			if tooLarge := errors.Is(err, genius.ErrResponseTooLarge); tooLarge != tt.tooLarge || (!tooLarge && err != nil) {
+				t.Errorf("got %v, want ErrResponseTooLarge %t", err, tt.tooLarge)
+			}
+		})
+	}
+}
+
+// pageTransport serves the page for every request, with a body that reports io.EOF only after the page was read.
+type pageTransport string
+
+func (p pageTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := io.NopCloser(strings.NewReader(string(p)))
+	return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: body, Request: req}, nil
+}
+
+func TestMaxResponseSizeLyricsPage(t *testing.T) {
+	page := `<div id="lyrics-root"><div data-lyrics-container="true">Sit down, be humble</div></div>`
+	httpClient := &http.Client{Transport: pageTransport(page)}
+	ctx, uri := context.Background(), "https://genius.com/Kendrick-lamar-humble-lyrics"
+
+	// Pages are read to the end, a page of exactly the limit ends right at it.
+	client := genius.NewClient(httpClient, "token", genius.WithMaxResponseSize(int64(len(page))))
+	if lyrics, err := client.GetLyrics(ctx, uri); err != nil || lyrics != "Sit down, be humble" {
+		t.Errorf("page of exactly the limit: got %q, %v", lyrics, err)
+	}
+
+	client = genius.NewClient(httpClient, "token", genius.WithMaxResponseSize(int64(len(page))-1))
+	if _, err := client.GetLyrics(ctx, uri); !errors.Is(err, genius.ErrResponseTooLarge) {
+		t.Errorf("page past the limit: got %v, want ErrResponseTooLarge", err)
+	}
+}
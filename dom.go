@@ -0,0 +1,311 @@
+package genius
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+)
+
+// Dom is a node of Genius's "dom" text format, a JSON representation of an HTML tree.
+// Text nodes only have Text set, elements have a Tag and optionally attributes, data and children.
+type Dom struct {
+	Tag        string         `json:"tag,omitempty"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+	Data       map[string]any `json:"data,omitempty"`
+	Children   []*Dom         `json:"children,omitempty"`
+	Text       string         `json:"-"`
+}
+
+// UnmarshalJSON decodes an element object or a text node, which Genius encodes as a plain JSON string.
+func (d *Dom) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &d.Text)
+	}
+
+	type dom Dom
+	return json.Unmarshal(data, (*dom)(d))
+}
+
+// MarshalJSON encodes text nodes as strings, mirroring UnmarshalJSON.
+func (d *Dom) MarshalJSON() ([]byte, error) {
+	if d.Tag == "" {
+		return json.Marshal(d.Text)
+	}
+
+	type dom Dom
+	return json.Marshal((*dom)(d))
+}
+
+// Attr returns the value of the attribute name as a string, attributes are occasionally numbers or booleans.
+func (d *Dom) Attr(name string) string {
+	return stringValue(d.Attributes[name])
+}
+
+// DataValue returns the data value name as a string, e.g. the referent ID of an annotated lyrics fragment.
+func (d *Dom) DataValue(name string) string {
+	return stringValue(d.Data[name])
+}
+
+func stringValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// blockTags are elements rendered on their own lines in plain text and Markdown.
+var blockTags = map[string]bool{
+	"root": true, "p": true, "div": true, "blockquote": true, "ul": true, "ol": true, "li": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "hr": true, "pre": true,
+}
+
+// voidTags are HTML elements without closing tags.
+var voidTags = map[string]bool{"br": true, "hr": true, "img": true}
+
+// RenderPlain renders the tree as plain text. Paragraphs and other blocks are separated by blank lines, links are
+// reduced to their text.
+func (d *Dom) RenderPlain() string {
+	var b strings.Builder
+	d.renderPlain(&b)
+	return tidyBlocks(b.String())
+}
+
+func (d *Dom) renderPlain(b *strings.Builder) {
+	if d == nil {
+		return
+	}
+
+	switch d.Tag {
+	case "":
+		b.WriteString(d.Text)
+		return
+	case "br":
+		b.WriteString("\n")
+		return
+	case "img":
+		b.WriteString(d.Attr("alt"))
+		return
+	case "hr":
+		b.WriteString("\n\n")
+		return
+	}
+
+	separator := ""
+	switch {
+	case d.Tag == "li":
+		separator = "\n"
+	case blockTags[d.Tag]:
+		separator = "\n\n"
+	}
+
+	b.WriteString(separator)
+	for _, child := range d.Children {
+		child.renderPlain(b)
+	}
+	b.WriteString(separator)
+}
+
+// RenderHTML renders the tree as HTML. Data values, e.g. the IDs of referents linked in lyrics, are kept as data-*
+// attributes.
+func (d *Dom) RenderHTML() string {
+	var b strings.Builder
+	d.renderHTML(&b)
+	return b.String()
+}
+
+func (d *Dom) renderHTML(b *strings.Builder) {
+	if d == nil {
+		return
+	}
+
+	if d.Tag == "" {
+		b.WriteString(html.EscapeString(d.Text))
+		return
+	}
+
+	// The root node only groups the top level elements.
+	if d.Tag == "root" {
+		for _, child := range d.Children {
+			child.renderHTML(b)
+		}
+		return
+	}
+
+	b.WriteString("<" + d.Tag)
+	for _, k := range sortedKeys(d.Attributes) {
+		fmt.Fprintf(b, ` %s="%s"`, k, html.EscapeString(d.Attr(k)))
+	}
+	for _, k := range sortedKeys(d.Data) {
+		fmt.Fprintf(b, ` data-%s="%s"`, strings.ReplaceAll(k, "_", "-"), html.EscapeString(d.DataValue(k)))
+	}
+	b.WriteString(">")
+
+	if voidTags[d.Tag] {
+		return
+	}
+
+	for _, child := range d.Children {
+		child.renderHTML(b)
+	}
+	b.WriteString("</" + d.Tag + ">")
+}
+
+// RenderMarkdown renders the tree as Markdown, keeping links, emphasis, headings, quotes, lists and images.
+func (d *Dom) RenderMarkdown() string {
+	var b strings.Builder
+	d.renderMarkdown(&b, "")
+	return tidyBlocks(b.String())
+}
+
+func (d *Dom) renderMarkdown(b *strings.Builder, listMarker string) {
+	if d == nil {
+		return
+	}
+
+	children := func() string {
+		var inner strings.Builder
+		for _, child := range d.Children {
+			child.renderMarkdown(&inner, listMarker)
+		}
+		return inner.String()
+	}
+
+	switch d.Tag {
+	case "":
+		b.WriteString(d.Text)
+	case "br":
+		b.WriteString("  \n")
+	case "hr":
+		b.WriteString("\n\n---\n\n")
+	case "img":
+		fmt.Fprintf(b, "![%s](%s)", d.Attr("alt"), d.Attr("src"))
+	case "a":
+		fmt.Fprintf(b, "[%s](%s)", children(), d.Attr("href"))
+	case "em", "i":
+		b.WriteString("*" + children() + "*")
+	case "strong", "b":
+		b.WriteString("**" + children() + "**")
+	case "code":
+		b.WriteString("`" + children() + "`")
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		level := int(d.Tag[1] - '0')
+		b.WriteString("\n\n" + strings.Repeat("#", level) + " " + strings.TrimSpace(children()) + "\n\n")
+	case "blockquote":
+		quoted := strings.Split(tidyBlocks(children()), "\n")
+		for i, line := range quoted {
+			quoted[i] = strings.TrimRight("> "+line, " ")
+		}
+		b.WriteString("\n\n" + strings.Join(quoted, "\n") + "\n\n")
+	case "ul", "ol":
+		b.WriteString("\n\n")
+		// Items are numbered on their own, lists may have text nodes such as whitespace between them.
+		number := 0
+		for _, child := range d.Children {
+			if child.Tag != "li" {
+				continue
+			}
+			number++
+			marker := "- "
+			if d.Tag == "ol" {
+				marker = fmt.Sprintf("%d. ", number)
+			}
+			child.renderMarkdown(b, marker)
+		}
+		b.WriteString("\n\n")
+	case "li":
+		b.WriteString(listMarker + strings.TrimSpace(children()) + "\n")
+	default:
+		if blockTags[d.Tag] {
+			b.WriteString("\n\n" + children() + "\n\n")
+		} else {
+			b.WriteString(children())
+		}
+	}
+}
+
+// tidyBlocks trims s and collapses the blank lines between blocks to one.
+func tidyBlocks(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	tidy := lines[:0]
+	blank := false
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			if !blank {
+				tidy = append(tidy, "")
+			}
+			blank = true
+			continue
+		}
+		tidy = append(tidy, line)
+		blank = false
+	}
+
+	return strings.Join(tidy, "\n")
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// RenderPlain returns the description as plain text, rendering the dom tree if that's the format it was fetched in.
+func (d *Description) RenderPlain() string {
+	if d == nil {
+		return ""
+	}
+	if d.Plain != "" || d.Dom == nil {
+		return d.Plain
+	}
+	return d.Dom.RenderPlain()
+}
+
+// RenderHTML returns the description as HTML, rendering the dom tree if that's the format it was fetched in.
+// A description fetched as plain text is escaped.
+func (d *Description) RenderHTML() string {
+	switch {
+	case d == nil:
+		return ""
+	case d.HTML != "":
+		return d.HTML
+	case d.Dom != nil:
+		return d.Dom.RenderHTML()
+	default:
+		return html.EscapeString(d.Plain)
+	}
+}
+
+// RenderMarkdown returns the description as Markdown. Only descriptions fetched in the dom format keep their
+// formatting, plain text is returned as is.
+func (d *Description) RenderMarkdown() string {
+	if d == nil {
+		return ""
+	}
+	if d.Dom != nil {
+		return d.Dom.RenderMarkdown()
+	}
+	return d.Plain
+}
+
+// Render returns the description in the text format, FormatDOM renders it as plain text. Unknown formats return "".
+func (d *Description) Render(format TextFormat) string {
+	switch format {
+	case FormatPlain, FormatDOM:
+		return d.RenderPlain()
+	case FormatHTML:
+		return d.RenderHTML()
+	case FormatMarkdown:
+		return d.RenderMarkdown()
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,81 @@
+package genius_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+func TestDomRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		dom      string
+		html     string
+		markdown string
+	}{
+		{
+			name:     "link",
+			dom:      `{"tag": "p", "children": ["Produced by ", {"tag": "a", "attributes": {"href": "https://genius.com/artists/Mike-will-made-it"}, "children": ["Mike WiLL"]}]}`,
+			html:     `<p>Produced by <a href="https://genius.com/artists/Mike-will-made-it">Mike WiLL</a></p>`,
+			markdown: "Produced by [Mike WiLL](https://genius.com/artists/Mike-will-made-it)",
+		},
+		{
+			name:     "referent link",
+			dom:      `{"tag": "a", "attributes": {"href": "/9117563"}, "data": {"api_path": "/referents/9117563"}, "children": ["Sit down"]}`,
+			html:     `<a href="/9117563" data-api-path="/referents/9117563">Sit down</a>`,
+			markdown: "[Sit down](/9117563)",
+		},
+		{
+			name:     "unordered list",
+			dom:      `{"tag": "ul", "children": [{"tag": "li", "children": ["DNA."]}, {"tag": "li", "children": ["HUMBLE."]}]}`,
+			html:     `<ul><li>DNA.</li><li>HUMBLE.</li></ul>`,
+			markdown: "- DNA.\n- HUMBLE.",
+		},
+		{
+			name:     "ordered list with whitespace between items",
+			dom:      `{"tag": "ol", "children": ["\n", {"tag": "li", "children": ["DNA."]}, "\n", {"tag": "li", "children": ["HUMBLE."]}, "\n"]}`,
+			html:     "<ol>\n<li>DNA.</li>\n<li>HUMBLE.</li>\n</ol>",
+			markdown: "1. DNA.\n2. HUMBLE.",
+		},
+		{
+			name:     "nested formatting",
+			dom:      `{"tag": "root", "children": [{"tag": "h2", "children": ["Background"]}, {"tag": "p", "children": [{"tag": "strong", "children": ["Bold ", {"tag": "em", "children": ["and italic"]}]}]}]}`,
+			html:     `<h2>Background</h2><p><strong>Bold <em>and italic</em></strong></p>`,
+			markdown: "## Background\n\n**Bold *and italic***",
+		},
+		{
+			name:     "list in a quote",
+			dom:      `{"tag": "blockquote", "children": [{"tag": "ol", "children": [{"tag": "li", "children": [{"tag": "a", "attributes": {"href": "/a"}, "children": ["First"]}]}, {"tag": "li", "children": ["Second"]}]}]}`,
+			html:     `<blockquote><ol><li><a href="/a">First</a></li><li>Second</li></ol></blockquote>`,
+			markdown: "> 1. [First](/a)\n> 2. Second",
+		},
+		{
+			name:     "escaping",
+			dom:      `{"tag": "p", "children": ["Tom & Jerry <3 ", {"tag": "a", "attributes": {"href": "/search?q=\"a\"&b"}, "children": ["search"]}]}`,
+			html:     `<p>Tom &amp; Jerry &lt;3 <a href="/search?q=&#34;a&#34;&amp;b">search</a></p>`,
+			markdown: `Tom & Jerry <3 [search](/search?q="a"&b)`,
+		},
+		{
+			name:     "image and line break",
+			dom:      `{"tag": "p", "children": [{"tag": "img", "attributes": {"src": "https://images.genius.com/a.jpg", "alt": "Cover"}}, {"tag": "br"}, "Caption"]}`,
+			html:     `<p><img alt="Cover" src="https://images.genius.com/a.jpg"><br>Caption</p>`,
+			markdown: "![Cover](https://images.genius.com/a.jpg)  \nCaption",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dom genius.Dom
+			if err := json.Unmarshal([]byte(tt.dom), &dom); err != nil {
+				t.Fatal(err)
+			}
+			if got := dom.RenderHTML(); got != tt.html {
+				t.Errorf("RenderHTML() = %q, want %q", got, tt.html)
+			}
+			if got := dom.RenderMarkdown(); got != tt.markdown {
+				t.Errorf("RenderMarkdown() = %q, want %q", got, tt.markdown)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/natecham/genius"
+)
+
+// CSVOptions configure CSV and AlbumsCSV.
+type CSVOptions struct {
+	// Comma is the field delimiter, ',' when 0. Use '\t' for TSV.
+	Comma rune
+}
+
+// CSV writes the metadata of the tracks to w as CSV with a header row, one song per row. The columns are id, title,
+// artist, album, release_date, pageviews, url and language; unknown values are empty.
+func CSV(w io.Writer, tracks []Track, opts *CSVOptions) error {
+	rows := [][]string{{"id", "title", "artist", "album", "release_date", "pageviews", "url", "language"}}
+	for _, track := range tracks {
+		rows = append(rows, []string{
+			strconv.Itoa(track.ID),
+			track.Title,
+			track.Artist(),
+			track.AlbumName(),
+			track.ReleaseDate,
+			count(track.Pageviews()),
+			track.URL,
+			track.Language,
+		})
+	}
+
+	return writeCSV(w, rows, opts)
+}
+
+// AlbumsCSV writes the metadata of albums to w as CSV with a header row, see CSV. The columns are id, name, artist,
+// release_date, pageviews, the accumulated page views of the album's songs, and url.
+func AlbumsCSV(w io.Writer, albums []*genius.Album, opts *CSVOptions) error {
+	rows := [][]string{{"id", "name", "artist", "release_date", "pageviews", "url"}}
+	for _, album := range albums {
+		var artist string
+		if album.Artist != nil {
+			artist = album.Artist.Name
+		}
+
+		rows = append(rows, []string{
+			strconv.Itoa(album.ID),
+			album.Name,
+			artist,
+			album.ReleaseDate,
+			count(album.SongPageviews),
+			album.URL,
+		})
+	}
+
+	return writeCSV(w, rows, opts)
+}
+
+func writeCSV(w io.Writer, rows [][]string, opts *CSVOptions) error {
+	writer := csv.NewWriter(w)
+	if opts != nil && opts.Comma != 0 {
+		writer.Comma = opts.Comma
+	}
+
+	return writer.WriteAll(rows)
+}
+
+// count formats n, empty when it is 0 as Genius omits unknown counts.
+func count(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
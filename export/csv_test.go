@@ -0,0 +1,52 @@
+package export_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/export"
+)
+
+func TestCSV(t *testing.T) {
+	tracks := []export.Track{
+		{Song: &genius.Song{
+			ID:                 1,
+			Title:              "HUMBLE.",
+			PrimaryArtistNames: "Kendrick Lamar",
+			Album:              &genius.Album{Name: "DAMN."},
+			ReleaseDate:        "2017-03-30",
+			Stats:              &genius.Stats{Pageviews: 9000},
+			URL:                "https://genius.com/Kendrick-lamar-humble-lyrics",
+			Language:           "en",
+		}},
+		{Song: &genius.Song{ID: 2, Title: `LOVE., "FEAT."`}},
+	}
+
+	var b strings.Builder
+	if err := export.CSV(&b, tracks, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `id,title,artist,album,release_date,pageviews,url,language
+1,HUMBLE.,Kendrick Lamar,DAMN.,2017-03-30,9000,https://genius.com/Kendrick-lamar-humble-lyrics,en
+2,"LOVE., ""FEAT.""",,,,,,
+`
+	if b.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestAlbumsTSV(t *testing.T) {
+	albums := []*genius.Album{{ID: 2, Name: "DAMN.", Artist: &genius.Artist{Name: "Kendrick Lamar"}, SongPageviews: 100}}
+
+	var b strings.Builder
+	if err := export.AlbumsCSV(&b, albums, &export.CSVOptions{Comma: '\t'}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "id\tname\tartist\trelease_date\tpageviews\turl\n2\tDAMN.\tKendrick Lamar\t\t100\t\n"
+	if b.String() != want {
+		t.Errorf("got %q, want %q", b.String(), want)
+	}
+}
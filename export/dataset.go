@@ -0,0 +1,131 @@
+// Package export writes songs and lyrics kept in a store.Store in formats for other tools.
+package export
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/store"
+)
+
+// DatasetRecord is a song in a dataset, see Dataset.
+type DatasetRecord struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	Artist      string `json:"artist"`
+	Album       string `json:"album,omitempty"`
+	ReleaseDate string `json:"release_date,omitempty"`
+	Language    string `json:"language,omitempty"`
+	Pageviews   int    `json:"pageviews,omitempty"`
+	URL         string `json:"url,omitempty"`
+	// Lyrics are the lyrics without section headers and empty lines, see genius.CleanLyrics.
+	Lyrics   string                 `json:"lyrics"`
+	Sections []genius.LyricsSection `json:"sections"`
+}
+
+// DatasetOptions filter the songs of a dataset.
+type DatasetOptions struct {
+	// MinPageviews skips songs with fewer page views, songs without known page views are skipped when it is set.
+	MinPageviews int
+	// Languages are the languages of the songs to keep, e.g. "en", ignoring case. All songs are kept when empty.
+	Languages []string
+	// Dedup skips songs with the same lyrics as a song with a lower ID, e.g. remasters and reissues. Lyrics are
+	// compared ignoring case and punctuation.
+	Dedup bool
+}
+
+// Dataset writes the stored songs with lyrics to w as JSON lines of DatasetRecord, ordered by song ID, and returns
+// the number of songs written. Lyrics of songs that aren't stored, and instrumentals, are skipped.
+func Dataset(ctx context.Context, s store.Store, w io.Writer, opts *DatasetOptions) (int, error) {
+	if opts == nil {
+		opts = &DatasetOptions{}
+	}
+
+	songIDs, err := s.IDs(ctx, store.KindLyrics)
+	if err != nil {
+		return 0, err
+	}
+
+	encoder := json.NewEncoder(w)
+	seen := make(map[[sha256.Size]byte]bool)
+	written := 0
+
+	for _, id := range songIDs {
+		song, err := s.Song(ctx, id)
+		if errors.Is(err, store.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return written, fmt.Errorf("song %d: %w", id, err)
+		}
+		if song.Instrumental || !opts.keep(song) {
+			continue
+		}
+
+		lyrics, err := s.Lyrics(ctx, id)
+		if err != nil {
+			return written, fmt.Errorf("lyrics of song %d: %w", id, err)
+		}
+		cleaned := genius.CleanLyrics(lyrics)
+		if cleaned == "" {
+			continue
+		}
+
+		if opts.Dedup {
+			sum := sha256.Sum256([]byte(strings.Join(store.Terms(cleaned), " ")))
+			if seen[sum] {
+				continue
+			}
+			seen[sum] = true
+		}
+
+		if err = encoder.Encode(datasetRecord(song, cleaned, genius.ParseSections(lyrics))); err != nil {
+			return written, err
+		}
+		written++
+	}
+
+	return written, nil
+}
+
+func (o *DatasetOptions) keep(song *genius.Song) bool {
+	if o.MinPageviews > 0 && song.Pageviews() < o.MinPageviews {
+		return false
+	}
+	if len(o.Languages) > 0 && !slices.ContainsFunc(o.Languages, func(language string) bool {
+		return strings.EqualFold(language, song.Language)
+	}) {
+		return false
+	}
+	return true
+}
+
+func datasetRecord(song *genius.Song, lyrics string, sections []genius.LyricsSection) DatasetRecord {
+	record := DatasetRecord{
+		ID:          song.ID,
+		Title:       song.Title,
+		Artist:      song.PrimaryArtistNames,
+		ReleaseDate: song.ReleaseDate,
+		Language:    song.Language,
+		Pageviews:   song.Pageviews(),
+		URL:         song.URL,
+		Lyrics:      lyrics,
+		Sections:    sections,
+	}
+
+	if record.Artist == "" && song.PrimaryArtist != nil {
+		record.Artist = song.PrimaryArtist.Name
+	}
+	if song.Album != nil {
+		record.Album = song.Album.Name
+	}
+
+	return record
+}
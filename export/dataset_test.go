@@ -0,0 +1,113 @@
+package export_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/export"
+	"github.com/natecham/genius/store"
+	"github.com/natecham/genius/store/bolt"
+)
+
+// newStore returns a store with songs and their lyrics, songs are decoded from JSON.
+func newStore(t *testing.T, songs map[string]string) store.Store {
+	t.Helper()
+	ctx := context.Background()
+
+	s, err := bolt.Open(filepath.Join(t.TempDir(), "genius.bolt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	for data, lyrics := range songs {
+		var song genius.Song
+		if err = json.Unmarshal([]byte(data), &song); err != nil {
+			t.Fatal(err)
+		}
+		if err = s.SaveSong(ctx, &song); err != nil {
+			t.Fatal(err)
+		}
+		if err = s.SaveLyrics(ctx, song.ID, lyrics); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return s
+}
+
+func TestDataset(t *testing.T) {
+	s := newStore(t, map[string]string{
+		`{"id":1,"title":"HUMBLE.","primary_artist_names":"Kendrick Lamar","language":"en","stats":{"pageviews":900},"album":{"name":"DAMN."}}`: "[Verse 1: Kendrick Lamar]\nWicked or weakness?",
+		`{"id":2,"title":"HUMBLE. (Remastered)","language":"en","stats":{"pageviews":500}}`:                                                     "[Verse]\nWICKED or weakness",
+		`{"id":3,"title":"HUMBLE. (Traduction française)","language":"fr","stats":{"pageviews":500}}`:                                           "Méchant ou faiblesse ?",
+		`{"id":4,"title":"Obscure","language":"en","stats":{"pageviews":10}}`:                                                                   "Nobody listens",
+		`{"id":5,"title":"Instrumental","language":"en","instrumental":true,"stats":{"pageviews":900}}`:                                         "[Instrumental]",
+	})
+
+	tests := []struct {
+		name string
+		opts *export.DatasetOptions
+		want []int
+	}{
+		{"All", nil, []int{1, 2, 3, 4}},
+		{"MinPageviews", &export.DatasetOptions{MinPageviews: 100}, []int{1, 2, 3}},
+		{"Languages", &export.DatasetOptions{Languages: []string{"fr"}}, []int{3}},
+		{"Languages ignoring case", &export.DatasetOptions{Languages: []string{"FR"}}, []int{3}},
+		{"Dedup", &export.DatasetOptions{Dedup: true}, []int{1, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			n, err := export.Dataset(context.Background(), s, &buf, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var ids []int
+			decoder := json.NewDecoder(&buf)
+			for decoder.More() {
+				var record export.DatasetRecord
+				if err = decoder.Decode(&record); err != nil {
+					t.Fatal(err)
+				}
+				ids = append(ids, record.ID)
+			}
+
+			if n != len(ids) || !slices.Equal(ids, tt.want) {
+				t.Errorf("got %d records %v, want %v", n, ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestDatasetRecord(t *testing.T) {
+	s := newStore(t, map[string]string{
+		`{"id":1,"title":"HUMBLE.","primary_artist_names":"Kendrick Lamar","language":"en","stats":{"pageviews":900},"album":{"name":"DAMN."}}`: "[Verse 1: Kendrick Lamar]\nWicked or weakness?\n\n[Chorus]\nBe humble",
+	})
+
+	var buf bytes.Buffer
+	if _, err := export.Dataset(context.Background(), s, &buf, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var record export.DatasetRecord
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatal(err)
+	}
+	if record.Title != "HUMBLE." || record.Artist != "Kendrick Lamar" || record.Album != "DAMN." || record.Language != "en" || record.Pageviews != 900 {
+		t.Errorf("unexpected metadata %+v", record)
+	}
+	if record.Lyrics != "Wicked or weakness?\nBe humble" {
+		t.Errorf("unexpected lyrics %q", record.Lyrics)
+	}
+	if len(record.Sections) != 2 || record.Sections[0].Type != "Verse" || record.Sections[1].Lines[0] != "Be humble" {
+		t.Errorf("unexpected sections %+v", record.Sections)
+	}
+}
@@ -0,0 +1,83 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/natecham/genius"
+)
+
+// DefaultLRCPathTemplate lays out LRC files like DefaultPathTemplate, with the .lrc extension.
+const DefaultLRCPathTemplate = `{{.Artist}}/{{.Album}}/{{if .Number}}{{printf "%02d" .Number}} - {{end}}{{.Title}}.lrc`
+
+// lrcPlaceholder is the timestamp of lines whose time isn't known.
+const lrcPlaceholder = "[00:00.00]"
+
+// LRCOptions configure LRC and LRCFiles.
+type LRCOptions struct {
+	// PathTemplate is the path template of LRCFiles, see TextOptions. DefaultLRCPathTemplate is used when empty.
+	PathTemplate string
+	// Placeholders prefixes every line, including the blank lines between sections, with a [00:00.00] timestamp for
+	// lyrics editors to fill in. Some players also only display lines with timestamps.
+	Placeholders bool
+}
+
+// LRC writes the lyrics of track to w in the LRC format, unsynchronized. The artist, title and album are written as
+// [ar], [ti] and [al] ID tags, lyrics without section headers, sections separated by blank lines.
+func LRC(w io.Writer, track Track, opts *LRCOptions) error {
+	_, err := io.WriteString(w, lrcContent(track, opts))
+	return err
+}
+
+// LRCFiles writes the lyrics of each track to an LRC file in dir, laid out by the path template, and returns the
+// paths of the written files, see Text and LRC.
+func LRCFiles(dir string, tracks []Track, opts *LRCOptions) ([]string, error) {
+	var text string
+	if opts != nil {
+		text = opts.PathTemplate
+	}
+
+	return writeFiles(dir, tracks, text, DefaultLRCPathTemplate, func(track Track) ([]byte, error) {
+		return []byte(lrcContent(track, opts)), nil
+	})
+}
+
+func lrcContent(track Track, opts *LRCOptions) string {
+	placeholders := opts != nil && opts.Placeholders
+
+	var b strings.Builder
+	for _, tag := range []struct{ name, value string }{
+		{"ar", track.Artist()},
+		{"ti", track.Title},
+		{"al", track.AlbumName()},
+	} {
+		if tag.value != "" {
+			fmt.Fprintf(&b, "[%s:%s]\n", tag.name, lrcTagValue(tag.value))
+		}
+	}
+
+	line := func(text string) {
+		if placeholders {
+			b.WriteString(lrcPlaceholder)
+		}
+		b.WriteString(text)
+		b.WriteByte('\n')
+	}
+
+	for i, section := range genius.ParseSections(track.Lyrics) {
+		if i > 0 || b.Len() > 0 {
+			line("")
+		}
+		for _, text := range section.Lines {
+			line(text)
+		}
+	}
+
+	return b.String()
+}
+
+// lrcTagValue removes the characters ending ID tags from value.
+func lrcTagValue(value string) string {
+	return strings.NewReplacer("]", ")", "[", "(", "\n", " ", "\r", "").Replace(value)
+}
@@ -0,0 +1,78 @@
+package export_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/export"
+)
+
+var humble = export.Track{Song: &genius.Song{
+	ID:                 1,
+	Title:              "HUMBLE. [Clean]",
+	PrimaryArtistNames: "Kendrick Lamar",
+	Album:              &genius.Album{Name: "DAMN."},
+	Lyrics:             "[Intro]\nNobody pray for me\n\n[Chorus]\nSit down\nBe humble",
+}, Number: 8}
+
+func TestLRC(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *export.LRCOptions
+		want string
+	}{
+		{"Unsynchronized", nil, `[ar:Kendrick Lamar]
+[ti:HUMBLE. (Clean)]
+[al:DAMN.]
+
+Nobody pray for me
+
+Sit down
+Be humble
+`},
+		{"Placeholders", &export.LRCOptions{Placeholders: true}, `[ar:Kendrick Lamar]
+[ti:HUMBLE. (Clean)]
+[al:DAMN.]
+[00:00.00]
+[00:00.00]Nobody pray for me
+[00:00.00]
+[00:00.00]Sit down
+[00:00.00]Be humble
+`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := export.LRC(&b, humble, tt.opts); err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", b.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestLRCFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	paths, err := export.LRCFiles(dir, []export.Track{humble}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "Kendrick Lamar", "DAMN", "08 - HUMBLE. [Clean].lrc"); len(paths) != 1 || paths[0] != want {
+		t.Fatalf("got paths %q, want %q", paths, want)
+	}
+
+	data, err := os.ReadFile(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "[ar:Kendrick Lamar]\n") {
+		t.Errorf("unexpected content %q", data)
+	}
+}
@@ -0,0 +1,153 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/tag"
+)
+
+// SidecarFormat is the format of a lyrics sidecar, its file extension.
+type SidecarFormat string
+
+const (
+	// SidecarText is a plain text sidecar, see Text.
+	SidecarText SidecarFormat = ".txt"
+	// SidecarLRC is an unsynchronized LRC sidecar, see LRC.
+	SidecarLRC SidecarFormat = ".lrc"
+)
+
+// defaultAudioExtensions are the extensions of the audio files Sidecars scans when the tagger isn't a tag.Mux.
+var defaultAudioExtensions = []string{".mp3", ".flac", ".m4a", ".ogg", ".opus"}
+
+// SidecarOptions configure Sidecars.
+type SidecarOptions struct {
+	// Formats are the sidecars written for each audio file, SidecarLRC when empty.
+	Formats []SidecarFormat
+	// Extensions are the extensions of the audio files to scan. When empty these are the extensions of the tagger if
+	// it is a tag.Mux, else .mp3, .flac, .m4a, .ogg and .opus.
+	Extensions []string
+	// Overwrite replaces existing sidecars. Audio files whose sidecars all exist are skipped otherwise, without
+	// requests.
+	Overwrite bool
+	// LRC configures the content of LRC sidecars, its PathTemplate is unused.
+	LRC *LRCOptions
+}
+
+// SidecarReport describes what Sidecars did with the audio files of a library.
+type SidecarReport struct {
+	// Written are the paths of the written sidecars.
+	Written []string
+	// Skipped are the paths of audio files whose sidecars already existed.
+	Skipped []string
+	// Unmatched are the paths of audio files without title or artist tags or a matching Genius song.
+	Unmatched []string
+}
+
+// Sidecars scans the music library in root for audio files and writes their lyrics to sidecar files next to them,
+// named like the audio file with the extension of the format, as Plex and Jellyfin expect: Music/DAMN./08
+// HUMBLE.flac gets Music/DAMN./08 HUMBLE.lrc. Songs are matched by the title and artist tags read with tagger, see
+// tag.Match.
+//
+// Files failing to be read, matched or written don't stop the scan, their errors are returned together.
+func Sidecars(ctx context.Context, client genius.GeniusAPI, tagger tag.Tagger, root string, opts *SidecarOptions) (*SidecarReport, error) {
+	if opts == nil {
+		opts = &SidecarOptions{}
+	}
+
+	formats := opts.Formats
+	if len(formats) == 0 {
+		formats = []SidecarFormat{SidecarLRC}
+	}
+	for _, format := range formats {
+		if format != SidecarText && format != SidecarLRC {
+			return nil, fmt.Errorf("export: unknown sidecar format %q", format)
+		}
+	}
+
+	extensions := opts.Extensions
+	if len(extensions) == 0 {
+		extensions = defaultAudioExtensions
+		if mux, ok := tagger.(tag.Mux); ok {
+			extensions = slices.Collect(maps.Keys(mux))
+		}
+	}
+
+	report := &SidecarReport{}
+	var errs []error
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !slices.ContainsFunc(extensions, func(ext string) bool {
+			return strings.EqualFold(ext, filepath.Ext(path))
+		}) {
+			return ctx.Err()
+		}
+
+		written, err := sidecar(ctx, client, tagger, path, formats, opts)
+		report.Written = append(report.Written, written...)
+		switch {
+		case errors.Is(err, genius.ErrNoMatch), errors.Is(err, tag.ErrMissingTags):
+			report.Unmatched = append(report.Unmatched, path)
+		case err != nil:
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+		case written == nil:
+			report.Skipped = append(report.Skipped, path)
+		}
+		return ctx.Err()
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	return report, errors.Join(errs...)
+}
+
+// sidecar writes the sidecars of the audio file at path and returns their paths, nil if they already exist.
+func sidecar(ctx context.Context, client genius.GeniusAPI, tagger tag.Tagger, path string, formats []SidecarFormat, opts *SidecarOptions) ([]string, error) {
+	base := strings.TrimSuffix(path, filepath.Ext(path))
+
+	var missing []SidecarFormat
+	for _, format := range formats {
+		if _, err := os.Stat(base + string(format)); opts.Overwrite || errors.Is(err, fs.ErrNotExist) {
+			missing = append(missing, format)
+		}
+	}
+	if len(missing) == 0 {
+		return nil, nil
+	}
+
+	song, err := tag.Match(ctx, client, tagger, path)
+	if err != nil {
+		return nil, err
+	}
+	if song.Lyrics, err = client.GetLyrics(ctx, song.URL); err != nil {
+		return nil, err
+	}
+
+	track := Track{Song: song}
+	var written []string
+	for _, format := range missing {
+		content := textContent(track)
+		if format == SidecarLRC {
+			content = lrcContent(track, opts.LRC)
+		}
+
+		name := base + string(format)
+		if err = os.WriteFile(name, []byte(content), 0o644); err != nil {
+			return written, err
+		}
+		written = append(written, name)
+	}
+
+	return written, nil
+}
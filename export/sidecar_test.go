@@ -0,0 +1,107 @@
+package export_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/export"
+	"github.com/natecham/genius/tag"
+)
+
+// libraryTagger reads the tags of audio files by their names.
+type libraryTagger map[string]tag.Tags
+
+func (l libraryTagger) ReadTags(path string) (*tag.Tags, error) {
+	tags := l[filepath.Base(path)]
+	return &tags, nil
+}
+
+func (l libraryTagger) WriteLyrics(string, string) error {
+	return nil
+}
+
+func newLyricsServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/search":
+			hits := []map[string]any{{"index": "song", "type": "song", "result": map[string]any{
+				"id":             1,
+				"title":          "HUMBLE.",
+				"artist_names":   "Kendrick Lamar",
+				"primary_artist": map[string]any{"name": "Kendrick Lamar"},
+				"url":            server.URL + "/lyrics",
+			}}}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"meta":     map[string]any{"status": 200},
+				"response": map[string]any{"hits": hits},
+			})
+		case "/lyrics":
+			fmt.Fprint(w, `<div id="lyrics-root"><div data-lyrics-container="true">[Chorus]<br/>Sit down<br/>Be humble</div></div>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestSidecars(t *testing.T) {
+	root := t.TempDir()
+	album := filepath.Join(root, "Kendrick Lamar", "DAMN.")
+	if err := os.MkdirAll(album, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"08 HUMBLE.flac", "09 Unknown.mp3", "cover.jpg"} {
+		if err := os.WriteFile(filepath.Join(album, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(newLyricsServer(t).URL))
+	tagger := libraryTagger{
+		"08 HUMBLE.flac": {Title: "HUMBLE.", Artist: "Kendrick Lamar"},
+		"09 Unknown.mp3": {Title: "Unknown", Artist: "Nobody"},
+	}
+	opts := &export.SidecarOptions{Formats: []export.SidecarFormat{export.SidecarText, export.SidecarLRC}}
+
+	report, err := export.Sidecars(context.Background(), client, tagger, root, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{filepath.Join(album, "08 HUMBLE.txt"), filepath.Join(album, "08 HUMBLE.lrc")}
+	if !slices.Equal(report.Written, want) {
+		t.Errorf("expected %q written, got %q", want, report.Written)
+	}
+	if unmatched := []string{filepath.Join(album, "09 Unknown.mp3")}; !slices.Equal(report.Unmatched, unmatched) {
+		t.Errorf("expected %q unmatched, got %q", unmatched, report.Unmatched)
+	}
+
+	lrc, err := os.ReadFile(want[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := "[ar:Kendrick Lamar]\n[ti:HUMBLE.]\n\nSit down\nBe humble\n"; string(lrc) != got {
+		t.Errorf("unexpected LRC sidecar %q", lrc)
+	}
+
+	report, err = export.Sidecars(context.Background(), client, tagger, root, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Written) != 0 || len(report.Skipped) != 1 {
+		t.Errorf("expected existing sidecars to be skipped, got %+v", report)
+	}
+}
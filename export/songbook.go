@@ -0,0 +1,168 @@
+package export
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/natecham/genius"
+)
+
+// SongbookFormat is the markup a songbook is written in.
+type SongbookFormat string
+
+const (
+	SongbookMarkdown SongbookFormat = "markdown"
+	SongbookHTML     SongbookFormat = "html"
+)
+
+// SongbookOptions configure Songbook.
+type SongbookOptions struct {
+	// Format is the markup of the songbook, SongbookMarkdown when empty.
+	Format SongbookFormat
+}
+
+// Songbook writes the tracks to w as a single document titled title: a table of contents linking to the songs,
+// followed by each song's title, metadata and lyrics with their section headers.
+func Songbook(w io.Writer, title string, tracks []Track, opts *SongbookOptions) error {
+	format := SongbookMarkdown
+	if opts != nil && opts.Format != "" {
+		format = opts.Format
+	}
+
+	book := newSongbook(title, tracks)
+	switch format {
+	case SongbookMarkdown:
+		_, err := io.WriteString(w, book.markdown())
+		return err
+	case SongbookHTML:
+		return songbookTemplate.Execute(w, book)
+	default:
+		return fmt.Errorf("unsupported songbook format %q", format)
+	}
+}
+
+// songbook is what songbooks are rendered from.
+type songbook struct {
+	Title string
+	Songs []songbookSong
+}
+
+type songbookSong struct {
+	Anchor   string
+	Title    string
+	Metadata []string
+	Sections []genius.LyricsSection
+}
+
+func newSongbook(title string, tracks []Track) songbook {
+	book := songbook{Title: title}
+	for _, track := range tracks {
+		song := songbookSong{
+			Anchor:   fmt.Sprintf("song-%d", track.ID),
+			Title:    track.Title,
+			Sections: genius.ParseSections(track.Lyrics),
+		}
+
+		for _, field := range []struct{ name, value string }{
+			{"Artist", track.Artist()},
+			{"Album", track.AlbumName()},
+			{"Released", track.ReleaseDateForDisplay},
+		} {
+			if field.value != "" {
+				song.Metadata = append(song.Metadata, field.name+": "+field.value)
+			}
+		}
+
+		book.Songs = append(book.Songs, song)
+	}
+	return book
+}
+
+func (book songbook) markdown() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n## Contents\n\n", escapeMarkdown(book.Title))
+	for i, song := range book.Songs {
+		fmt.Fprintf(&b, "%d. [%s](#%s)\n", i+1, escapeMarkdown(song.Title), song.Anchor)
+	}
+
+	for _, song := range book.Songs {
+		fmt.Fprintf(&b, "\n<a id=\"%s\"></a>\n\n## %s\n", song.Anchor, escapeMarkdown(song.Title))
+		if len(song.Metadata) > 0 {
+			fmt.Fprintf(&b, "\n*%s*\n", escapeMarkdown(strings.Join(song.Metadata, " · ")))
+		}
+
+		for _, section := range song.Sections {
+			b.WriteByte('\n')
+			if section.Header != "" {
+				fmt.Fprintf(&b, "**%s**  \n", escapeMarkdown("["+section.Header+"]"))
+			}
+			for i, line := range section.Lines {
+				b.WriteString(escapeMarkdown(line))
+				// Two trailing spaces break lines within a paragraph.
+				if i < len(section.Lines)-1 {
+					b.WriteString("  ")
+				}
+				b.WriteByte('\n')
+			}
+		}
+	}
+
+	return b.String()
+}
+
+var (
+	markdownSpecial = regexp.MustCompile("[\\\\`*_\\[\\]<>#|~]")
+	markdownList    = regexp.MustCompile(`^(\s*)([-+]|\d+\.)(\s)`)
+)
+
+// escapeMarkdown escapes the characters of text Markdown would format.
+func escapeMarkdown(text string) string {
+	text = markdownSpecial.ReplaceAllString(text, `\$0`)
+	return markdownList.ReplaceAllStringFunc(text, func(list string) string {
+		// Escaping the last character of the marker, e.g. "1\." or "\-", keeps the line a paragraph.
+		match := markdownList.FindStringSubmatch(list)
+		marker := match[2]
+		return match[1] + marker[:len(marker)-1] + `\` + marker[len(marker)-1:] + match[3]
+	})
+}
+
+var songbookTemplate = template.Must(template.New("songbook").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<nav>
+<h2>Contents</h2>
+<ol>
+{{- range .Songs}}
+<li><a href="#{{.Anchor}}">{{.Title}}</a></li>
+{{- end}}
+</ol>
+</nav>
+{{- range .Songs}}
+<article id="{{.Anchor}}">
+<h2>{{.Title}}</h2>
+{{- if .Metadata}}
+<p class="metadata">{{range $i, $field := .Metadata}}{{if $i}} · {{end}}{{$field}}{{end}}</p>
+{{- end}}
+{{- range .Sections}}
+<section>
+{{- if .Header}}
+<h3>[{{.Header}}]</h3>
+{{- end}}
+<p>{{range $i, $line := .Lines}}{{if $i}}<br>
+{{end}}{{$line}}{{end}}</p>
+</section>
+{{- end}}
+</article>
+{{- end}}
+</body>
+</html>
+`))
@@ -0,0 +1,73 @@
+package export_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/export"
+)
+
+func TestSongbookMarkdown(t *testing.T) {
+	tracks := []export.Track{
+		humble,
+		{Song: &genius.Song{ID: 2, Title: "DNA.", Lyrics: "- I got loyalty\n*got royalty*"}},
+	}
+
+	var b strings.Builder
+	if err := export.Songbook(&b, "DAMN.", tracks, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `# DAMN.
+
+## Contents
+
+1. [HUMBLE. \[Clean\]](#song-1)
+2. [DNA.](#song-2)
+
+<a id="song-1"></a>
+
+## HUMBLE. \[Clean\]
+
+*Artist: Kendrick Lamar · Album: DAMN.*
+
+**\[Intro\]**  
+Nobody pray for me
+
+**\[Chorus\]**  
+Sit down  
+Be humble
+
+<a id="song-2"></a>
+
+## DNA.
+
+\- I got loyalty  
+\*got royalty\*
+`
+	if b.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", b.String(), want)
+	}
+}
+
+func TestSongbookHTML(t *testing.T) {
+	tracks := []export.Track{{Song: &genius.Song{ID: 2, Title: "DNA.", Lyrics: "[Verse 1]\nI got <loyalty>\nGot royalty"}}}
+
+	var b strings.Builder
+	if err := export.Songbook(&b, "DAMN.", tracks, &export.SongbookOptions{Format: export.SongbookHTML}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		`<title>DAMN.</title>`,
+		`<li><a href="#song-2">DNA.</a></li>`,
+		`<article id="song-2">`,
+		`<h3>[Verse 1]</h3>`,
+		"<p>I got &lt;loyalty&gt;<br>\nGot royalty</p>",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("songbook doesn't contain %q:\n%s", want, b.String())
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/natecham/genius"
+)
+
+// Executor is a parsed template, a *text/template.Template or an *html/template.Template.
+type Executor interface {
+	Execute(w io.Writer, data any) error
+}
+
+// TemplateData is what templates are executed with for each track.
+type TemplateData struct {
+	Track Track
+	Song  *genius.Song
+	// Album is the song's album, nil if it isn't on an album.
+	Album *genius.Album
+	// Artist is the name of the song's primary artists.
+	Artist string
+	Lyrics string
+	// Sections are the sections of the lyrics, see genius.ParseSections.
+	Sections []genius.LyricsSection
+}
+
+// Template executes tmpl for each track, writing the outputs to w one after another.
+//
+// For example a template writing a song's title followed by its verses:
+//
+//	{{.Song.Title}}
+//	{{range .Sections}}{{if eq .Type "Verse"}}{{range .Lines}}{{.}}
+//	{{end}}{{end}}{{end}}
+func Template(w io.Writer, tmpl Executor, tracks []Track) error {
+	for _, track := range tracks {
+		if err := tmpl.Execute(w, newTemplateData(track)); err != nil {
+			return fmt.Errorf("song %d: %w", track.ID, err)
+		}
+	}
+	return nil
+}
+
+// TemplateFiles executes tmpl for each track, writing the output to a file in dir laid out by the path template, and
+// returns the paths of the written files, see Text.
+func TemplateFiles(dir string, tmpl Executor, tracks []Track, opts *TextOptions) ([]string, error) {
+	var text string
+	if opts != nil {
+		text = opts.PathTemplate
+	}
+
+	return writeFiles(dir, tracks, text, DefaultPathTemplate, func(track Track) ([]byte, error) {
+		var b bytes.Buffer
+		err := tmpl.Execute(&b, newTemplateData(track))
+		return b.Bytes(), err
+	})
+}
+
+func newTemplateData(track Track) TemplateData {
+	return TemplateData{
+		Track:    track,
+		Song:     track.Song,
+		Album:    track.Album,
+		Artist:   track.Artist(),
+		Lyrics:   track.Lyrics,
+		Sections: genius.ParseSections(track.Lyrics),
+	}
+}
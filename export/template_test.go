@@ -0,0 +1,50 @@
+package export_test
+
+import (
+	htmltemplate "html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/natecham/genius/export"
+)
+
+func TestTemplate(t *testing.T) {
+	tmpl := template.Must(template.New("song").Parse(
+		`{{.Song.Title}} by {{.Artist}} on {{.Album.Name}}
+{{range .Sections}}{{.Type}}: {{len .Lines}} lines
+{{end}}`))
+
+	var b strings.Builder
+	if err := export.Template(&b, tmpl, []export.Track{humble}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "HUMBLE. [Clean] by Kendrick Lamar on DAMN.\nIntro: 1 lines\nChorus: 2 lines\n"
+	if b.String() != want {
+		t.Errorf("got %q, want %q", b.String(), want)
+	}
+}
+
+func TestTemplateFiles(t *testing.T) {
+	dir := t.TempDir()
+	tmpl := htmltemplate.Must(htmltemplate.New("song").Parse(`<h1>{{.Song.Title}}</h1>`))
+
+	paths, err := export.TemplateFiles(dir, tmpl, []export.Track{humble}, &export.TextOptions{PathTemplate: "{{.ID}}.html"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 1 || paths[0] != filepath.Join(dir, "1.html") {
+		t.Fatalf("unexpected paths %q", paths)
+	}
+
+	data, err := os.ReadFile(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "<h1>HUMBLE. [Clean]</h1>" {
+		t.Errorf("unexpected content %q", data)
+	}
+}
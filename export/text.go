@@ -0,0 +1,160 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultPathTemplate lays out files as "Artist/Album/NN - Title.txt", without the track number when it is unknown.
+const DefaultPathTemplate = `{{.Artist}}/{{.Album}}/{{if .Number}}{{printf "%02d" .Number}} - {{end}}{{.Title}}.txt`
+
+// maxNameLength is the longest sanitized name in bytes, leaving room for track numbers and extensions in file names
+// of at most 255 bytes, which most file systems allow.
+const maxNameLength = 200
+
+// ErrInvalidPath is returned for path templates producing paths outside the export directory.
+var ErrInvalidPath = errors.New("export: invalid path")
+
+// PathData is what path templates are executed with. All names are sanitized to be safe in file names, see
+// SanitizeName.
+type PathData struct {
+	ID     int
+	Artist string
+	Album  string
+	Title  string
+	Number int
+	Disc   int
+}
+
+// TextOptions configure Text.
+type TextOptions struct {
+	// PathTemplate is the text/template of the path of a song's file relative to the export directory, executed
+	// with PathData. DefaultPathTemplate is used when empty.
+	PathTemplate string
+}
+
+// Text writes the lyrics of each track to a text file in dir, laid out by the path template, and returns the paths
+// of the written files. Directories are created as needed and existing files are replaced.
+func Text(dir string, tracks []Track, opts *TextOptions) ([]string, error) {
+	var text string
+	if opts != nil {
+		text = opts.PathTemplate
+	}
+
+	return writeFiles(dir, tracks, text, DefaultPathTemplate, func(track Track) ([]byte, error) {
+		return []byte(textContent(track)), nil
+	})
+}
+
+// textContent is the content of a track's text file.
+func textContent(track Track) string {
+	return strings.TrimSpace(track.Lyrics) + "\n"
+}
+
+// writeFiles writes content of each track to a file in dir at the path executing the path template text, or
+// defaultText when it is empty, and returns the paths of the written files.
+func writeFiles(dir string, tracks []Track, text string, defaultText string, content func(track Track) ([]byte, error)) ([]string, error) {
+	paths, err := newPathTemplate(text, defaultText)
+	if err != nil {
+		return nil, err
+	}
+
+	var written []string
+	for _, track := range tracks {
+		name, err := paths.path(track)
+		if err != nil {
+			return written, err
+		}
+
+		data, err := content(track)
+		if err != nil {
+			return written, fmt.Errorf("song %d: %w", track.ID, err)
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return written, err
+		}
+		if err = os.WriteFile(path, data, 0o644); err != nil {
+			return written, err
+		}
+		written = append(written, path)
+	}
+
+	return written, nil
+}
+
+type pathTemplate struct {
+	tmpl *template.Template
+}
+
+// newPathTemplate parses the path template text, defaultText when it is empty.
+func newPathTemplate(text string, defaultText string) (*pathTemplate, error) {
+	if text == "" {
+		text = defaultText
+	}
+
+	tmpl, err := template.New("path").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &pathTemplate{tmpl: tmpl}, nil
+}
+
+// path returns the slash separated path of track relative to the export directory.
+func (p *pathTemplate) path(track Track) (string, error) {
+	data := PathData{
+		ID:     track.ID,
+		Artist: SanitizeName(track.Artist()),
+		Album:  SanitizeName(track.AlbumName()),
+		Title:  SanitizeName(track.Title),
+		Number: track.Number,
+		Disc:   track.Disc,
+	}
+	if data.Artist == "_" {
+		data.Artist = "Unknown Artist"
+	}
+	if data.Album == "_" {
+		data.Album = "Unknown Album"
+	}
+
+	var b strings.Builder
+	if err := p.tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+
+	path := filepath.ToSlash(filepath.Clean(filepath.FromSlash(b.String())))
+	if path == "." || filepath.IsAbs(path) || path == ".." || strings.HasPrefix(path, "../") {
+		return "", fmt.Errorf("%w %q for song %d", ErrInvalidPath, b.String(), track.ID)
+	}
+	return path, nil
+}
+
+// SanitizeName makes name safe to use as a file name on common file systems: path separators, characters Windows
+// reserves and control characters are replaced by "_", leading and trailing dots and spaces are removed and the
+// name is shortened to 200 bytes. Empty names become "_".
+func SanitizeName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	name = strings.Trim(name, ". ")
+
+	for len(name) > maxNameLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = strings.TrimRight(name[:len(name)-size], ". ")
+	}
+
+	if name == "" {
+		return "_"
+	}
+	return name
+}
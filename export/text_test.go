@@ -0,0 +1,77 @@
+package export_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/export"
+)
+
+func TestText(t *testing.T) {
+	dir := t.TempDir()
+	album := &genius.Album{Name: "DAMN."}
+	tracks := []export.Track{
+		{Song: &genius.Song{ID: 1, Title: "HUMBLE.", PrimaryArtistNames: "Kendrick Lamar", Album: album, Lyrics: "Sit down\n"}, Number: 8},
+		{Song: &genius.Song{ID: 2, Title: "AC/DC?", PrimaryArtistNames: "Kendrick Lamar", Lyrics: "Untitled"}},
+	}
+
+	paths, err := export.Text(dir, tracks, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		filepath.Join(dir, "Kendrick Lamar", "DAMN", "08 - HUMBLE.txt"),
+		filepath.Join(dir, "Kendrick Lamar", "Unknown Album", "AC_DC_.txt"),
+	}
+	if strings.Join(paths, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("got paths %q, want %q", paths, want)
+	}
+
+	data, err := os.ReadFile(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "Sit down\n" {
+		t.Errorf("unexpected content %q", data)
+	}
+}
+
+func TestTextPathTemplate(t *testing.T) {
+	dir := t.TempDir()
+	tracks := []export.Track{{Song: &genius.Song{ID: 1, Title: "HUMBLE."}}}
+
+	paths, err := export.Text(dir, tracks, &export.TextOptions{PathTemplate: "{{.ID}}-{{.Title}}.lyrics"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "1-HUMBLE.lyrics"); len(paths) != 1 || paths[0] != want {
+		t.Errorf("got paths %q, want %q", paths, want)
+	}
+
+	_, err = export.Text(dir, tracks, &export.TextOptions{PathTemplate: "../{{.Title}}.txt"})
+	if !errors.Is(err, export.ErrInvalidPath) {
+		t.Errorf("expected ErrInvalidPath, got %v", err)
+	}
+}
+
+func TestSanitizeName(t *testing.T) {
+	tests := map[string]string{
+		"HUMBLE.":                  "HUMBLE",
+		"AC/DC: Back in Black?":    "AC_DC_ Back in Black_",
+		"  ...  ":                  "_",
+		"tab\there":                "tab_here",
+		strings.Repeat("é", 150):   strings.Repeat("é", 100),
+		`Who "Knows" <What> | \ *`: "Who _Knows_ _What_ _ _ _",
+	}
+
+	for name, want := range tests {
+		if got := export.SanitizeName(name); got != want {
+			t.Errorf("SanitizeName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/store"
+)
+
+// Track is a song to export, with its lyrics in Song.Lyrics.
+type Track struct {
+	*genius.Song
+
+	// Number is the song's position on its album and Disc the album's disc, 0 when unknown.
+	Number int
+	Disc   int
+}
+
+// Artist returns the name of the song's primary artists.
+func (t Track) Artist() string {
+	if t.PrimaryArtistNames != "" {
+		return t.PrimaryArtistNames
+	}
+	if t.PrimaryArtist != nil {
+		return t.PrimaryArtist.Name
+	}
+	return t.ArtistNames
+}
+
+// AlbumName returns the name of the song's album, empty if it isn't on an album.
+func (t Track) AlbumName() string {
+	if t.Album == nil {
+		return ""
+	}
+	return t.Album.Name
+}
+
+// FromSongs returns songs as tracks without album positions. Their lyrics need to be set, e.g. by
+// Client.GetSongWithLyrics.
+func FromSongs(songs ...*genius.Song) []Track {
+	tracks := make([]Track, len(songs))
+	for i, song := range songs {
+		tracks[i] = Track{Song: song}
+	}
+	return tracks
+}
+
+// FromAlbum returns the tracks of album, fetched with Client.GetAlbum. The songs' lyrics need to be set.
+func FromAlbum(album *genius.Album) []Track {
+	tracks := make([]Track, len(album.Tracks))
+	for i, albumTrack := range album.Tracks {
+		song := albumTrack.Song
+		if song.Album == nil {
+			song.Album = album
+		}
+		tracks[i] = Track{Song: &song, Number: albumTrack.Number, Disc: albumTrack.Disc}
+	}
+	return tracks
+}
+
+// FromStore returns the stored songs with the IDs songIDs with their stored lyrics, songs without lyrics have none.
+func FromStore(ctx context.Context, s store.Store, songIDs ...int) ([]Track, error) {
+	songs := make([]*genius.Song, len(songIDs))
+	for i, id := range songIDs {
+		song, err := s.Song(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("song %d: %w", id, err)
+		}
+		songs[i] = song
+	}
+
+	return withStoredLyrics(ctx, s, songs)
+}
+
+// FromStoredArtist returns the stored songs of the artist with their stored lyrics, see FromStore.
+func FromStoredArtist(ctx context.Context, s store.Store, artistID int) ([]Track, error) {
+	songs, err := s.SongsByArtist(ctx, artistID)
+	if err != nil {
+		return nil, err
+	}
+
+	return withStoredLyrics(ctx, s, songs)
+}
+
+func withStoredLyrics(ctx context.Context, s store.Store, songs []*genius.Song) ([]Track, error) {
+	for _, song := range songs {
+		lyrics, err := s.Lyrics(ctx, song.ID)
+		if err != nil && !errors.Is(err, store.ErrNotFound) {
+			return nil, fmt.Errorf("lyrics of song %d: %w", song.ID, err)
+		}
+		song.Lyrics = lyrics
+	}
+
+	return FromSongs(songs...), nil
+}
@@ -0,0 +1,35 @@
+package export_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/export"
+)
+
+func TestFromStore(t *testing.T) {
+	s := newStore(t, map[string]string{
+		`{"id":1,"title":"HUMBLE.","primary_artist":{"id":1421,"name":"Kendrick Lamar"}}`: "Sit down",
+	})
+
+	tracks, err := export.FromStoredArtist(context.Background(), s, 1421)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tracks) != 1 || tracks[0].Title != "HUMBLE." || tracks[0].Lyrics != "Sit down" || tracks[0].Artist() != "Kendrick Lamar" {
+		t.Errorf("unexpected tracks %+v", tracks)
+	}
+}
+
+func TestFromAlbum(t *testing.T) {
+	album := &genius.Album{Name: "DAMN.", Tracks: []*genius.AlbumTrack{
+		{Number: 1, Song: genius.Song{ID: 1, Title: "BLOOD."}},
+		{Number: 2, Song: genius.Song{ID: 2, Title: "DNA."}},
+	}}
+
+	tracks := export.FromAlbum(album)
+	if len(tracks) != 2 || tracks[1].Number != 2 || tracks[1].Title != "DNA." || tracks[1].AlbumName() != "DAMN." {
+		t.Errorf("unexpected tracks %+v", tracks)
+	}
+}
@@ -0,0 +1,82 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// ZipManifestName is the name of the manifest in archives written by Zip.
+const ZipManifestName = "metadata.json"
+
+// ZipEntry describes a song in the manifest of an archive written by Zip.
+type ZipEntry struct {
+	// Path is the slash separated path of the song's text file in the archive.
+	Path        string `json:"path"`
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	Artist      string `json:"artist,omitempty"`
+	Album       string `json:"album,omitempty"`
+	Number      int    `json:"number,omitempty"`
+	ReleaseDate string `json:"release_date,omitempty"`
+	Language    string `json:"language,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+// Zip streams the tracks to w as a ZIP archive of text files laid out like Text lays them out, followed by a
+// metadata.json manifest listing a ZipEntry per song. Nothing is written to disk, so it suits e.g. HTTP handlers
+// returning an album's lyrics as a download.
+func Zip(w io.Writer, tracks []Track, opts *TextOptions) error {
+	var text string
+	if opts != nil {
+		text = opts.PathTemplate
+	}
+	paths, err := newPathTemplate(text, DefaultPathTemplate)
+	if err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(w)
+	modified := time.Now()
+
+	manifest := make([]ZipEntry, 0, len(tracks))
+	for _, track := range tracks {
+		path, err := paths.path(track)
+		if err != nil {
+			return err
+		}
+
+		file, err := archive.CreateHeader(&zip.FileHeader{Name: path, Method: zip.Deflate, Modified: modified})
+		if err != nil {
+			return err
+		}
+		if _, err = io.WriteString(file, textContent(track)); err != nil {
+			return err
+		}
+
+		manifest = append(manifest, ZipEntry{
+			Path:        path,
+			ID:          track.ID,
+			Title:       track.Title,
+			Artist:      track.Artist(),
+			Album:       track.AlbumName(),
+			Number:      track.Number,
+			ReleaseDate: track.ReleaseDate,
+			Language:    track.Language,
+			URL:         track.URL,
+		})
+	}
+
+	file, err := archive.CreateHeader(&zip.FileHeader{Name: ZipManifestName, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(manifest); err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
@@ -0,0 +1,51 @@
+package export_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/natecham/genius/export"
+)
+
+func TestZip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := export.Zip(&buf, []export.Track{humble}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := make(map[string]string)
+	for _, file := range archive.File {
+		r, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[file.Name] = string(data)
+	}
+
+	path := "Kendrick Lamar/DAMN/08 - HUMBLE. [Clean].txt"
+	if files[path] != humble.Lyrics+"\n" {
+		t.Errorf("unexpected lyrics file %q in %v", files[path], archive.File)
+	}
+
+	var manifest []export.ZipEntry
+	if err = json.Unmarshal([]byte(files[export.ZipManifestName]), &manifest); err != nil {
+		t.Fatal(err)
+	}
+	want := export.ZipEntry{Path: path, ID: 1, Title: "HUMBLE. [Clean]", Artist: "Kendrick Lamar", Album: "DAMN.", Number: 8}
+	if len(manifest) != 1 || manifest[0] != want {
+		t.Errorf("got manifest %+v, want %+v", manifest, want)
+	}
+}
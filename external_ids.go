@@ -0,0 +1,85 @@
+package genius
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var isrcPattern = regexp.MustCompile(`^[A-Z]{2}[A-Z0-9]{3}[0-9]{7}$`)
+
+// ExternalIDs are identifiers of a song in other catalogs, extracted from its media and provider data.
+// Fields are empty when Genius has no link to the respective service.
+type ExternalIDs struct {
+	SpotifyURI    string
+	SpotifyID     string
+	YouTubeID     string
+	AppleMusicID  string
+	SoundCloudURL string
+	ISRC          string
+	UnknownMedia  []*Media
+}
+
+// ExternalIDs extracts Spotify, YouTube, Apple Music and (where present) ISRC identifiers from the song's media
+// entries, simplifying reconciliation with other catalogs such as Spotify or MusicBrainz.
+func (s *Song) ExternalIDs() ExternalIDs {
+	ids := ExternalIDs{AppleMusicID: s.AppleMusicID}
+
+	for _, m := range s.Media {
+		if m == nil {
+			continue
+		}
+
+		switch strings.ToLower(m.Provider) {
+		case "spotify":
+			ids.SpotifyURI, ids.SpotifyID = spotifyIDs(m)
+		case "youtube":
+			ids.YouTubeID = youTubeID(m.URL)
+		case "apple_music", "itunes":
+			if ids.AppleMusicID == "" {
+				ids.AppleMusicID = m.ProviderID
+			}
+		case "soundcloud":
+			ids.SoundCloudURL = m.URL
+		default:
+			ids.UnknownMedia = append(ids.UnknownMedia, m)
+		}
+
+		if ids.ISRC == "" && isrcPattern.MatchString(strings.ToUpper(m.ProviderID)) {
+			ids.ISRC = strings.ToUpper(m.ProviderID)
+		}
+	}
+
+	return ids
+}
+
+func spotifyIDs(m *Media) (string, string) {
+	if strings.HasPrefix(m.NativeURI, "spotify:") {
+		return m.NativeURI, m.NativeURI[strings.LastIndex(m.NativeURI, ":")+1:]
+	}
+
+	u, err := url.Parse(m.URL)
+	if err != nil || !strings.Contains(u.Path, "/track/") {
+		return "", m.ProviderID
+	}
+
+	id := path.Base(u.Path)
+	return "spotify:track:" + id, id
+}
+
+func youTubeID(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+
+	if v := u.Query().Get("v"); v != "" {
+		return v
+	}
+	if strings.HasSuffix(u.Host, "youtu.be") || strings.Contains(u.Path, "/embed/") {
+		return path.Base(u.Path)
+	}
+
+	return ""
+}
@@ -1,40 +1,56 @@
 package genius
 
 import (
+	"errors"
 	"io"
+	"slices"
 	"strings"
 
 	"golang.org/x/net/html"
 	"golang.org/x/net/html/atom"
 )
 
+// ErrNoLyrics is returned by Extract for pages without lyrics, e.g. error pages.
+var ErrNoLyrics = errors.New("no lyrics found in page")
+
 type visitFunc func(node *html.Node) bool
 
 type Extractor struct {
 	reader io.Reader
 	root   *html.Node
 	node   *html.Node
-	text   string
+	text   strings.Builder
 }
 
 func NewExtractor(reader io.Reader) *Extractor {
 	return &Extractor{reader: reader}
 }
 
+// Extract returns the text of the lyrics of the page, a line per text node.
+//
+// Pages without lyrics fail with ErrNoLyrics.
 func (e *Extractor) Extract() (string, error) {
 	if root, err := html.Parse(e.reader); err != nil {
 		return "", err
 	} else {
 		e.root = root
 		e.walk(e.root, e.findDivLyrics)
+		if e.node == nil {
+			e.walk(e.root, e.findAMPLyrics)
+		}
+		if e.node == nil {
+			return "", ErrNoLyrics
+		}
 		e.walk(e.node, e.htmlToText)
-		return e.text, nil
+		return e.text.String(), nil
 	}
 }
 
 func (e *Extractor) htmlToText(node *html.Node) bool {
 	if node.Type == html.TextNode {
-		e.text += node.Data + "\n"
+		// Lyrics pages have thousands of text nodes, concatenating strings would copy the text for each of them.
+		e.text.WriteString(node.Data)
+		e.text.WriteByte('\n')
 	}
 	return true
 }
@@ -64,6 +80,22 @@ func (e *Extractor) findDivLyrics(node *html.Node) bool {
 	return true
 }
 
+// findAMPLyrics finds the lyrics of AMP song pages, which keep them in a div of class lyrics.
+func (e *Extractor) findAMPLyrics(node *html.Node) bool {
+	if node.DataAtom != atom.Div {
+		return true
+	}
+
+	for _, attr := range node.Attr {
+		if attr.Key == "class" && slices.Contains(strings.Fields(attr.Val), "lyrics") {
+			e.node = node
+			return false
+		}
+	}
+
+	return true
+}
+
 func (e *Extractor) walk(node *html.Node, fn visitFunc) {
 	if node.Type == html.CommentNode ||
 		node.Type == html.DoctypeNode ||
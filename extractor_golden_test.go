@@ -0,0 +1,91 @@
+package genius_test
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"flag"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files of the extractor tests")
+
+// pages are saved genius.com song pages, testdata/extractor/{name}.html, with the lyrics expected to be extracted
+// from them in {name}.golden.
+//
+//go:embed testdata/extractor
+var pages embed.FS
+
+// extractionStrategy extracts the lyrics of a song page as GetLyrics returns them.
+type extractionStrategy func(t *testing.T, page []byte) (string, error)
+
+var extractionStrategies = map[string]extractionStrategy{
+	"Extractor": func(t *testing.T, page []byte) (string, error) {
+		lyrics, err := genius.NewExtractor(bytes.NewReader(page)).Extract()
+		return strings.TrimSpace(lyrics), err
+	},
+	"GetLyrics": func(t *testing.T, page []byte) (string, error) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write(page)
+		}))
+		t.Cleanup(server.Close)
+		return genius.NewClient(nil, "token").GetLyrics(context.Background(), server.URL)
+	},
+}
+
+// TestExtractGolden runs every extraction strategy against the saved pages. Run it with -update to rewrite the
+// golden files from the Extractor after changing it, and review their diff.
+func TestExtractGolden(t *testing.T) {
+	names, err := fs.Glob(pages, "testdata/extractor/*.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) == 0 {
+		t.Fatal("no pages in testdata/extractor")
+	}
+
+	for _, name := range names {
+		page, err := pages.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		golden := strings.TrimSuffix(name, ".html") + ".golden"
+
+		if *update {
+			lyrics, err := extractionStrategies["Extractor"](t, page)
+			if err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+			if err = os.WriteFile(golden, []byte(lyrics+"\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+
+		want, err := pages.ReadFile(golden)
+		if err != nil {
+			t.Fatalf("%s has no golden file, run the test with -update: %v", name, err)
+		}
+
+		for strategy, extract := range extractionStrategies {
+			t.Run(path.Base(name)+"/"+strategy, func(t *testing.T) {
+				lyrics, err := extract(t, page)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if lyrics+"\n" != string(want) {
+					t.Errorf("got lyrics\n%s\nwant\n%s", lyrics, want)
+				}
+			})
+		}
+	}
+}
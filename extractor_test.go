@@ -0,0 +1,60 @@
+package genius_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+// lyricsPage returns a song page resembling genius.com with lines lines of lyrics.
+func lyricsPage(lines int) string {
+	var b strings.Builder
+	b.WriteString(`<!DOCTYPE html><html><head><title>Song Lyrics</title></head><body><div id="application">`)
+	b.WriteString(`<div id="lyrics-root"><div class="LyricsHeader__Container">Song Lyrics</div>`)
+	b.WriteString(`<div data-lyrics-container="true" class="Lyrics__Container">`)
+	for i := 0; i < lines; i++ {
+		if i%8 == 0 {
+			fmt.Fprintf(&b, "[Verse %d]<br>", i/8+1)
+		}
+		fmt.Fprintf(&b, `<a href="/1234%d/annotation"><span>Line %d of the lyrics</span></a><br>`, i, i)
+	}
+	b.WriteString(`</div><div class="LyricsFooter__Container">Embed</div></div></div></body></html>`)
+	return b.String()
+}
+
+func TestExtract(t *testing.T) {
+	lyrics, err := genius.NewExtractor(strings.NewReader(lyricsPage(3))).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "[Verse 1]\nLine 0 of the lyrics\nLine 1 of the lyrics\nLine 2 of the lyrics\n"
+	if lyrics != want {
+		t.Fatalf("got %q, want %q", lyrics, want)
+	}
+}
+
+func BenchmarkExtract(b *testing.B) {
+	for _, lines := range []int{100, 1000, 5000} {
+		page := lyricsPage(lines)
+		b.Run(fmt.Sprintf("%d lines", lines), func(b *testing.B) {
+			b.SetBytes(int64(len(page)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := genius.NewExtractor(strings.NewReader(page)).Extract(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestExtractNoLyrics(t *testing.T) {
+	_, err := genius.NewExtractor(strings.NewReader(`<html><body>Not found</body></html>`)).Extract()
+	if !errors.Is(err, genius.ErrNoLyrics) {
+		t.Fatalf("expected ErrNoLyrics, got %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package genius
+
+// TextFormat is the format Genius returns text fields such as descriptions, annotation bodies and lyrics in.
+type TextFormat string
+
+// Text formats supported by the API.
+const (
+	FormatDOM   TextFormat = "dom"
+	FormatPlain TextFormat = "plain"
+	FormatHTML  TextFormat = "html"
+	// FormatMarkdown requests the dom format and renders annotation bodies as Markdown from it. Other text fields are
+	// returned in the dom format.
+	FormatMarkdown TextFormat = "markdown"
+)
+
+// apiFormat returns the format requested from the API for the format.
+func (f TextFormat) apiFormat() TextFormat {
+	if f == FormatMarkdown {
+		return FormatDOM
+	}
+	return f
+}
+
+// RequestOption configures a single request.
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	textFormat   TextFormat
+	verifiedOnly bool
+	states       []AnnotationState
+	refresh      bool
+}
+
+// WithTextFormat sets the format text fields are returned in, FormatDOM by default.
+func WithTextFormat(format TextFormat) RequestOption {
+	return func(o *requestOptions) {
+		o.textFormat = format
+	}
+}
+
+// WithRefresh makes GetArtist, GetAlbum and GetSong request the object from Genius even if it is memoized or cached,
+// see WithMemoSize and WithCache, and replace the memoized and cached copies with it.
+func WithRefresh() RequestOption {
+	return func(o *requestOptions) {
+		o.refresh = true
+	}
+}
+
+func newRequestOptions(opts []RequestOption) *requestOptions {
+	o := &requestOptions{textFormat: FormatDOM}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
@@ -1,51 +1,108 @@
 package genius
 
 import (
+	"bytes"
+	"cmp"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/rs/zerolog/log"
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
+
+	"golang.org/x/time/rate"
 )
 
 const (
 	defaultRetryDuration = time.Second * 5
+	// maxServerErrorRetries is how often a request failing with a 5xx status is retried.
+	maxServerErrorRetries = 3
+	// serverErrorBackoff is the wait before the first retry of a server error, doubling with every further retry.
+	serverErrorBackoff = time.Second
+	// defaultMaxResponseSize limits the size of response bodies, see WithMaxResponseSize.
+	defaultMaxResponseSize = 32 << 20
+	// maxErrorBodySize is how much of the body of a failed request is read into its error.
+	maxErrorBodySize = 64 << 10
 )
 
+// ErrNotFound is returned for lookups Genius answered without the song, album or annotation that was looked up.
+var ErrNotFound = errors.New("not found")
+
 // Client is a client for Genius API.
 type Client struct {
 	AccessToken   string
 	baseURL       string
 	unofficialUrl string
 	client        *http.Client
+	// pageClient makes the requests for genius.com pages, see newPageClient.
+	pageClient *http.Client
+	limiter    *rate.Limiter
+
+	lenient           bool
+	reportDecodeError func(err error)
+	metrics           *metrics
+	onRetry           RetryHook
+	onRateLimited     RetryHook
+	logger            Logger
+	stats             *requestStats
+
+	generateCorrelationIDs bool
+	maxResponseSize        int64
+	memo                   *lru[memoKey, any]
+	concurrency            int
+	cache                  Cache
+	acceptLanguage         string
+	proxies                *proxyPool
+	jar                    http.CookieJar
+	cookies                []*http.Cookie
+	solver                 ChallengeSolver
 }
 
 type ClientOption func(client *Client)
 
 // NewClient creates Client to work with Genius API
-// You can pass http.Client or it will use http.DefaultClient by default
+// You can pass http.Client or it will create one with a transport tuned for many requests to Genius and a
+// request timeout of one minute
 //
 // It requires a token for accessing Genius API.
 func NewClient(httpClient *http.Client, token string, opts ...ClientOption) *Client {
 	if httpClient == nil {
-		httpClient = http.DefaultClient
+		httpClient = newHTTPClient()
 	}
 
-	c := &Client{AccessToken: token, client: httpClient, baseURL: "https://api.genius.com", unofficialUrl: "https://genius.com/api"}
+	c := &Client{
+		AccessToken:   token,
+		client:        httpClient,
+		baseURL:       "https://api.genius.com",
+		unofficialUrl: "https://genius.com/api",
+		stats:         newRequestStats(),
+
+		maxResponseSize: defaultMaxResponseSize,
+		memo:            newLRU[memoKey, any](defaultMemoSize),
+		concurrency:     defaultConcurrency,
+	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
+	c.pageClient = c.newPageClient()
 
 	return c
 }
 
+// WithUnofficialURL provides an alternative base url for the unofficial genius.com API used for endpoints such as
+// page_data and artist albums.
+func WithUnofficialURL(url string) ClientOption {
+	return func(client *Client) {
+		client.unofficialUrl = url
+	}
+}
+
 // WithBaseURL provides an alternative base url to use for requests to the Spotify API. This can be used to connect to a
 // staging or other alternative environment.
 func WithBaseURL(url string) ClientOption {
@@ -54,6 +111,14 @@ func WithBaseURL(url string) ClientOption {
 	}
 }
 
+// WithRateLimit limits the client to requestsPerSecond requests, allowing bursts of up to burst requests.
+// This keeps concurrent pagination from running into Genius rate limits.
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOption {
+	return func(client *Client) {
+		client.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+	}
+}
+
 func retryDuration(resp *http.Response) time.Duration {
 	raw := resp.Header.Get("Retry-After")
 	if raw == "" {
@@ -66,172 +131,224 @@ func retryDuration(resp *http.Response) time.Duration {
 	return time.Duration(seconds) * time.Second
 }
 
-// doRequest makes a request and puts authorization token in headers.
-func (c *Client) doRequest(req *http.Request) ([]byte, error) {
+// doRequest makes a request, puts authorization token in headers and decodes the JSON response into v.
+//
+// Rate limited requests are retried after the time Genius asks for, server errors up to maxServerErrorRetries times
+// with exponential backoff.
+func (c *Client) doRequest(req *http.Request, v any) error {
 	req.Header.Set("Authorization", "Bearer "+c.AccessToken)
 	req.Header.Set("Content-Type", "application/json")
+	acceptGzip(req)
+	c.setAcceptLanguage(req)
+
+	correlationID := c.correlationID(req)
+	if correlationID != "" {
+		req.Header.Set(CorrelationIDHeader, correlationID)
+	}
+
+	start := time.Now()
+	resp, retries, err := c.send(req)
+	status := 0
+	var statusErr *StatusError
+	switch {
+	case resp != nil:
+		status = resp.StatusCode
+		err = c.decodeFrom("", c.limitBody(resp.Body), v)
+		resp.Body.Close()
+	case errors.As(err, &statusErr):
+		status = statusErr.StatusCode
+	}
+	duration := time.Since(start)
+
+	c.stats.record(endpoint(req), duration, err)
+	c.logRequest(req, status, retries, duration, err)
+
+	return correlationError(correlationID, err)
+}
+
+// send makes req until it succeeds or retrying is given up, returning the successful response and the number of
+// retries. The caller must close the response body.
+func (c *Client) send(req *http.Request) (resp *http.Response, retries int, err error) {
+	endpoint := endpoint(req)
+	serverErrors := 0
 
-	for {
-		resp, err := c.client.Do(req)
+	for attempt := 1; ; attempt++ {
+		if c.limiter != nil {
+			if err = c.limiter.Wait(req.Context()); err != nil {
+				return nil, retries, err
+			}
+		}
+
+		start := time.Now()
+		resp, err = c.httpClient(req).Do(req)
+		retries = attempt - 1
+		c.metrics.observeRequest(endpoint, resp, err, time.Since(start))
 		if err != nil {
-			return nil, err
+			return nil, retries, err
+		}
+		if err = gunzip(resp); err != nil {
+			return nil, retries, err
 		}
 
-		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusOK {
+			return resp, retries, nil
+		}
 
-		if resp.StatusCode == 429 || resp.StatusCode == 1015 {
-			time.Sleep(retryDuration(resp))
-			continue
-			/*
-				select {
-					//case <-ctx.Done():
-						// If the context is cancelled, return the original error
-				case <-time.After(retryDuration(resp)):
-					fmt.Printf("Retrying url: %s\n", req.URL.Path)
-					continue
-				}
-			*/
+		// Error bodies are short messages, anything beyond maxErrorBodySize is dropped.
+		body, readErr := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBodySize))
+		resp.Body.Close()
+		if readErr != nil {
+			return nil, retries, readErr
 		}
 
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
+		rateLimited := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == 1015
+		if resp.StatusCode >= 500 {
+			serverErrors++
 		}
+		if rateLimited || (resp.StatusCode >= 500 && serverErrors <= maxServerErrorRetries) {
+			wait := retryDuration(resp)
+			if !rateLimited && resp.Header.Get("Retry-After") == "" {
+				wait = serverErrorBackoff << (serverErrors - 1)
+			}
 
-		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("%s", body)
+			info := RetryInfo{URL: req.URL.String(), Attempt: attempt, Wait: wait, StatusCode: resp.StatusCode}
+			if err = c.waitRetry(req.Context(), endpoint, info, rateLimited); err != nil {
+				return nil, retries, err
+			}
+			continue
 		}
 
-		return body, nil
+		return nil, retries, &StatusError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
-
-	return nil, nil
 }
 
-// GetAccount returns current user account data.
-func (c *Client) GetAccount() (*GeniusResponse, error) {
-	url := fmt.Sprintf(c.baseURL + "/account/")
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
-	if err != nil {
-		return nil, err
-	}
+// waitRetry calls the retry hooks and waits before a request is retried.
+func (c *Client) waitRetry(ctx context.Context, endpoint string, info RetryInfo, rateLimited bool) error {
+	c.metrics.observeRetry(endpoint, rateLimited)
 
-	bytes, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
+	hook := c.onRetry
+	if rateLimited {
+		hook = c.onRateLimited
 	}
-
-	var response GeniusResponse
-	err = json.Unmarshal(bytes, &response)
-	if err != nil {
-		return nil, err
+	if hook != nil {
+		if err := hook(ctx, info); err != nil {
+			return err
+		}
 	}
 
-	return &response, nil
-}
-
-// GetArtist returns Artist object in response
-// Uses "dom" as textFormat by default.
-func (c *Client) GetArtist(id int) (*GeniusResponse, error) {
-	return c.GetArtistDom(id)
-}
-
-// GetArtistDom returns Artist object in response
-// With "dom" as textFormat.
-func (c *Client) GetArtistDom(id int) (*GeniusResponse, error) {
-	return c.getArtist(id, "dom")
-}
+	timer := time.NewTimer(info.Wait)
+	defer timer.Stop()
 
-// GetArtistPlain returns Artist object in response
-// With "plain" as textFormat.
-func (c *Client) GetArtistPlain(id int) (*GeniusResponse, error) {
-	return c.getArtist(id, "plain")
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
-// GetArtistHTML returns Artist object in response
-// With "html" as textFormat.
-func (c *Client) GetArtistHTML(id int) (*GeniusResponse, error) {
-	return c.getArtist(id, "html")
+// GetAccount returns current user account data.
+func (c *Client) GetAccount(ctx context.Context) (*AccountResponse, error) {
+	return get[AccountResponse](ctx, c, c.baseURL+"/account/", nil)
 }
 
-func getPerPage(total int, fetched int, perPage int) int {
-	if newPerPage := total - fetched; newPerPage < perPage {
-		return newPerPage
+// GetArtist returns Artist object in response.
+//
+// Text fields are returned in the dom format unless another one is set with WithTextFormat.
+func (c *Client) GetArtist(ctx context.Context, id int, opts ...RequestOption) (*ArtistResponse, error) {
+	o := newRequestOptions(opts)
+	key := memoKey{endpoint: "/artists/:id", id: id, textFormat: o.textFormat}
+	if artist, ok := memoized[Artist](c, key); ok && !o.refresh {
+		response := &ArtistResponse{}
+		response.Response.Artist = artist
+		return response, nil
+	}
+	if c.cacheable(key) && !o.refresh {
+		if artist, err := c.cache.Artist(ctx, id); err == nil {
+			memoize(c, key, artist)
+			response := &ArtistResponse{}
+			response.Response.Artist = artist
+			return response, nil
+		}
 	}
-	return perPage
-}
 
-func (c *Client) GetArtistSongs(id int, sort string, total int) ([]*Song, error) {
-	perPage := 50
-	var songs []*Song
-	page := 1
+	response, err := c.getArtist(ctx, id, key.textFormat)
+	if err != nil {
+		return nil, err
+	}
+	if response.Response.Artist != nil {
+		memoize(c, key, response.Response.Artist)
+		if c.cacheable(key) {
+			c.logCacheError(ctx, key, c.cache.SaveArtist(ctx, response.Response.Artist))
+		}
+	}
 
-	// Check if total is 0 and set a flag
-	fetchUntilEnd := total == -1
+	return response, nil
+}
 
-	// Initialize newPerPage only if total is not -1
-	var newPerPage int
-	if !fetchUntilEnd {
-		newPerPage = getPerPage(total, (page-1)*perPage, perPage)
+// GetArtistSongs returns up to total songs of an artist in the given order, all songs if total is -1.
+//
+// Fetching stops at the last page even if the artist has fewer than total songs.
+func (c *Client) GetArtistSongs(ctx context.Context, id int, sort Sort, total int) ([]*Song, error) {
+	if total == 0 {
+		return nil, nil
 	}
 
-	for fetchUntilEnd || newPerPage > 0 {
+	opts := &ArtistSongsOptions{Sort: sort}
+	if total > 0 {
+		opts.MaxItems = total
+	}
 
-		response, err := c.getArtistSongsPage(id, sort, newPerPage, page)
+	var songs []*Song
+	for song, err := range c.ArtistSongs(ctx, id, opts) {
 		if err != nil {
 			return nil, err
 		}
+		songs = append(songs, song)
+	}
 
-		// Break the loop if NextPage is nil and total is 0
-		if fetchUntilEnd && response.Response.NextPage == 0 {
-			break
-		}
+	return songs, nil
+}
 
-		songs = append(songs, response.Response.Songs...)
+// GetArtistSongsByPopularity returns the limit most popular songs of an artist in Genius's popularity order, all of
+// them if limit isn't positive. Pages are requested no larger than limit and fetching stops once limit songs were
+// fetched. See GetArtistTopSongs for songs ordered strictly by page views.
+func (c *Client) GetArtistSongsByPopularity(ctx context.Context, id int, limit int) ([]*Song, error) {
+	opts := &ArtistSongsOptions{Sort: SortPopularity}
+	if limit > 0 {
+		opts.MaxItems = limit
+		opts.PerPage = min(limit, defaultPerPage)
+	}
 
-		page = response.Response.NextPage
-		if !fetchUntilEnd {
-			newPerPage = getPerPage(total, (page-1)*perPage, perPage)
+	var songs []*Song
+	for song, err := range c.ArtistSongs(ctx, id, opts) {
+		if err != nil {
+			return nil, err
 		}
+		songs = append(songs, song)
 	}
 
 	return songs, nil
 }
 
 // GetArtistSongs returns array of songs objects in response.
-func (c *Client) getArtistSongsPage(id int, sort string, perPage int, page int) (*GeniusResponse, error) {
-	url := fmt.Sprintf(c.baseURL+"/artists/%d/songs", id)
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	q := req.URL.Query()
-	q.Add("sort", sort)
-	q.Add("per_page", strconv.Itoa(perPage))
-	q.Add("page", strconv.Itoa(page))
-	req.URL.RawQuery = q.Encode()
-
-	bytes, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
-	}
-
-	var response GeniusResponse
-	err = json.Unmarshal(bytes, &response)
-	if err != nil {
-		return nil, err
+func (c *Client) getArtistSongsPage(ctx context.Context, id int, sort Sort, perPage int, page int) (*Page[*Song], error) {
+	params := url.Values{}
+	if sort != "" {
+		params.Set("sort", string(sort))
 	}
 
-	return &response, nil
+	return getPage[*Song](ctx, c, fmt.Sprintf(c.baseURL+"/artists/%d/songs", id), params, "songs", perPage, page)
 }
 
-func (c *Client) GetSongWithLyrics(id int) (*Song, error) {
-	song, err := c.GetSongDom(id)
+// GetSongWithLyrics returns Song object in response with Lyrics scraped from the song page.
+func (c *Client) GetSongWithLyrics(ctx context.Context, id int, opts ...RequestOption) (*Song, error) {
+	song, err := c.GetSong(ctx, id, opts...)
 	if err != nil {
 		return nil, err
 	}
-	lyrics, err := c.GetLyrics(song.URL)
+	lyrics, err := c.GetLyrics(ctx, song.URL)
 	if err != nil {
 		return nil, err
 	}
@@ -240,308 +357,312 @@ func (c *Client) GetSongWithLyrics(id int) (*Song, error) {
 	return song, nil
 }
 
-// GetSong returns Song object in response
-//
-// Uses "dom" as textFormat by default.
-func (c *Client) GetSong(id int) (*Song, error) {
-	return c.GetSongDom(id)
-}
-
-// GetSongDom returns Song object in response
-// With "dom" as textFormat.
-func (c *Client) GetSongDom(id int) (*Song, error) {
-	return c.getSong(id, "dom")
-}
-
-// GetSongPlain returns Song object in response
-// With "plain" as textFormat.
-func (c *Client) GetSongPlain(id int) (*Song, error) {
-	return c.getSong(id, "plain")
-}
-
-// GetSongHTML returns Song object in response
-// With "html" as textFormat.
-func (c *Client) GetSongHTML(id int) (*Song, error) {
-	return c.getSong(id, "html")
-}
-
 // GetSong returns Song object in response.
-func (c *Client) getSong(id int, textFormat string) (*Song, error) {
-	url := fmt.Sprintf(c.baseURL+"/songs/%d", id)
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
-	if err != nil {
-		return nil, err
+//
+// Text fields are returned in the dom format unless another one is set with WithTextFormat.
+func (c *Client) GetSong(ctx context.Context, id int, opts ...RequestOption) (*Song, error) {
+	o := newRequestOptions(opts)
+	key := memoKey{endpoint: "/songs/:id", id: id, textFormat: o.textFormat}
+	if song, ok := memoized[Song](c, key); ok && !o.refresh {
+		return song, nil
+	}
+	if c.cacheable(key) && !o.refresh {
+		if song, err := c.cache.Song(ctx, id); err == nil {
+			memoize(c, key, song)
+			return song, nil
+		}
 	}
 
-	q := req.URL.Query()
-	q.Add("text_format", textFormat)
-	req.URL.RawQuery = q.Encode()
-
-	bytes, err := c.doRequest(req)
+	song, err := c.getSong(ctx, id, key.textFormat)
 	if err != nil {
 		return nil, err
 	}
+	memoize(c, key, song)
+	if c.cacheable(key) {
+		c.logCacheError(ctx, key, c.cache.SaveSong(ctx, song))
+	}
 
-	var response GeniusResponse
-	err = json.Unmarshal(bytes, &response)
+	return song, nil
+}
+
+func (c *Client) getSong(ctx context.Context, id int, textFormat TextFormat) (*Song, error) {
+	response, err := get[SongResponse](ctx, c, fmt.Sprintf(c.baseURL+"/songs/%d", id), textFormatParams(textFormat))
 	if err != nil {
 		return nil, err
 	}
 
-	if response.Response == nil {
-		return nil, errors.New("No song found")
+	if response.Response.Song == nil {
+		return nil, fmt.Errorf("song %d %w", id, ErrNotFound)
 	}
 
 	return response.Response.Song, nil
 }
 
-func (c *Client) GetArtistAlbums(id int) ([]*Album, error) {
+// GetArtistAlbums returns the albums of an artist, limited and paged according to opts which may be nil.
+func (c *Client) GetArtistAlbums(ctx context.Context, id int, opts *ListOptions) ([]*Album, error) {
 	var albums []*Album
-	page := 1
-	for page >= 1 {
-		response, err := c.getArtistAlbumsPage(id, 50, page)
+	for album, err := range c.ArtistAlbums(ctx, id, opts) {
 		if err != nil {
 			return nil, err
 		}
-
-		page = response.Response.NextPage
-		albums = append(albums, response.Response.Albums...)
+		albums = append(albums, album)
 	}
 
 	return albums, nil
 }
 
-func (c *Client) getArtistAlbumsPage(id int, perPage int, page int) (*GeniusResponse, error) {
-	getArtistAlbumsURL := fmt.Sprintf(c.unofficialUrl+"/artists/%d/albums", id)
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, getArtistAlbumsURL, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	q := req.URL.Query()
-	q.Add("per_page", strconv.Itoa(perPage))
-	q.Add("page", strconv.Itoa(page))
-	req.URL.RawQuery = q.Encode()
+func (c *Client) getArtistAlbumsPage(ctx context.Context, id int, perPage int, page int) (*Page[*Album], error) {
+	return getPage[*Album](ctx, c, fmt.Sprintf(c.unofficialUrl+"/artists/%d/albums", id), nil, "albums", perPage, page)
+}
 
-	bytes, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
+// GetAlbum returns Album object in response, including its tracks if getTracks is set.
+//
+// Text fields are returned in the dom format unless another one is set with WithTextFormat.
+func (c *Client) GetAlbum(ctx context.Context, id int, getTracks bool, opts ...RequestOption) (*Album, error) {
+	o := newRequestOptions(opts)
+	key := memoKey{endpoint: "/albums/:id", id: id, textFormat: o.textFormat, tracks: getTracks}
+	if album, ok := memoized[Album](c, key); ok && !o.refresh {
+		return album, nil
+	}
+	if c.cacheable(key) && !o.refresh {
+		if album, err := c.cache.Album(ctx, id); err == nil && (!getTracks || album.Tracks != nil) {
+			memoize(c, key, album)
+			return album, nil
+		}
 	}
 
-	var response GeniusResponse
-	err = json.Unmarshal(bytes, &response)
+	album, err := c.getAlbum(ctx, id, getTracks, key.textFormat)
 	if err != nil {
 		return nil, err
 	}
+	memoize(c, key, album)
+	if c.cacheable(key) {
+		c.logCacheError(ctx, key, c.cache.SaveAlbum(ctx, album))
+	}
 
-	return &response, nil
-}
-
-// GetAlbum returns Album object in response
-func (c *Client) GetAlbum(id int, getTracks bool) (*Album, error) {
-	return c.getAlbumDom(id, getTracks)
+	return album, nil
 }
 
-func (c *Client) getAlbumDom(id int, getTracks bool) (*Album, error) {
-	return c.getAlbum(id, getTracks, "dom")
-}
+func (c *Client) getAlbum(ctx context.Context, id int, getTracks bool, textFormat TextFormat) (*Album, error) {
+	// The album and its tracks are fetched in parallel if the client's concurrency allows.
+	g, ctx := c.group(ctx)
 
-func (c *Client) getAlbum(id int, getTracks bool, textFormat string) (*Album, error) {
-	getAlbumURL := fmt.Sprintf(c.baseURL+"/albums/%d", id)
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, getAlbumURL, nil)
-	if err != nil {
-		return nil, err
-	}
+	var album *Album
+	g.Go(func() error {
+		response, err := get[AlbumResponse](ctx, c, fmt.Sprintf(c.baseURL+"/albums/%d", id), textFormatParams(textFormat))
+		if err != nil {
+			return err
+		}
 
-	q := req.URL.Query()
-	q.Add("text_format", textFormat)
-	req.URL.RawQuery = q.Encode()
+		if response.Response.Album == nil {
+			return fmt.Errorf("album %d %w", id, ErrNotFound)
+		}
+		album = response.Response.Album
+		return nil
+	})
 
-	bytes, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
+	var tracks []*AlbumTrack
+	if getTracks {
+		g.Go(func() error {
+			var err error
+			tracks, err = c.GetAlbumTracks(ctx, id, nil)
+			return err
+		})
 	}
 
-	var response GeniusResponse
-	err = json.Unmarshal(bytes, &response)
-	if err != nil {
+	if err := g.Wait(); err != nil {
 		return nil, err
 	}
 
 	if getTracks {
-		albumTracks, err := c.GetAlbumTracks(id)
-		if err != nil {
-			return nil, err
-		}
-
-		response.Response.Album.Tracks = albumTracks
+		album.Tracks = tracks
 	}
 
-	return response.Response.Album, nil
+	return album, nil
 }
 
-func (c *Client) GetAlbumTracks(id int) ([]*AlbumTrack, error) {
+// GetAlbumTracks returns the tracks of an album, limited and paged according to opts which may be nil.
+func (c *Client) GetAlbumTracks(ctx context.Context, id int, opts *ListOptions) ([]*AlbumTrack, error) {
 	var tracks []*AlbumTrack
-	page := 1
-	for page >= 1 {
-		response, err := c.getAlbumTracksPage(id, 50, page)
+	for track, err := range c.AlbumTracks(ctx, id, opts) {
 		if err != nil {
 			return nil, err
 		}
-
-		page = response.Response.NextPage
-		tracks = append(tracks, response.Response.AlbumTracks...)
+		tracks = append(tracks, track)
 	}
 
 	return tracks, nil
 }
 
-func (c *Client) getAlbumTracksPage(id int, perPage int, page int) (*GeniusResponse, error) {
-	getAlbumURL := fmt.Sprintf(c.baseURL+"/albums/%d/tracks", id)
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, getAlbumURL, nil)
+// GetAlbumTracksWithLyrics returns the tracks of an album ordered by disc and track number, each with its full Song
+// and its lyrics, see GetSongWithLyrics. The songs are fetched in parallel as far as the client's concurrency allows,
+// see WithConcurrency.
+func (c *Client) GetAlbumTracksWithLyrics(ctx context.Context, id int, opts ...RequestOption) ([]*AlbumTrack, error) {
+	tracks, err := c.GetAlbumTracks(ctx, id, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	q := req.URL.Query()
-	q.Add("per_page", strconv.Itoa(perPage))
-	q.Add("page", strconv.Itoa(page))
-	req.URL.RawQuery = q.Encode()
-
-	bytes, err := c.doRequest(req)
-	if err != nil {
-		return nil, err
+	g, ctx := c.group(ctx)
+	for _, track := range tracks {
+		g.Go(func() error {
+			song, err := c.GetSongWithLyrics(ctx, track.Song.ID, opts...)
+			if err != nil {
+				return fmt.Errorf("track %d: %w", track.Number, err)
+			}
+			track.Song = *song
+			return nil
+		})
 	}
-
-	var response GeniusResponse
-	err = json.Unmarshal(bytes, &response)
-	if err != nil {
+	if err = g.Wait(); err != nil {
 		return nil, err
 	}
 
-	return &response, nil
+	slices.SortStableFunc(tracks, func(a, b *AlbumTrack) int {
+		return cmp.Or(cmp.Compare(a.Disc, b.Disc), cmp.Compare(a.Number, b.Number))
+	})
+	return tracks, nil
 }
 
-// getArtist is a method taking id and textFormat as arguments to make request and return Artist object in response.
-func (c *Client) getArtist(id int, textFormat string) (*GeniusResponse, error) {
-	getArtistURL := fmt.Sprintf(c.baseURL+"/artists/%d", id)
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, getArtistURL, nil)
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) getAlbumTracksPage(ctx context.Context, id int, perPage int, page int) (*Page[*AlbumTrack], error) {
+	return getPage[*AlbumTrack](ctx, c, fmt.Sprintf(c.baseURL+"/albums/%d/tracks", id), nil, "tracks", perPage, page)
+}
 
-	q := req.URL.Query()
-	q.Add("text_format", textFormat)
-	req.URL.RawQuery = q.Encode()
+func (c *Client) getReferentsPage(ctx context.Context, songID int, textFormat TextFormat, perPage int, page int) (*Page[*Referent], error) {
+	params := textFormatParams(textFormat)
+	params.Set("song_id", strconv.Itoa(songID))
 
-	bytes, err := c.doRequest(req)
+	referents, err := getPage[*Referent](ctx, c, c.baseURL+"/referents", params, "referents", perPage, page)
 	if err != nil {
 		return nil, err
 	}
-
-	var response GeniusResponse
-	err = json.Unmarshal(bytes, &response)
-	if err != nil {
-		return nil, err
+	for _, referent := range referents.Items {
+		for _, annotation := range referent.Annotations {
+			annotation.Process(textFormat)
+		}
 	}
 
-	return &response, nil
+	// The referents endpoint doesn't report next_page.
+	referents.NextPage = nextPageBySize(page, perPage, len(referents.Items))
+
+	return referents, nil
 }
 
-// Search returns array of Hit objects in response
-//
-// Currently only songs are searchable by this handler.
-func (c *Client) Search(q string) (*GeniusResponse, error) {
-	searchURL := fmt.Sprintf(c.baseURL + "/search")
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, searchURL, nil)
-	if err != nil {
-		return nil, err
-	}
+// getArtist is a method taking id and textFormat as arguments to make request and return Artist object in response.
+func (c *Client) getArtist(ctx context.Context, id int, textFormat TextFormat) (*ArtistResponse, error) {
+	return get[ArtistResponse](ctx, c, fmt.Sprintf(c.baseURL+"/artists/%d", id), textFormatParams(textFormat))
+}
 
-	getParams := req.URL.Query()
-	getParams.Add("q", q)
-	req.URL.RawQuery = getParams.Encode()
+// Search returns the song hits of a search for q. Only songs are searchable by this handler.
+func (c *Client) Search(ctx context.Context, q string) (*SearchResponse, error) {
+	return get[SearchResponse](ctx, c, c.baseURL+"/search", url.Values{"q": {q}})
+}
 
-	bytes, err := c.doRequest(req)
+func (c *Client) searchPage(ctx context.Context, q string, perPage int, page int) (*Page[*Hit], error) {
+	hits, err := getPage[*Hit](ctx, c, c.baseURL+"/search", url.Values{"q": {q}}, "hits", perPage, page)
 	if err != nil {
 		return nil, err
 	}
 
-	var response GeniusResponse
-	err = json.Unmarshal(bytes, &response)
-	if err != nil {
-		return nil, err
-	}
+	// The search endpoint doesn't report next_page.
+	hits.NextPage = nextPageBySize(page, perPage, len(hits.Items))
 
-	return &response, nil
+	return hits, nil
 }
 
-//https://genius.com/api/page_data/album?page_path=%2Falbums%2FVarious-artists%2FAbove-the-rim-the-soundtrack
-
-func (c *Client) WebSearch(perPage int, searchTerm string) (*GeniusResponse, error) {
-	searchURL := fmt.Sprintf(c.baseURL + "/search/multi")
+// GetSongByPath returns the full Song for a genius.com page path such as "/Kendrick-lamar-humble-lyrics",
+// resolved through the unofficial page_data API so the numeric song ID is not needed up front.
+// A full song URL is accepted as well.
+func (c *Client) GetSongByPath(ctx context.Context, path string) (*Song, error) {
+	path = pagePath(path)
 
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, searchURL, nil)
+	response, err := get[PageDataResponse](ctx, c, c.unofficialUrl+"/page_data/song", url.Values{"page_path": {path}})
 	if err != nil {
 		return nil, err
 	}
 
-	params := url.Values{}
-	params.Add("per_page", strconv.Itoa(perPage))
-	params.Add("q", searchTerm)
-	req.URL.RawQuery = params.Encode()
+	if response.Response.PageData == nil || response.Response.PageData.Song == nil {
+		return nil, fmt.Errorf("song at %s %w", path, ErrNotFound)
+	}
+
+	return response.Response.PageData.Song, nil
+}
 
-	bytes, err := c.doRequest(req)
+// GetAlbumByPath returns the Album for a genius.com page path such as "/albums/Kendrick-lamar/Damn", resolved
+// through the unofficial page_data API like GetSongByPath. A full album URL is accepted as well.
+func (c *Client) GetAlbumByPath(ctx context.Context, path string) (*Album, error) {
+	path = pagePath(path)
+
+	response, err := get[PageDataResponse](ctx, c, c.unofficialUrl+"/page_data/album", url.Values{"page_path": {path}})
 	if err != nil {
 		return nil, err
 	}
 
-	var response GeniusResponse
-	err = json.Unmarshal(bytes, &response)
-	if err != nil {
-		return nil, err
+	if response.Response.PageData == nil || response.Response.PageData.Album == nil {
+		return nil, fmt.Errorf("album at %s %w", path, ErrNotFound)
 	}
 
-	return &response, nil
+	return response.Response.PageData.Album, nil
 }
 
-// GetAnnotation gets annotation object in response.
-func (c *Client) GetAnnotation(id string, textFormat string) (*GeniusResponse, error) {
-	annotationsURL := fmt.Sprintf(c.baseURL+"/annotations/%s", id)
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, annotationsURL, nil)
-	if err != nil {
-		return nil, err
+// pagePath returns the path of a genius.com page URL, or path itself with a leading slash.
+func pagePath(path string) string {
+	if u, err := url.Parse(path); err == nil && u.Host != "" {
+		path = u.Path
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
 	}
+	return path
+}
 
-	q := req.URL.Query()
-	q.Add("text_format", textFormat)
-	req.URL.RawQuery = q.Encode()
+// WebSearch returns the sections of up to perPage hits each of a search for searchTerm, as genius.com's
+// search does: songs, artists, albums, lyrics and more.
+func (c *Client) WebSearch(ctx context.Context, perPage int, searchTerm string) (*WebSearchResponse, error) {
+	params := url.Values{"per_page": {strconv.Itoa(perPage)}, "q": {searchTerm}}
+	return get[WebSearchResponse](ctx, c, c.baseURL+"/search/multi", params)
+}
+
+// GetAnnotation returns the annotation with the ID, its Body rendered in the text format.
+//
+// The body is rendered as plain text from the dom format unless another format is set with WithTextFormat, see
+// WithBody.Process.
+func (c *Client) GetAnnotation(ctx context.Context, id int, opts ...RequestOption) (*Annotation, error) {
+	textFormat := newRequestOptions(opts).textFormat
 
-	bytes, err := c.doRequest(req)
+	response, err := get[AnnotationResponse](ctx, c, fmt.Sprintf(c.baseURL+"/annotations/%d", id), textFormatParams(textFormat))
 	if err != nil {
 		return nil, err
 	}
 
-	var response GeniusResponse
-	err = json.Unmarshal(bytes, &response)
-	if err != nil {
-		return nil, err
+	if response.Response.Annotation == nil {
+		return nil, fmt.Errorf("annotation %d %w", id, ErrNotFound)
 	}
 
 	response.Response.Annotation.Process(textFormat)
 
-	return &response, nil
+	return response.Response.Annotation, nil
 }
 
-func GetArtistFromSearchResponse(response *GeniusResponse, searchTerm string) (*Song, error) {
-	return getItemFromSearchResponse(response, searchTerm, "artist", "name")
+// GetArtistFromSearchResponse returns the artist hit named searchTerm, or the first artist hit if none matches exactly.
+//
+// Rankers replace the exact match ranking, the hit with the highest sum of their scores is returned, see Ranker.
+func GetArtistFromSearchResponse(response *WebSearchResponse, searchTerm string, rankers ...Ranker) (*Artist, error) {
+	return getItemFromSearchResponse(response, searchTerm, HitTypeArtist, (*Hit).AsArtist, rankers)
 }
 
-func GetSongFromSearchResponse(response *GeniusResponse, searchTerm string) (*Song, error) {
-	return getItemFromSearchResponse(response, searchTerm, "song", "title")
+// GetSongFromSearchResponse returns the song hit titled searchTerm, or the first song hit if none matches exactly.
+//
+// Rankers replace the exact match ranking like for GetArtistFromSearchResponse, e.g. to prefer an artist and
+// penalize translations:
+//
+//	song, err := genius.GetSongFromSearchResponse(response, title,
+//		genius.ExactMatch, genius.PreferArtist(artist), genius.PenalizeTranslations)
+func GetSongFromSearchResponse(response *WebSearchResponse, searchTerm string, rankers ...Ranker) (*Song, error) {
+	return getItemFromSearchResponse(response, searchTerm, HitTypeSong, (*Hit).AsSong, rankers)
 }
 
-func getItemFromSearchResponse(response *GeniusResponse, searchTerm string, itemType string, resultType string) (*Song, error) {
+func getItemFromSearchResponse[T any](response *WebSearchResponse, searchTerm string, itemType string, decode func(*Hit) (T, error), rankers []Ranker) (T, error) {
 	var hits []Hit
 	for _, section := range response.Response.Sections {
 		if section.Type == itemType {
@@ -549,54 +670,103 @@ func getItemFromSearchResponse(response *GeniusResponse, searchTerm string, item
 		}
 	}
 
-	for _, hit := range hits {
-		if strings.EqualFold(resultType, "name") {
-			if strings.EqualFold(hit.Result.Title, searchTerm) {
-				return hit.Result, nil
+	var zero T
+	if len(hits) < 1 {
+		return zero, fmt.Errorf("could not find a match for: %s", searchTerm)
+	}
+
+	if len(rankers) == 0 {
+		rankers = []Ranker{ExactMatch}
+	}
+	return decode(&hits[rank(hits, searchTerm, rankers)])
+}
+
+// GetLyrics scrapes the lyrics from the song page at uri.
+//
+// If the page can't be fetched, e.g. because genius.com served an anti-bot challenge instead, the lyrics are scraped
+// from the AMP version of the page as a mobile browser would request it. The error of the desktop page is returned if
+// that fails as well, ErrBlocked if genius.com blocked the request. If either page was blocked, it is passed to the
+// solver of WithChallengeSolver, if set, with ctx. Pages without lyrics fail with ErrNoLyrics.
+func (c *Client) GetLyrics(ctx context.Context, uri string) (string, error) {
+	lyrics, err := c.scrapeLyrics(ctx, uri, "")
+	var blocked *BlockedError
+	errors.As(err, &blocked)
+	if err != nil && fallBackToAMP(err) {
+		if amp, ampErr := ampURL(uri); ampErr == nil {
+			lyrics, ampErr = c.scrapeLyrics(ctx, amp, mobileUserAgent)
+			switch {
+			case ampErr == nil:
+				err = nil
+			case blocked == nil:
+				errors.As(ampErr, &blocked)
 			}
 		}
 	}
+	if err != nil && blocked != nil && c.solver != nil {
+		lyrics, err = c.solveChallenge(ctx, blocked)
+	}
+	if err != nil {
+		return "", err
+	}
 
-	if len(hits) < 1 {
-		return nil, fmt.Errorf("could not find a match for: %s", searchTerm)
+	lyrics = strings.TrimSpace(lyrics)
+
+	if strings.HasSuffix(lyrics, "Embed") {
+		found := false
+		lyrics, found = strings.CutSuffix(lyrics, "Embed")
+		if found {
+			log.Debug().Msg("Embed found at end of lyrics")
+		}
 	}
-	return hits[0].Result, nil
+
+	return lyrics, nil
 }
 
-func (c *Client) GetLyrics(uri string) (string, error) {
+// scrapeLyrics extracts the lyrics from the page at uri, requested with userAgent unless it is empty.
+func (c *Client) scrapeLyrics(ctx context.Context, uri string, userAgent string) (string, error) {
 	var err error
 	var req *http.Request
 	var res *http.Response
 
-	if req, err = http.NewRequest(http.MethodGet, uri, nil); err != nil {
+	if req, err = http.NewRequestWithContext(ctx, http.MethodGet, uri, nil); err != nil {
 		return "", err
 	}
+	acceptGzip(req)
+	c.setAcceptLanguage(req)
+	if userAgent != "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
 
-	if res, err = c.client.Do(req); err != nil {
+	if res, err = c.pageClient.Do(req); err != nil {
+		return "", err
+	}
+	if err = gunzip(res); err != nil {
 		return "", err
 	}
-	//
 	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+		if isBlocked(res.StatusCode, body) {
+			return "", &BlockedError{URL: uri, StatusCode: res.StatusCode}
+		}
+		return "", fmt.Errorf("lyrics page %s: %w", uri, &StatusError{StatusCode: res.StatusCode})
+	}
 
-	bodyBytes, err := io.ReadAll(res.Body)
+	page, err := io.ReadAll(c.limitBody(res.Body))
 	if err != nil {
 		return "", err
 	}
 
-	lyrics, extractErr := NewExtractor(strings.NewReader(string(bodyBytes))).Extract()
-	if extractErr != nil {
-		return "", extractErr
-	}
-
-	lyrics = strings.TrimSpace(lyrics)
+	return extractLyrics(uri, res.StatusCode, page)
+}
 
-	if strings.HasSuffix(lyrics, "Embed") {
-		found := false
-		lyrics, found = strings.CutSuffix(lyrics, "Embed")
-		if found {
-			log.Debug().Msg("Embed found at end of lyrics")
-		}
+// extractLyrics extracts the lyrics from page, the page at uri served with statusCode, returning a BlockedError if
+// it is an anti-bot challenge.
+func extractLyrics(uri string, statusCode int, page []byte) (string, error) {
+	// Challenges are detected only when there are no lyrics, as regular pages may contain challenge markers too.
+	lyrics, err := NewExtractor(bytes.NewReader(page)).Extract()
+	if errors.Is(err, ErrNoLyrics) && isChallenge(page) {
+		return "", &BlockedError{URL: uri, StatusCode: statusCode}
 	}
-
-	return lyrics, nil
+	return lyrics, err
 }
@@ -1,8 +1,13 @@
 package genius_test
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/natecham/genius"
@@ -12,7 +17,7 @@ func TestNewClient(t *testing.T) {
 	accessToken := os.Getenv("ACCESS_TOKEN")
 	client := genius.NewClient(nil, accessToken)
 
-	response, err := client.GetArtistHTML(1177)
+	response, err := client.GetArtist(context.Background(), 1177, genius.WithTextFormat(genius.FormatHTML))
 	if err != nil {
 		t.Fatal("error occurred getting artist", err)
 	}
@@ -23,12 +28,12 @@ func TestNewClient(t *testing.T) {
 
 	t.Log(response.Response.Artist.Name)
 
-	song, err := client.GetSong(57418)
+	song, err := client.GetSong(context.Background(), 57418)
 	if err != nil {
 		t.Fatal("error occurred getting song", err)
 	}
 
-	lyrics, lyricsErr := client.GetLyrics(song.URL)
+	lyrics, lyricsErr := client.GetLyrics(context.Background(), song.URL)
 	if lyricsErr != nil {
 		t.Fatal("error occurred getting lyrics", lyricsErr)
 	}
@@ -37,7 +42,7 @@ func TestNewClient(t *testing.T) {
 		t.Fatal("lyrics missing")
 	}
 
-	song2, lyErr := client.GetSongWithLyrics(57418)
+	song2, lyErr := client.GetSongWithLyrics(context.Background(), 57418)
 	if lyErr != nil {
 		t.Fatal("error getting lyrics with GetSongWithLyrics")
 	}
@@ -51,3 +56,43 @@ func TestNewClient(t *testing.T) {
 	}
 
 }
+
+func TestRequestsCancelled(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	t.Cleanup(server.Close)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := map[string]func() error{
+		"GetAccount": func() error { _, err := client.GetAccount(ctx); return err },
+		"GetArtistSongs": func() error {
+			_, err := client.GetArtistSongs(ctx, 1, genius.SortTitle, -1)
+			return err
+		},
+		"Search":    func() error { _, err := client.Search(ctx, "humble"); return err },
+		"WebSearch": func() error { _, err := client.WebSearch(ctx, 5, "humble"); return err },
+		"GetLyrics": func() error { _, err := client.GetLyrics(ctx, server.URL+"/lyrics"); return err },
+	}
+	for name, call := range calls {
+		if err := call(); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: got %v, want context.Canceled", name, err)
+		}
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("got %d requests with a cancelled context", n)
+	}
+}
+
+func TestGetSongNotFound(t *testing.T) {
+	server := newSongServer(t, `{"meta": {"status": 200}, "response": {}}`)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	if _, err := client.GetSong(context.Background(), 1); !errors.Is(err, genius.ErrNotFound) {
+		t.Errorf("got %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,144 @@
+package geniustest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/natecham/genius"
+)
+
+// CaptureOptions configure Capture.
+type CaptureOptions struct {
+	// Dir is the testdata directory the fixtures are written to, "testdata" if empty.
+	Dir string
+	// Name is the name of the fixtures, the path of the song's page without "-lyrics" in lower case if empty.
+	Name string
+	// HTTPClient downloads the lyrics page, http.DefaultClient if nil.
+	HTTPClient *http.Client
+}
+
+// Capture saves the song with the ID as test fixtures in the layout of the genius package's testdata: its lyrics
+// page as {Dir}/extractor/{Name}.html, which the extractor's golden tests pick up once its golden file was written
+// with -update, and the API responses of the song, its album and its primary artist as
+// {Dir}/responses/{Name}/{song,album,artist}.json. The client's access token and the CSRF tokens of the page are
+// scrubbed from the fixtures. The paths of the written files are returned.
+//
+// The client shouldn't have a cache, see genius.WithCache, so the responses are saved as Genius sent them.
+func Capture(ctx context.Context, client *genius.Client, id int, opts *CaptureOptions) ([]string, error) {
+	if opts == nil {
+		opts = &CaptureOptions{}
+	}
+	dir := opts.Dir
+	if dir == "" {
+		dir = "testdata"
+	}
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	song, err := client.GetSong(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting song %d: %w", id, err)
+	}
+	name := opts.Name
+	if name == "" {
+		name = strings.ToLower(strings.TrimSuffix(strings.Trim(song.Path, "/"), "-lyrics"))
+	}
+	if name == "" {
+		return nil, fmt.Errorf("song %d has no path to name its fixtures after", id)
+	}
+
+	responses := map[string]any{"song": song}
+	if song.Album != nil {
+		if responses["album"], err = client.GetAlbum(ctx, song.Album.ID, false); err != nil {
+			return nil, fmt.Errorf("getting album %d: %w", song.Album.ID, err)
+		}
+	}
+	if song.PrimaryArtist != nil {
+		artist, err := client.GetArtist(ctx, song.PrimaryArtist.ID)
+		if err != nil {
+			return nil, fmt.Errorf("getting artist %d: %w", song.PrimaryArtist.ID, err)
+		}
+		responses["artist"] = artist.Response.Artist
+	}
+
+	page, err := downloadPage(ctx, httpClient, song.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	scrub := scrubber(client.AccessToken)
+	var paths []string
+	write := func(path string, data []byte) error {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, scrub(data), 0o644); err != nil {
+			return err
+		}
+		paths = append(paths, path)
+		return nil
+	}
+
+	if err = write(filepath.Join(dir, "extractor", name+".html"), page); err != nil {
+		return paths, err
+	}
+	for _, key := range []string{"song", "album", "artist"} {
+		entity, ok := responses[key]
+		if !ok {
+			continue
+		}
+		response := map[string]any{"meta": map[string]int{"status": http.StatusOK}, "response": map[string]any{key: entity}}
+		data, err := json.MarshalIndent(response, "", "  ")
+		if err != nil {
+			return paths, err
+		}
+		if err = write(filepath.Join(dir, "responses", name, key+".json"), append(data, '\n')); err != nil {
+			return paths, err
+		}
+	}
+	return paths, nil
+}
+
+func downloadPage(ctx context.Context, httpClient *http.Client, uri string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("lyrics page %s: %s", uri, res.Status)
+	}
+	return io.ReadAll(res.Body)
+}
+
+// Secrets scrubbed from captured fixtures.
+var (
+	csrfTokenPattern   = regexp.MustCompile(`(?i)(csrf[-_]token\\?["']?(?:\s+content=|\s*:\s*)\\?["'])[^"'\\]+`)
+	accessTokenPattern = regexp.MustCompile(`(access_token=)[^&"'\s\\]+`)
+)
+
+// scrubber returns a function replacing accessToken and tokens matched by the patterns with "REDACTED".
+func scrubber(accessToken string) func([]byte) []byte {
+	return func(data []byte) []byte {
+		if accessToken != "" {
+			data = bytes.ReplaceAll(data, []byte(accessToken), []byte("REDACTED"))
+		}
+		data = csrfTokenPattern.ReplaceAll(data, []byte("${1}REDACTED"))
+		return accessTokenPattern.ReplaceAll(data, []byte("${1}REDACTED"))
+	}
+}
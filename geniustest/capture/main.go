@@ -0,0 +1,56 @@
+// Command capture saves a song as test fixtures of the genius package, for regression tests of changes on Genius.
+//
+// Usage:
+//
+//	GENIUS_TOKEN=token go run ./geniustest/capture [-dir testdata] [-name name] id
+//
+// It writes the song's lyrics page and the API responses of the song, its album and its artist with tokens scrubbed,
+// see geniustest.Capture. Run go test -run ExtractGolden -update afterwards to write the golden lyrics of the page,
+// and review them.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/geniustest"
+)
+
+func main() {
+	opts := &geniustest.CaptureOptions{}
+	flag.StringVar(&opts.Dir, "dir", "testdata", "testdata directory to write the fixtures to")
+	flag.StringVar(&opts.Name, "name", "", "name of the fixtures, the song's page path by default")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: capture [-dir testdata] [-name name] id")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	id, err := strconv.Atoi(flag.Arg(0))
+	if flag.NArg() != 1 || err != nil {
+		flag.Usage()
+		os.Exit(2)
+	}
+	token := os.Getenv("GENIUS_TOKEN")
+	if token == "" {
+		fmt.Fprintln(os.Stderr, "capture: GENIUS_TOKEN is not set")
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	paths, err := geniustest.Capture(ctx, genius.NewClient(nil, token), id, opts)
+	for _, path := range paths {
+		fmt.Println(path)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "capture:", err)
+		os.Exit(1)
+	}
+}
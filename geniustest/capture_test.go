@@ -0,0 +1,65 @@
+package geniustest_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/geniustest"
+)
+
+func TestCapture(t *testing.T) {
+	server := newSeededServer(t)
+	song, err := server.Client().GetSong(context.Background(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	song.Album = &genius.Album{ID: 100, Name: "DAMN."}
+	song.Description = &genius.Description{Plain: "csrf_token: 'abc123' and access_token=" + geniustest.Token}
+	server.AddSong(song, "[Chorus]\nSit down\nBe humble")
+
+	dir := t.TempDir()
+	paths, err := geniustest.Capture(context.Background(), server.Client(), 10, &geniustest.CaptureOptions{Dir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		filepath.Join(dir, "extractor", "kendrick-lamar-humble.html"),
+		filepath.Join(dir, "responses", "kendrick-lamar-humble", "song.json"),
+		filepath.Join(dir, "responses", "kendrick-lamar-humble", "album.json"),
+		filepath.Join(dir, "responses", "kendrick-lamar-humble", "artist.json"),
+	}
+	if !slices.Equal(paths, want) {
+		t.Fatalf("got paths %v, want %v", paths, want)
+	}
+
+	page, err := os.ReadFile(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	lyrics, err := genius.NewExtractor(strings.NewReader(string(page))).Extract()
+	if err != nil || lyrics != "[Chorus]\nSit down\nBe humble\n" {
+		t.Errorf("unexpected lyrics %q of the captured page, %v", lyrics, err)
+	}
+
+	data, err := os.ReadFile(paths[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "abc123") || strings.Contains(string(data), geniustest.Token) {
+		t.Errorf("tokens weren't scrubbed from %s", data)
+	}
+	var response genius.SongResponse
+	if err = json.Unmarshal(data, &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Meta.Status != 200 || response.Response.Song.ID != 10 {
+		t.Errorf("unexpected song response %s", data)
+	}
+}
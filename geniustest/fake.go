@@ -0,0 +1,679 @@
+package geniustest
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"iter"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/natecham/genius"
+)
+
+// Fake is an in-memory genius.GeniusAPI serving the songs, artists, albums and lyrics added to it, for unit tests
+// that don't need the requests of a Server. Its methods are safe for concurrent use.
+//
+// Lookups of items that weren't added fail with a genius.StatusError with status 404. Referents, annotations,
+// comments and charts are always empty.
+type Fake struct {
+	mu      sync.Mutex
+	songs   []*genius.Song
+	lyrics  map[string]string
+	artists []*genius.Artist
+	albums  []*genius.Album
+	err     error
+}
+
+var _ genius.GeniusAPI = (*Fake)(nil)
+
+// NewFake returns an empty Fake.
+func NewFake() *Fake {
+	return &Fake{lyrics: map[string]string{}}
+}
+
+// AddSong adds song to the fake, replacing a song with the same ID. Songs are listed for their primary artists and
+// found by GetSongByPath with the path of their URL.
+func (f *Fake) AddSong(song *genius.Song) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.songs = replace(f.songs, song, func(other *genius.Song) bool { return other.ID == song.ID })
+}
+
+// AddLyrics sets the lyrics GetLyrics returns for the page at uri, e.g. the URL of a song.
+func (f *Fake) AddLyrics(uri string, lyrics string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.lyrics[uri] = lyrics
+}
+
+// AddArtist adds artist to the fake, replacing an artist with the same ID.
+func (f *Fake) AddArtist(artist *genius.Artist) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.artists = replace(f.artists, artist, func(other *genius.Artist) bool { return other.ID == artist.ID })
+}
+
+// AddAlbum adds album to the fake, replacing an album with the same ID. Its Tracks are returned as its tracks and
+// albums are listed for their Artist.
+func (f *Fake) AddAlbum(album *genius.Album) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.albums = replace(f.albums, album, func(other *genius.Album) bool { return other.ID == album.ID })
+}
+
+// SetError makes every lookup fail with err, until it is reset with nil.
+func (f *Fake) SetError(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.err = err
+}
+
+var errNotFound = &genius.StatusError{StatusCode: http.StatusNotFound}
+
+// lookup returns the item of items for which match is true, f.mu must be held.
+func lookup[T any](f *Fake, items []T, match func(T) bool) (T, error) {
+	var zero T
+	if f.err != nil {
+		return zero, f.err
+	}
+	if i := slices.IndexFunc(items, match); i >= 0 {
+		return items[i], nil
+	}
+	return zero, errNotFound
+}
+
+// all iterates the items returned by list, which is called with f.mu held, up to the MaxItems of opts.
+func all[T any](f *Fake, list func() []T, opts *genius.ListOptions) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		f.mu.Lock()
+		items, err := list(), f.err
+		f.mu.Unlock()
+
+		if err != nil {
+			var zero T
+			yield(zero, err)
+			return
+		}
+		if opts != nil && opts.MaxItems > 0 {
+			items = items[:min(opts.MaxItems, len(items))]
+		}
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
+
+func collect[T any](items iter.Seq2[T, error]) ([]T, error) {
+	var collected []T
+	for item, err := range items {
+		if err != nil {
+			return nil, err
+		}
+		collected = append(collected, item)
+	}
+	return collected, nil
+}
+
+// getBatch looks up the IDs with get like the batch lookups of genius.Client.
+func getBatch[T any](ids []int, get func(id int) (T, error)) ([]T, error) {
+	results := make([]T, len(ids))
+	batchErr := &genius.BatchError{Errors: map[int]error{}}
+	for i, id := range ids {
+		var err error
+		if results[i], err = get(id); err != nil {
+			batchErr.Errors[id] = err
+		}
+	}
+	if len(batchErr.Errors) > 0 {
+		return results, batchErr
+	}
+	return results, nil
+}
+
+// GetAccount returns an account with the Token as login.
+func (f *Fake) GetAccount(context.Context) (*genius.AccountResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.err != nil {
+		return nil, f.err
+	}
+	response := &genius.AccountResponse{}
+	response.Response.User = &genius.User{Login: Token, Name: Token}
+	return response, nil
+}
+
+// GetArtist returns the artist with the ID.
+func (f *Fake) GetArtist(_ context.Context, id int, _ ...genius.RequestOption) (*genius.ArtistResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	artist, err := lookup(f, f.artists, func(artist *genius.Artist) bool { return artist.ID == id })
+	if err != nil {
+		return nil, err
+	}
+	response := &genius.ArtistResponse{}
+	response.Response.Artist = artist
+	return response, nil
+}
+
+// GetArtistBio returns the description of the artist with the ID rendered in the format, see genius.ArtistBio.
+func (f *Fake) GetArtistBio(ctx context.Context, id int, format genius.TextFormat) (string, error) {
+	response, err := f.GetArtist(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return genius.ArtistBio(response.Response.Artist, format), nil
+}
+
+// GetArtists returns the artists with the IDs in order like GetSongs.
+func (f *Fake) GetArtists(ctx context.Context, ids []int) ([]*genius.Artist, error) {
+	return getBatch(ids, func(id int) (*genius.Artist, error) {
+		response, err := f.GetArtist(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return response.Response.Artist, nil
+	})
+}
+
+// GetArtistSongs returns up to total songs of the artist in the given order, all songs if total is -1.
+func (f *Fake) GetArtistSongs(ctx context.Context, id int, sort genius.Sort, total int) ([]*genius.Song, error) {
+	if total == 0 {
+		return nil, nil
+	}
+	opts := &genius.ArtistSongsOptions{Sort: sort}
+	opts.MaxItems = max(total, 0)
+	return collect(f.ArtistSongs(ctx, id, opts))
+}
+
+// GetArtistSongsByPopularity returns up to limit songs of the artist in the order they were added, all of them if
+// limit isn't positive.
+func (f *Fake) GetArtistSongsByPopularity(ctx context.Context, id int, limit int) ([]*genius.Song, error) {
+	opts := &genius.ArtistSongsOptions{Sort: genius.SortPopularity}
+	opts.MaxItems = max(limit, 0)
+	return collect(f.ArtistSongs(ctx, id, opts))
+}
+
+// GetArtistTopSongs returns the n songs of the artist with the most page views, all of them if n isn't positive.
+func (f *Fake) GetArtistTopSongs(ctx context.Context, id int, n int) ([]*genius.Song, error) {
+	songs, err := collect(f.ArtistSongs(ctx, id, &genius.ArtistSongsOptions{Sort: genius.SortPopularity}))
+	if err != nil {
+		return nil, err
+	}
+	genius.SortSongsByPageviews(songs)
+	if n > 0 && len(songs) > n {
+		songs = songs[:n]
+	}
+	return songs, nil
+}
+
+// GetArtistAlbums returns the albums of the artist.
+func (f *Fake) GetArtistAlbums(ctx context.Context, id int, opts *genius.ListOptions) ([]*genius.Album, error) {
+	return collect(f.ArtistAlbums(ctx, id, opts))
+}
+
+// GetSong returns the song with the ID.
+func (f *Fake) GetSong(_ context.Context, id int, _ ...genius.RequestOption) (*genius.Song, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return lookup(f, f.songs, func(song *genius.Song) bool { return song.ID == id })
+}
+
+// GetSongs returns the songs with the IDs in order, nil for the IDs that weren't added, which are reported by a
+// *genius.BatchError.
+func (f *Fake) GetSongs(ctx context.Context, ids []int, _ genius.TextFormat) ([]*genius.Song, error) {
+	return getBatch(ids, func(id int) (*genius.Song, error) { return f.GetSong(ctx, id) })
+}
+
+// GetSongWithLyrics returns a copy of the song with the ID and the lyrics added for its URL.
+func (f *Fake) GetSongWithLyrics(ctx context.Context, id int, opts ...genius.RequestOption) (*genius.Song, error) {
+	song, err := f.GetSong(ctx, id, opts...)
+	if err != nil {
+		return nil, err
+	}
+	lyrics, err := f.GetLyrics(ctx, song.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	withLyrics := *song
+	withLyrics.Lyrics = lyrics
+	return &withLyrics, nil
+}
+
+// GetSongByPath returns the song whose URL has the path, which may be a full URL.
+func (f *Fake) GetSongByPath(_ context.Context, path string) (*genius.Song, error) {
+	path = pagePath(path)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return lookup(f, f.songs, func(song *genius.Song) bool { return song.URL != "" && pagePath(song.URL) == path })
+}
+
+// GetAlbum returns the album with the ID, with its tracks if getTracks is set.
+func (f *Fake) GetAlbum(_ context.Context, id int, getTracks bool, _ ...genius.RequestOption) (*genius.Album, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	album, err := lookup(f, f.albums, func(album *genius.Album) bool { return album.ID == id })
+	if err != nil {
+		return nil, err
+	}
+	if !getTracks {
+		withoutTracks := *album
+		withoutTracks.Tracks = nil
+		return &withoutTracks, nil
+	}
+	return album, nil
+}
+
+// GetAlbumByPath returns the album whose URL has the path, which may be a full URL.
+func (f *Fake) GetAlbumByPath(_ context.Context, path string) (*genius.Album, error) {
+	path = pagePath(path)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return lookup(f, f.albums, func(album *genius.Album) bool { return album.URL != "" && pagePath(album.URL) == path })
+}
+
+// GetAlbumByName returns the album with its tracks whose name and artist normalize like albumTitle and artist, see
+// genius.NormalizeTitle, or genius.ErrNoMatch.
+func (f *Fake) GetAlbumByName(_ context.Context, artist string, albumTitle string, _ ...genius.RequestOption) (*genius.Album, error) {
+	albumTitle = genius.NormalizeTitle(albumTitle)
+	artist = genius.NormalizeArtist(artist)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.err != nil {
+		return nil, f.err
+	}
+	for _, album := range f.albums {
+		if genius.NormalizeTitle(album.Name) == albumTitle && album.Artist != nil && genius.NormalizeArtist(album.Artist.Name) == artist {
+			return album, nil
+		}
+	}
+	return nil, genius.ErrNoMatch
+}
+
+// GetAlbumTracks returns the tracks of the album.
+func (f *Fake) GetAlbumTracks(ctx context.Context, id int, opts *genius.ListOptions) ([]*genius.AlbumTrack, error) {
+	return collect(f.AlbumTracks(ctx, id, opts))
+}
+
+// GetAlbumTracksWithLyrics returns copies of the tracks of the album ordered by disc and track number, each with the
+// song added with its ID and the lyrics added for its URL.
+func (f *Fake) GetAlbumTracksWithLyrics(ctx context.Context, id int, opts ...genius.RequestOption) ([]*genius.AlbumTrack, error) {
+	tracks, err := f.GetAlbumTracks(ctx, id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	withLyrics := make([]*genius.AlbumTrack, len(tracks))
+	for i, track := range tracks {
+		song, err := f.GetSongWithLyrics(ctx, track.Song.ID, opts...)
+		if err != nil {
+			return nil, err
+		}
+		withLyrics[i] = &genius.AlbumTrack{Number: track.Number, Disc: track.Disc, Song: *song}
+	}
+	slices.SortStableFunc(withLyrics, func(a, b *genius.AlbumTrack) int {
+		return cmp.Or(cmp.Compare(a.Disc, b.Disc), cmp.Compare(a.Number, b.Number))
+	})
+	return withLyrics, nil
+}
+
+// GetAnnotation fails, annotations can't be added to the fake.
+func (f *Fake) GetAnnotation(_ context.Context, _ int, _ ...genius.RequestOption) (*genius.Annotation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.err != nil {
+		return nil, f.err
+	}
+	return nil, errNotFound
+}
+
+// GetSongAnnotations returns no fragments.
+func (f *Fake) GetSongAnnotations(_ context.Context, _ int, _ ...genius.RequestOption) ([]*genius.AnnotatedFragment, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return nil, f.err
+}
+
+// GetLyricsWithAnnotations returns the song with the ID and the lyrics added for its URL, without annotations.
+func (f *Fake) GetLyricsWithAnnotations(ctx context.Context, id int, opts ...genius.RequestOption) (*genius.AnnotatedLyrics, error) {
+	song, err := f.GetSongWithLyrics(ctx, id, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &genius.AnnotatedLyrics{Song: song, Lyrics: song.Lyrics, Annotations: []*genius.LyricsAnnotation{}}, nil
+}
+
+// GetLyrics returns the lyrics added for uri.
+func (f *Fake) GetLyrics(ctx context.Context, uri string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.err != nil {
+		return "", f.err
+	}
+	lyrics, ok := f.lyrics[uri]
+	if !ok {
+		return "", errNotFound
+	}
+	return lyrics, nil
+}
+
+// GetChart returns no items.
+func (f *Fake) GetChart(ctx context.Context, opts *genius.ChartOptions) ([]*genius.ChartItem, error) {
+	return collect(f.Chart(ctx, opts))
+}
+
+// Search returns song hits for the songs whose title or artist names contain q, ignoring case.
+func (f *Fake) Search(ctx context.Context, q string) (*genius.SearchResponse, error) {
+	hits, err := collect(f.SearchHits(ctx, q, nil))
+	if err != nil {
+		return nil, err
+	}
+	response := &genius.SearchResponse{}
+	response.Response.Hits = hits
+	return response, nil
+}
+
+// WebSearch returns sections of up to perPage songs, artists and albums whose names contain searchTerm,
+// ignoring case.
+func (f *Fake) WebSearch(_ context.Context, perPage int, searchTerm string) (*genius.WebSearchResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.err != nil {
+		return nil, f.err
+	}
+	q := strings.ToLower(searchTerm)
+	response := &genius.WebSearchResponse{}
+	response.Response.Sections = []genius.Sections{
+		hitSection(genius.HitTypeSong, f.searchSongs(q), perPage),
+		hitSection(genius.HitTypeArtist, filter(f.artists, func(artist *genius.Artist) bool {
+			return strings.Contains(strings.ToLower(artist.Name), q)
+		}), perPage),
+		hitSection(genius.HitTypeAlbum, filter(f.albums, func(album *genius.Album) bool {
+			return strings.Contains(strings.ToLower(album.Name), q)
+		}), perPage),
+	}
+	return response, nil
+}
+
+// MatchTrack returns the first song with the title and artist, compared after normalization like the client's
+// matching, or genius.ErrNoMatch.
+func (f *Fake) MatchTrack(_ context.Context, title string, artist string, _ int) (*genius.Song, error) {
+	title = genius.NormalizeTitle(title)
+	artist = genius.NormalizeArtist(strings.Split(artist, ",")[0])
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.err != nil {
+		return nil, f.err
+	}
+	for _, song := range f.songs {
+		if genius.NormalizeTitle(song.Title) != title {
+			continue
+		}
+		if (song.PrimaryArtist != nil && genius.NormalizeArtist(song.PrimaryArtist.Name) == artist) ||
+			strings.Contains(genius.NormalizeArtist(song.ArtistNames), artist) {
+			return song, nil
+		}
+	}
+	return nil, genius.ErrNoMatch
+}
+
+// CheckHealth reports the error set with SetError as unreachable upstream.
+func (f *Fake) CheckHealth(context.Context) *genius.Health {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return &genius.Health{Upstream: f.err}
+}
+
+// ArtistSongs iterates the songs of the artist, sorted by title or release date, in the order they were added
+// otherwise. Songs are filtered by MinPageviews, From, To and Languages.
+func (f *Fake) ArtistSongs(_ context.Context, id int, opts *genius.ArtistSongsOptions) iter.Seq2[*genius.Song, error] {
+	var o genius.ArtistSongsOptions
+	if opts != nil {
+		o = *opts
+	}
+	sort := o.Sort
+	if sort == "" && (!o.From.IsZero() || !o.To.IsZero()) {
+		sort = genius.SortReleaseDate
+	}
+	return all(f, func() []*genius.Song {
+		return filter(f.artistSongs(id, cmp.Or(sort, genius.SortTitle)), func(song *genius.Song) bool { return matches(song, &o) })
+	}, &o.ListOptions)
+}
+
+// matches reports whether song passes the filters of opts.
+func matches(song *genius.Song, opts *genius.ArtistSongsOptions) bool {
+	if song.Pageviews() < opts.MinPageviews {
+		return false
+	}
+	if len(opts.Languages) > 0 && !slices.ContainsFunc(opts.Languages, func(language string) bool {
+		return song.Language != "" && strings.EqualFold(language, song.Language)
+	}) {
+		return false
+	}
+	if opts.From.IsZero() && opts.To.IsZero() {
+		return true
+	}
+	released, ok := song.Released()
+	return ok && !released.Before(opts.From) && (opts.To.IsZero() || !released.After(opts.To))
+}
+
+// artistSongs returns the songs of the artist in order, f.mu must be held.
+func (f *Fake) artistSongs(id int, sort genius.Sort) []*genius.Song {
+	songs := filter(f.songs, func(song *genius.Song) bool { return isPrimaryArtist(song, id) })
+	switch sort {
+	case genius.SortTitle:
+		slices.SortStableFunc(songs, func(a, b *genius.Song) int { return cmp.Compare(a.Title, b.Title) })
+	case genius.SortReleaseDate:
+		slices.SortStableFunc(songs, func(a, b *genius.Song) int { return cmp.Compare(a.ReleaseDate, b.ReleaseDate) })
+	}
+	return songs
+}
+
+// ArtistAlbums iterates the albums of the artist.
+func (f *Fake) ArtistAlbums(_ context.Context, id int, opts *genius.ListOptions) iter.Seq2[*genius.Album, error] {
+	return all(f, func() []*genius.Album {
+		return filter(f.albums, func(album *genius.Album) bool { return album.Artist != nil && album.Artist.ID == id })
+	}, opts)
+}
+
+// AlbumTracks iterates the tracks of the album, failing if it wasn't added.
+func (f *Fake) AlbumTracks(_ context.Context, id int, opts *genius.ListOptions) iter.Seq2[*genius.AlbumTrack, error] {
+	return func(yield func(*genius.AlbumTrack, error) bool) {
+		f.mu.Lock()
+		album, err := lookup(f, f.albums, func(album *genius.Album) bool { return album.ID == id })
+		f.mu.Unlock()
+
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		for track, err := range all(f, func() []*genius.AlbumTrack { return album.Tracks }, opts) {
+			if !yield(track, err) {
+				return
+			}
+		}
+	}
+}
+
+// Referents iterates no referents.
+func (f *Fake) Referents(context.Context, int, *genius.ReferentsOptions) iter.Seq2[*genius.Referent, error] {
+	return all(f, func() []*genius.Referent { return nil }, nil)
+}
+
+// SearchHits iterates song hits for the songs whose title or artist names contain q, ignoring case.
+func (f *Fake) SearchHits(_ context.Context, q string, opts *genius.ListOptions) iter.Seq2[*genius.Hit, error] {
+	return all(f, func() []*genius.Hit {
+		var hits []*genius.Hit
+		for _, song := range f.searchSongs(strings.ToLower(q)) {
+			hits = append(hits, newHit(genius.HitTypeSong, song))
+		}
+		return hits
+	}, opts)
+}
+
+// Chart iterates no items.
+func (f *Fake) Chart(context.Context, *genius.ChartOptions) iter.Seq2[*genius.ChartItem, error] {
+	return all(f, func() []*genius.ChartItem { return nil }, nil)
+}
+
+// SongComments iterates no comments.
+func (f *Fake) SongComments(context.Context, int, *genius.CommentsOptions) iter.Seq2[*genius.Comment, error] {
+	return all(f, func() []*genius.Comment { return nil }, nil)
+}
+
+// AlbumComments iterates no comments.
+func (f *Fake) AlbumComments(context.Context, int, *genius.CommentsOptions) iter.Seq2[*genius.Comment, error] {
+	return all(f, func() []*genius.Comment { return nil }, nil)
+}
+
+// CommentReplies iterates no replies.
+func (f *Fake) CommentReplies(context.Context, int, *genius.CommentsOptions) iter.Seq2[*genius.Comment, error] {
+	return all(f, func() []*genius.Comment { return nil }, nil)
+}
+
+// StreamArtistSongs delivers the songs of ArtistSongs on a channel, with cursors that ResumeArtistSongs of the fake
+// continues from.
+func (f *Fake) StreamArtistSongs(ctx context.Context, id int, opts *genius.ArtistSongsOptions) <-chan genius.SongResult {
+	state := fakeCursor{ArtistID: id, Sort: genius.SortTitle, PerPage: 1, Page: 1}
+	if opts != nil {
+		state.Sort = cmp.Or(opts.Sort, state.Sort)
+	}
+	return f.streamArtistSongs(ctx, state, opts)
+}
+
+// ResumeArtistSongs continues the song stream of StreamArtistSongs cursor was taken from.
+func (f *Fake) ResumeArtistSongs(ctx context.Context, cursor genius.Cursor, opts *genius.ArtistSongsOptions) <-chan genius.SongResult {
+	var state fakeCursor
+	raw, err := base64.RawURLEncoding.DecodeString(string(cursor))
+	if err == nil {
+		err = json.Unmarshal(raw, &state)
+	}
+	if err != nil {
+		results := make(chan genius.SongResult, 1)
+		results <- genius.SongResult{Err: genius.ErrInvalidCursor, Cursor: cursor}
+		close(results)
+		return results
+	}
+	return f.streamArtistSongs(ctx, state, opts)
+}
+
+// fakeCursor is the content of the cursors of the fake's streams, in the format of the client's cursors with a song
+// per page, so Cursor.Done reports the end of the stream.
+type fakeCursor struct {
+	ArtistID int         `json:"artist_id"`
+	Sort     genius.Sort `json:"sort,omitempty"`
+	PerPage  int         `json:"per_page"`
+	Page     int         `json:"page"`
+	Fetched  int         `json:"fetched"`
+}
+
+func (c fakeCursor) encode() genius.Cursor {
+	raw, _ := json.Marshal(c)
+	return genius.Cursor(base64.RawURLEncoding.EncodeToString(raw))
+}
+
+func (f *Fake) streamArtistSongs(ctx context.Context, state fakeCursor, opts *genius.ArtistSongsOptions) <-chan genius.SongResult {
+	results := make(chan genius.SongResult)
+	go func() {
+		defer close(results)
+
+		f.mu.Lock()
+		songs, err := f.artistSongs(state.ArtistID, state.Sort), f.err
+		f.mu.Unlock()
+
+		if err != nil {
+			select {
+			case results <- genius.SongResult{Err: err, Cursor: state.encode()}:
+			case <-ctx.Done():
+			}
+			return
+		}
+		if opts != nil && opts.MaxItems > 0 {
+			songs = songs[:min(opts.MaxItems, len(songs))]
+		}
+
+		for i := state.Page - 1; i >= 0 && i < len(songs); i++ {
+			state.Fetched++
+			state.Page++
+			if state.Page > len(songs) {
+				state.Page = 0
+			}
+			select {
+			case results <- genius.SongResult{Song: songs[i], Cursor: state.encode()}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return results
+}
+
+// searchSongs returns the songs whose title or artist names contain q, which is lower case, f.mu must be held.
+func (f *Fake) searchSongs(q string) []*genius.Song {
+	return filter(f.songs, func(song *genius.Song) bool {
+		return strings.Contains(strings.ToLower(song.Title), q) || strings.Contains(strings.ToLower(song.ArtistNames), q)
+	})
+}
+
+func filter[T any](items []T, keep func(T) bool) []T {
+	var kept []T
+	for _, item := range items {
+		if keep(item) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+func newHit(hitType string, result any) *genius.Hit {
+	raw, _ := json.Marshal(result)
+	return &genius.Hit{Index: hitType, Type: hitType, Result: raw}
+}
+
+func hitSection[T any](hitType string, items []T, perPage int) genius.Sections {
+	section := genius.Sections{Type: hitType, Hits: []genius.Hit{}}
+	for _, item := range items[:min(max(perPage, 0), len(items))] {
+		section.Hits = append(section.Hits, *newHit(hitType, item))
+	}
+	return section
+}
+
+// pagePath returns the path of a page URL, or path itself with a leading slash.
+func pagePath(path string) string {
+	if u, err := url.Parse(path); err == nil && u.Host != "" {
+		path = u.Path
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return path
+}
@@ -0,0 +1,204 @@
+package geniustest_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/geniustest"
+)
+
+func newSeededFake() *geniustest.Fake {
+	fake := geniustest.NewFake()
+	kendrick := &genius.Artist{ID: 1, Name: "Kendrick Lamar"}
+	humble := &genius.Song{ID: 10, Title: "HUMBLE.", ArtistNames: "Kendrick Lamar", PrimaryArtist: kendrick,
+		URL: "https://genius.com/Kendrick-lamar-humble-lyrics", ReleaseDate: "2017-03-30"}
+	dna := &genius.Song{ID: 11, Title: "DNA.", ArtistNames: "Kendrick Lamar", PrimaryArtist: kendrick,
+		URL: "https://genius.com/Kendrick-lamar-dna-lyrics", ReleaseDate: "2017-04-14"}
+	fake.AddArtist(kendrick)
+	fake.AddSong(humble)
+	fake.AddSong(dna)
+	fake.AddLyrics(humble.URL, "[Chorus]\nSit down\nBe humble")
+	fake.AddAlbum(&genius.Album{ID: 100, Name: "DAMN.", Artist: kendrick, URL: "https://genius.com/albums/Kendrick-lamar/Damn",
+		Tracks: []*genius.AlbumTrack{{Number: 2, Song: *dna}, {Number: 8, Song: *humble}}})
+	return fake
+}
+
+func titles(songs []*genius.Song) []string {
+	var titles []string
+	for _, song := range songs {
+		titles = append(titles, song.Title)
+	}
+	return titles
+}
+
+func TestFake(t *testing.T) {
+	ctx := context.Background()
+	var api genius.GeniusAPI = newSeededFake()
+
+	song, err := api.GetSongWithLyrics(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if song.Lyrics != "[Chorus]\nSit down\nBe humble" {
+		t.Errorf("unexpected lyrics %q", song.Lyrics)
+	}
+
+	if byPath, err := api.GetSongByPath(ctx, "/Kendrick-lamar-dna-lyrics"); err != nil || byPath.ID != 11 {
+		t.Errorf("expected DNA. by its path, got %v, %v", byPath, err)
+	}
+
+	songs, err := api.GetArtistSongs(ctx, 1, genius.SortTitle, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := titles(songs); !slices.Equal(got, []string{"DNA.", "HUMBLE."}) {
+		t.Errorf("got songs %v by title", got)
+	}
+	songs, err = api.GetArtistSongs(ctx, 1, genius.SortReleaseDate, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := titles(songs); !slices.Equal(got, []string{"HUMBLE."}) {
+		t.Errorf("got songs %v, want the first release", got)
+	}
+
+	album, err := api.GetAlbum(ctx, 100, false)
+	if err != nil || album.Tracks != nil {
+		t.Errorf("expected the album without tracks, got %v, %v", album, err)
+	}
+	tracks, err := api.GetAlbumTracks(ctx, 100, nil)
+	if err != nil || len(tracks) != 2 {
+		t.Errorf("expected 2 tracks, got %v, %v", tracks, err)
+	}
+
+	if match, err := api.MatchTrack(ctx, "Humble", "Kendrick Lamar, Someone Else", 0); err != nil || match.ID != 10 {
+		t.Errorf("expected HUMBLE. to match, got %v, %v", match, err)
+	}
+	if _, err := api.MatchTrack(ctx, "Alright", "Kendrick Lamar", 0); !errors.Is(err, genius.ErrNoMatch) {
+		t.Errorf("got %v, want ErrNoMatch", err)
+	}
+
+	response, err := api.Search(ctx, "dna")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hits := response.Response.Hits; len(hits) != 1 {
+		t.Fatalf("got %d hits, want 1", len(hits))
+	}
+	if hit, err := response.Response.Hits[0].AsSong(); err != nil || hit.ID != 11 {
+		t.Errorf("expected a hit for DNA., got %v, %v", hit, err)
+	}
+}
+
+func TestFakeArtistSongsFilters(t *testing.T) {
+	fake := geniustest.NewFake()
+	artist := &genius.Artist{ID: 1, Name: "Stromae"}
+	fake.AddSong(&genius.Song{ID: 10, Title: "Alors on danse", PrimaryArtist: artist, Language: "fr", ReleaseDate: "2009-09-21",
+		Stats: &genius.Stats{Pageviews: 90000}})
+	fake.AddSong(&genius.Song{ID: 11, Title: "Papaoutai", PrimaryArtist: artist, Language: "fr", ReleaseDate: "2013-05-13",
+		Stats: &genius.Stats{Pageviews: 80000}})
+	fake.AddSong(&genius.Song{ID: 12, Title: "Papaoutai (English Translation)", PrimaryArtist: artist, Language: "en",
+		ReleaseDate: "2013-05-13"})
+
+	tests := []struct {
+		name string
+		opts *genius.ArtistSongsOptions
+		want []string
+	}{
+		{"languages", &genius.ArtistSongsOptions{Languages: []string{"FR"}}, []string{"Alors on danse", "Papaoutai"}},
+		{"min pageviews", &genius.ArtistSongsOptions{MinPageviews: 85000}, []string{"Alors on danse"}},
+		{"from", &genius.ArtistSongsOptions{From: time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)},
+			[]string{"Papaoutai", "Papaoutai (English Translation)"}},
+	}
+	for _, test := range tests {
+		var songs []*genius.Song
+		for song, err := range fake.ArtistSongs(context.Background(), 1, test.opts) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			songs = append(songs, song)
+		}
+		if got := titles(songs); !slices.Equal(got, test.want) {
+			t.Errorf("%s: got songs %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestFakeBatch(t *testing.T) {
+	fake := newSeededFake()
+
+	songs, err := fake.GetSongs(context.Background(), []int{11, 12, 10}, genius.FormatPlain)
+	var batchErr *genius.BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 1 || batchErr.Errors[12] == nil {
+		t.Fatalf("got %v, want a BatchError for song 12", err)
+	}
+	if len(songs) != 3 || songs[0].ID != 11 || songs[1] != nil || songs[2].ID != 10 {
+		t.Errorf("unexpected songs %v", songs)
+	}
+
+	artists, err := fake.GetArtists(context.Background(), []int{1})
+	if err != nil || len(artists) != 1 || artists[0].Name != "Kendrick Lamar" {
+		t.Errorf("got %v, %v, want Kendrick Lamar", artists, err)
+	}
+}
+
+func TestFakeErrors(t *testing.T) {
+	ctx := context.Background()
+	fake := newSeededFake()
+
+	var statusErr *genius.StatusError
+	if _, err := fake.GetSong(ctx, 12); !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Errorf("got %v, want a 404 for a missing song", err)
+	}
+	if _, err := fake.GetLyrics(ctx, "https://genius.com/Kendrick-lamar-dna-lyrics"); err == nil {
+		t.Error("expected an error for missing lyrics")
+	}
+
+	failure := errors.New("offline")
+	fake.SetError(failure)
+	if _, err := fake.GetSong(ctx, 10); !errors.Is(err, failure) {
+		t.Errorf("got %v, want the set error", err)
+	}
+	for _, err := range fake.ArtistSongs(ctx, 1, nil) {
+		if !errors.Is(err, failure) {
+			t.Errorf("got %v, want the set error", err)
+		}
+	}
+	if health := fake.CheckHealth(ctx); health.OK() {
+		t.Error("expected the health check to fail")
+	}
+
+	fake.SetError(nil)
+	if _, err := fake.GetSong(ctx, 10); err != nil {
+		t.Errorf("expected the error to be reset, got %v", err)
+	}
+}
+
+func TestFakeStream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fake := newSeededFake()
+
+	first := <-fake.StreamArtistSongs(ctx, 1, nil)
+	if first.Err != nil || first.Song.Title != "DNA." || first.Cursor.Done() {
+		t.Fatalf("unexpected first result %+v", first)
+	}
+
+	var results []genius.SongResult
+	for result := range fake.ResumeArtistSongs(ctx, first.Cursor, nil) {
+		results = append(results, result)
+	}
+	if len(results) != 1 || results[0].Song.Title != "HUMBLE." || !results[0].Cursor.Done() {
+		t.Errorf("expected HUMBLE. to end the resumed stream, got %+v", results)
+	}
+
+	result := <-fake.ResumeArtistSongs(ctx, "invalid", nil)
+	if !errors.Is(result.Err, genius.ErrInvalidCursor) {
+		t.Errorf("got %v, want ErrInvalidCursor", result.Err)
+	}
+}
@@ -0,0 +1,440 @@
+// Package geniustest provides a fake Genius API for testing programs using genius.Client without network access or
+// tokens.
+//
+// A Server is seeded with songs, artists and albums and serves them from the official and unofficial endpoints the
+// client uses, along with lyrics pages of the songs:
+//
+//	server := geniustest.NewServer(t)
+//	server.AddSong(&genius.Song{ID: 1, Title: "HUMBLE.", ArtistNames: "Kendrick Lamar"}, "[Chorus]\nBe humble")
+//	client := server.Client()
+//
+// Failures such as rate limiting are injected with Fail.
+//
+// Code accepting a genius.GeniusAPI can be tested without requests against a Fake, which serves the songs, artists,
+// albums and lyrics added to it from memory.
+package geniustest
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/natecham/genius"
+)
+
+// Token is the access token of the clients returned by Server.Client.
+const Token = "geniustest"
+
+// Failure makes a Server answer requests with an error status instead of serving them, see Server.Fail.
+type Failure struct {
+	// Status is the status of the responses, e.g. http.StatusTooManyRequests or http.StatusInternalServerError.
+	Status int
+	// Count is the number of requests failed, every request if it is 0 or less.
+	Count int
+	// Path limits the failure to requests whose path starts with it, all requests if it is empty.
+	Path string
+	// RetryAfter is sent in whole seconds as the Retry-After header of rate limited and server error responses,
+	// which tells the client how long to wait before retrying. Clients retry right away by default.
+	RetryAfter time.Duration
+}
+
+// Server is a fake Genius API. Its methods are safe to call while it serves requests.
+type Server struct {
+	*httptest.Server
+
+	mux *http.ServeMux
+
+	mu       sync.Mutex
+	songs    []*genius.Song
+	lyrics   map[int]string
+	artists  []*genius.Artist
+	albums   []*genius.Album
+	searches map[string][]*genius.Song
+	failures []*Failure
+	requests int
+}
+
+// NewServer starts a Server, which is closed when the test ends.
+func NewServer(tb testing.TB) *Server {
+	tb.Helper()
+
+	s := &Server{mux: http.NewServeMux(), lyrics: map[int]string{}, searches: map[string][]*genius.Song{}}
+	s.mux.HandleFunc("GET /songs/{id}", s.song)
+	s.mux.HandleFunc("GET /artists/{id}", s.artist)
+	s.mux.HandleFunc("GET /artists/{id}/songs", s.artistSongs)
+	s.mux.HandleFunc("GET /artists/{id}/albums", s.artistAlbums)
+	s.mux.HandleFunc("GET /albums/{id}", s.album)
+	s.mux.HandleFunc("GET /albums/{id}/tracks", s.albumTracks)
+	s.mux.HandleFunc("GET /search", s.search)
+	s.mux.HandleFunc("GET /search/multi", s.searchMulti)
+	s.mux.HandleFunc("GET /page_data/song", s.songPageData)
+	s.mux.HandleFunc("GET /page_data/album", s.albumPageData)
+	s.mux.HandleFunc("GET /", s.lyricsPage)
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	tb.Cleanup(s.Close)
+	return s
+}
+
+// Client returns a client of the server, with opts applied after the options pointing it at the server.
+func (s *Server) Client(opts ...genius.ClientOption) *genius.Client {
+	opts = append([]genius.ClientOption{genius.WithBaseURL(s.URL), genius.WithUnofficialURL(s.URL)}, opts...)
+	return genius.NewClient(nil, Token, opts...)
+}
+
+// AddSong adds song with lyrics to the server. The song's URL is moved to the server, keeping its path, or set to
+// /{id}-lyrics if it is empty, where its lyrics page is served. Songs are listed for their primary artists.
+func (s *Server) AddSong(song *genius.Song, lyrics string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := fmt.Sprintf("/%d-lyrics", song.ID)
+	if u, err := url.Parse(song.URL); err == nil && u.Path != "" && u.Path != "/" {
+		path = u.Path
+	}
+	song.URL = s.URL + path
+	song.Path = path
+
+	s.songs = replace(s.songs, song, func(other *genius.Song) bool { return other.ID == song.ID })
+	s.lyrics[song.ID] = lyrics
+}
+
+// AddArtist adds artist to the server.
+func (s *Server) AddArtist(artist *genius.Artist) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.artists = replace(s.artists, artist, func(other *genius.Artist) bool { return other.ID == artist.ID })
+}
+
+// AddAlbum adds album to the server, its Tracks are served as its tracks. Albums are listed for their Artist.
+func (s *Server) AddAlbum(album *genius.Album) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.albums = replace(s.albums, album, func(other *genius.Album) bool { return other.ID == album.ID })
+}
+
+// SetSearchResults makes searches for q return songs in order. Other searches return the songs whose title or
+// artist names contain the query, ignoring case.
+func (s *Server) SetSearchResults(q string, songs ...*genius.Song) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.searches[strings.ToLower(q)] = songs
+}
+
+// Fail makes the server answer requests with an error, failures are applied in the order they were added until
+// their Count is used up.
+func (s *Server) Fail(failure Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, &failure)
+}
+
+// Requests returns the number of requests the server received, failed ones included.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+func replace[T any](items []T, item T, same func(T) bool) []T {
+	if i := slices.IndexFunc(items, same); i >= 0 {
+		items[i] = item
+		return items
+	}
+	return append(items, item)
+}
+
+// serve answers req with the first failure matching it, or else serves it.
+func (s *Server) serve(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	s.requests++
+	failure := s.failure(req)
+	s.mu.Unlock()
+
+	if failure != nil {
+		if failure.Status == http.StatusTooManyRequests || failure.Status >= 500 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(failure.RetryAfter.Seconds())))
+		}
+		writeError(w, failure.Status)
+		return
+	}
+	s.mux.ServeHTTP(w, req)
+}
+
+// failure returns the failure applying to req and uses it up, s.mu must be held.
+func (s *Server) failure(req *http.Request) *Failure {
+	for i, failure := range s.failures {
+		if !strings.HasPrefix(req.URL.Path, failure.Path) {
+			continue
+		}
+		if failure.Count > 0 {
+			if failure.Count--; failure.Count == 0 {
+				s.failures = slices.Delete(s.failures, i, i+1)
+			}
+		}
+		return failure
+	}
+	return nil
+}
+
+func writeResponse(w http.ResponseWriter, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"meta": map[string]any{"status": http.StatusOK}, "response": response})
+}
+
+func writeError(w http.ResponseWriter, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{"meta": map[string]any{"status": status, "message": http.StatusText(status)}})
+}
+
+// find returns the item of items with the ID in the path, or writes a 404.
+func find[T any](s *Server, w http.ResponseWriter, req *http.Request, items func() []T, id func(T) int) (T, bool) {
+	var zero T
+	want, err := strconv.Atoi(req.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound)
+		return zero, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, item := range items() {
+		if id(item) == want {
+			return item, true
+		}
+	}
+	writeError(w, http.StatusNotFound)
+	return zero, false
+}
+
+// writePage writes the page of items requested by req under key, with next_page.
+func writePage[T any](w http.ResponseWriter, req *http.Request, key string, items []T) {
+	query := req.URL.Query()
+	perPage, err := strconv.Atoi(query.Get("per_page"))
+	if err != nil || perPage <= 0 {
+		perPage = 20
+	}
+	page, err := strconv.Atoi(query.Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+
+	start := min((page-1)*perPage, len(items))
+	end := min(start+perPage, len(items))
+	var next any
+	if end < len(items) {
+		next = page + 1
+	}
+	writeResponse(w, map[string]any{key: append([]T{}, items[start:end]...), "next_page": next})
+}
+
+func songID(song *genius.Song) int       { return song.ID }
+func artistID(artist *genius.Artist) int { return artist.ID }
+func albumID(album *genius.Album) int    { return album.ID }
+
+func (s *Server) song(w http.ResponseWriter, req *http.Request) {
+	if song, ok := find(s, w, req, func() []*genius.Song { return s.songs }, songID); ok {
+		writeResponse(w, map[string]any{"song": song})
+	}
+}
+
+func (s *Server) artist(w http.ResponseWriter, req *http.Request) {
+	if artist, ok := find(s, w, req, func() []*genius.Artist { return s.artists }, artistID); ok {
+		writeResponse(w, map[string]any{"artist": artist})
+	}
+}
+
+func (s *Server) album(w http.ResponseWriter, req *http.Request) {
+	album, ok := find(s, w, req, func() []*genius.Album { return s.albums }, albumID)
+	if !ok {
+		return
+	}
+	// Tracks are served by /albums/{id}/tracks.
+	withoutTracks := *album
+	withoutTracks.Tracks = nil
+	writeResponse(w, map[string]any{"album": &withoutTracks})
+}
+
+func (s *Server) albumTracks(w http.ResponseWriter, req *http.Request) {
+	if album, ok := find(s, w, req, func() []*genius.Album { return s.albums }, albumID); ok {
+		writePage(w, req, "tracks", album.Tracks)
+	}
+}
+
+func (s *Server) artistSongs(w http.ResponseWriter, req *http.Request) {
+	id, _ := strconv.Atoi(req.PathValue("id"))
+
+	s.mu.Lock()
+	var songs []*genius.Song
+	for _, song := range s.songs {
+		if isPrimaryArtist(song, id) {
+			songs = append(songs, song)
+		}
+	}
+	s.mu.Unlock()
+
+	switch genius.Sort(req.URL.Query().Get("sort")) {
+	case genius.SortTitle:
+		slices.SortStableFunc(songs, func(a, b *genius.Song) int { return cmp.Compare(a.Title, b.Title) })
+	case genius.SortReleaseDate:
+		slices.SortStableFunc(songs, func(a, b *genius.Song) int { return cmp.Compare(a.ReleaseDate, b.ReleaseDate) })
+	}
+	writePage(w, req, "songs", songs)
+}
+
+func isPrimaryArtist(song *genius.Song, id int) bool {
+	if song.PrimaryArtist != nil && song.PrimaryArtist.ID == id {
+		return true
+	}
+	return slices.ContainsFunc(song.PrimaryArtists, func(artist *genius.Artist) bool { return artist.ID == id })
+}
+
+func (s *Server) artistAlbums(w http.ResponseWriter, req *http.Request) {
+	id, _ := strconv.Atoi(req.PathValue("id"))
+
+	s.mu.Lock()
+	var albums []*genius.Album
+	for _, album := range s.albums {
+		if album.Artist != nil && album.Artist.ID == id {
+			albums = append(albums, album)
+		}
+	}
+	s.mu.Unlock()
+
+	writePage(w, req, "albums", albums)
+}
+
+// searchSongs returns the songs found for q, s.mu must be held.
+func (s *Server) searchSongs(q string) []*genius.Song {
+	q = strings.ToLower(q)
+	if songs, ok := s.searches[q]; ok {
+		return songs
+	}
+
+	var songs []*genius.Song
+	for _, song := range s.songs {
+		if strings.Contains(strings.ToLower(song.Title), q) || strings.Contains(strings.ToLower(song.ArtistNames), q) {
+			songs = append(songs, song)
+		}
+	}
+	return songs
+}
+
+func (s *Server) search(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	var hits []map[string]any
+	for _, song := range s.searchSongs(req.URL.Query().Get("q")) {
+		hits = append(hits, map[string]any{"index": "song", "type": genius.HitTypeSong, "result": song})
+	}
+	s.mu.Unlock()
+
+	writePage(w, req, "hits", hits)
+}
+
+func (s *Server) searchMulti(w http.ResponseWriter, req *http.Request) {
+	q := req.URL.Query().Get("q")
+	perPage, err := strconv.Atoi(req.URL.Query().Get("per_page"))
+	if err != nil || perPage <= 0 {
+		perPage = 5
+	}
+
+	s.mu.Lock()
+	songs := s.searchSongs(q)
+	var artists []*genius.Artist
+	for _, artist := range s.artists {
+		if strings.Contains(strings.ToLower(artist.Name), strings.ToLower(q)) {
+			artists = append(artists, artist)
+		}
+	}
+	var albums []*genius.Album
+	for _, album := range s.albums {
+		if strings.Contains(strings.ToLower(album.Name), strings.ToLower(q)) {
+			albums = append(albums, album)
+		}
+	}
+	s.mu.Unlock()
+
+	writeResponse(w, map[string]any{"sections": []map[string]any{
+		section(genius.HitTypeSong, songs, perPage),
+		section(genius.HitTypeArtist, artists, perPage),
+		section(genius.HitTypeAlbum, albums, perPage),
+	}})
+}
+
+func section[T any](hitType string, items []T, perPage int) map[string]any {
+	hits := []map[string]any{}
+	for _, item := range items[:min(perPage, len(items))] {
+		hits = append(hits, map[string]any{"index": hitType, "type": hitType, "result": item})
+	}
+	return map[string]any{"type": hitType, "hits": hits}
+}
+
+func (s *Server) songPageData(w http.ResponseWriter, req *http.Request) {
+	path := req.URL.Query().Get("page_path")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(s.songs, func(song *genius.Song) bool { return song.Path == path })
+	if i < 0 {
+		writeError(w, http.StatusNotFound)
+		return
+	}
+	writeResponse(w, map[string]any{"page_data": map[string]any{"song": s.songs[i]}})
+}
+
+func (s *Server) albumPageData(w http.ResponseWriter, req *http.Request) {
+	path := req.URL.Query().Get("page_path")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, album := range s.albums {
+		if u, err := url.Parse(album.URL); err == nil && u.Path == path {
+			writeResponse(w, map[string]any{"page_data": map[string]any{"album": album}})
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound)
+}
+
+// lyricsPage serves the lyrics page of the song at the path, as genius.com lays it out.
+func (s *Server) lyricsPage(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	i := slices.IndexFunc(s.songs, func(song *genius.Song) bool { return song.Path == req.URL.Path })
+	var lyrics string
+	if i >= 0 {
+		lyrics = s.lyrics[s.songs[i].ID]
+	}
+	s.mu.Unlock()
+
+	if i < 0 {
+		http.NotFound(w, req)
+		return
+	}
+
+	lines := strings.Split(lyrics, "\n")
+	for i, line := range lines {
+		lines[i] = html.EscapeString(line)
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, `<html><body><div id="lyrics-root"><div data-lyrics-container="true">%s</div></div></body></html>`,
+		strings.Join(lines, "<br/>"))
+}
@@ -0,0 +1,133 @@
+package geniustest_test
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/geniustest"
+)
+
+func newSeededServer(t *testing.T) *geniustest.Server {
+	t.Helper()
+
+	server := geniustest.NewServer(t)
+	kendrick := &genius.Artist{ID: 1, Name: "Kendrick Lamar"}
+	humble := &genius.Song{ID: 10, Title: "HUMBLE.", ArtistNames: "Kendrick Lamar", PrimaryArtist: kendrick,
+		URL: "https://genius.com/Kendrick-lamar-humble-lyrics"}
+	dna := &genius.Song{ID: 11, Title: "DNA.", ArtistNames: "Kendrick Lamar", PrimaryArtist: kendrick}
+	server.AddArtist(kendrick)
+	server.AddSong(humble, "[Chorus]\nSit down\nBe humble")
+	server.AddSong(dna, "[Verse 1]\nI got loyalty, got royalty inside my DNA")
+	server.AddAlbum(&genius.Album{ID: 100, Name: "DAMN.", Artist: kendrick, URL: "https://genius.com/albums/Kendrick-lamar/Damn",
+		Tracks: []*genius.AlbumTrack{{Number: 2, Song: *dna}, {Number: 8, Song: *humble}}})
+	return server
+}
+
+func TestServer(t *testing.T) {
+	ctx := context.Background()
+	server := newSeededServer(t)
+	client := server.Client()
+
+	song, err := client.GetSongWithLyrics(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if song.Title != "HUMBLE." || song.Lyrics != "[Chorus]\nSit down\nBe humble" {
+		t.Errorf("unexpected song %q with lyrics %q", song.Title, song.Lyrics)
+	}
+
+	byPath, err := client.GetSongByPath(ctx, "https://genius.com/Kendrick-lamar-humble-lyrics")
+	if err != nil || byPath.ID != 10 {
+		t.Errorf("expected the song by its genius.com URL, got %v, %v", byPath, err)
+	}
+
+	songs, err := client.GetArtistSongs(ctx, 1, genius.SortTitle, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if titles := []string{songs[0].Title, songs[1].Title}; len(songs) != 2 || !slices.Equal(titles, []string{"DNA.", "HUMBLE."}) {
+		t.Errorf("unexpected artist songs %v", titles)
+	}
+
+	album, err := client.GetAlbum(ctx, 100, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(album.Tracks) != 2 || album.Tracks[1].Song.ID != 10 {
+		t.Errorf("unexpected album tracks %+v", album.Tracks)
+	}
+
+	match, err := client.MatchTrack(ctx, "DNA.", "Kendrick Lamar", 0)
+	if err != nil || match.ID != 11 {
+		t.Errorf("expected DNA. to match, got %v, %v", match, err)
+	}
+
+	response, err := client.WebSearch(ctx, 5, "kendrick")
+	if err != nil {
+		t.Fatal(err)
+	}
+	artist, err := genius.GetArtistFromSearchResponse(response, "Kendrick Lamar")
+	if err != nil || artist.ID != 1 {
+		t.Errorf("expected the artist in the search sections, got %v, %v", artist, err)
+	}
+}
+
+func TestServerSearchResults(t *testing.T) {
+	server := newSeededServer(t)
+	server.SetSearchResults("loyalty", &genius.Song{ID: 11, Title: "DNA."})
+
+	var ids []int
+	for hit, err := range server.Client().SearchHits(context.Background(), "loyalty", nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		song, err := hit.AsSong()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, song.ID)
+	}
+	if !slices.Equal(ids, []int{11}) {
+		t.Errorf("got songs %v, want the seeded results [11]", ids)
+	}
+}
+
+func TestServerFail(t *testing.T) {
+	ctx := context.Background()
+	server := newSeededServer(t)
+
+	var retries []int
+	client := server.Client(
+		genius.WithOnRateLimited(func(ctx context.Context, info genius.RetryInfo) error {
+			retries = append(retries, info.StatusCode)
+			return nil
+		}),
+		genius.WithOnRetry(func(ctx context.Context, info genius.RetryInfo) error {
+			retries = append(retries, info.StatusCode)
+			return nil
+		}),
+	)
+
+	server.Fail(geniustest.Failure{Status: http.StatusTooManyRequests, Count: 1})
+	server.Fail(geniustest.Failure{Status: http.StatusInternalServerError, Count: 1, Path: "/songs/"})
+	if _, err := client.GetSong(ctx, 10); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(retries, []int{http.StatusTooManyRequests, http.StatusInternalServerError}) {
+		t.Errorf("got retries for %v, want a 429 and a 500", retries)
+	}
+	if server.Requests() != 3 {
+		t.Errorf("got %d requests, want 3", server.Requests())
+	}
+
+	server.Fail(geniustest.Failure{Status: http.StatusNotFound, Path: "/artists/"})
+	if _, err := client.GetArtist(ctx, 1); err == nil {
+		t.Error("expected the artist request to fail")
+	}
+	if _, err := client.GetSong(ctx, 11); err != nil {
+		t.Errorf("expected other requests to be served, got %v", err)
+	}
+}
@@ -0,0 +1,882 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: genius.proto
+
+// Package genius.v1 serves songs, albums and lyrics fetched from Genius.
+
+package grpcapi
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SearchSongsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// max_results limits the songs returned, 10 if it isn't set.
+	MaxResults int32 `protobuf:"varint,2,opt,name=max_results,json=maxResults,proto3" json:"max_results,omitempty"`
+}
+
+func (x *SearchSongsRequest) Reset() {
+	*x = SearchSongsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_genius_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchSongsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSongsRequest) ProtoMessage() {}
+
+func (x *SearchSongsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_genius_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSongsRequest.ProtoReflect.Descriptor instead.
+func (*SearchSongsRequest) Descriptor() ([]byte, []int) {
+	return file_genius_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SearchSongsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchSongsRequest) GetMaxResults() int32 {
+	if x != nil {
+		return x.MaxResults
+	}
+	return 0
+}
+
+type SearchSongsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Songs []*Song `protobuf:"bytes,1,rep,name=songs,proto3" json:"songs,omitempty"`
+}
+
+func (x *SearchSongsResponse) Reset() {
+	*x = SearchSongsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_genius_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SearchSongsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSongsResponse) ProtoMessage() {}
+
+func (x *SearchSongsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_genius_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSongsResponse.ProtoReflect.Descriptor instead.
+func (*SearchSongsResponse) Descriptor() ([]byte, []int) {
+	return file_genius_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SearchSongsResponse) GetSongs() []*Song {
+	if x != nil {
+		return x.Songs
+	}
+	return nil
+}
+
+type GetSongRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetSongRequest) Reset() {
+	*x = GetSongRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_genius_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSongRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSongRequest) ProtoMessage() {}
+
+func (x *GetSongRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_genius_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSongRequest.ProtoReflect.Descriptor instead.
+func (*GetSongRequest) Descriptor() ([]byte, []int) {
+	return file_genius_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetSongRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// GetLyricsRequest names a song by one of its id, its url or its artist and title, which are matched to the song
+// best matching them.
+type GetLyricsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url    string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Artist string `protobuf:"bytes,3,opt,name=artist,proto3" json:"artist,omitempty"`
+	Title  string `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+}
+
+func (x *GetLyricsRequest) Reset() {
+	*x = GetLyricsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_genius_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetLyricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLyricsRequest) ProtoMessage() {}
+
+func (x *GetLyricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_genius_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLyricsRequest.ProtoReflect.Descriptor instead.
+func (*GetLyricsRequest) Descriptor() ([]byte, []int) {
+	return file_genius_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetLyricsRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetLyricsRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *GetLyricsRequest) GetArtist() string {
+	if x != nil {
+		return x.Artist
+	}
+	return ""
+}
+
+func (x *GetLyricsRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type GetAlbumRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// lyrics sets the lyrics of the tracks, fetching them as the tracks are streamed.
+	Lyrics bool `protobuf:"varint,2,opt,name=lyrics,proto3" json:"lyrics,omitempty"`
+}
+
+func (x *GetAlbumRequest) Reset() {
+	*x = GetAlbumRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_genius_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAlbumRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAlbumRequest) ProtoMessage() {}
+
+func (x *GetAlbumRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_genius_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAlbumRequest.ProtoReflect.Descriptor instead.
+func (*GetAlbumRequest) Descriptor() ([]byte, []int) {
+	return file_genius_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetAlbumRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetAlbumRequest) GetLyrics() bool {
+	if x != nil {
+		return x.Lyrics
+	}
+	return false
+}
+
+type Song struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Artist      string `protobuf:"bytes,3,opt,name=artist,proto3" json:"artist,omitempty"`
+	Url         string `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	Album       string `protobuf:"bytes,5,opt,name=album,proto3" json:"album,omitempty"`
+	ReleaseDate string `protobuf:"bytes,6,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
+	ImageUrl    string `protobuf:"bytes,7,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+}
+
+func (x *Song) Reset() {
+	*x = Song{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_genius_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Song) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Song) ProtoMessage() {}
+
+func (x *Song) ProtoReflect() protoreflect.Message {
+	mi := &file_genius_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Song.ProtoReflect.Descriptor instead.
+func (*Song) Descriptor() ([]byte, []int) {
+	return file_genius_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Song) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Song) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Song) GetArtist() string {
+	if x != nil {
+		return x.Artist
+	}
+	return ""
+}
+
+func (x *Song) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Song) GetAlbum() string {
+	if x != nil {
+		return x.Album
+	}
+	return ""
+}
+
+func (x *Song) GetReleaseDate() string {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return ""
+}
+
+func (x *Song) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+type Lyrics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Song   *Song  `protobuf:"bytes,1,opt,name=song,proto3" json:"song,omitempty"`
+	Lyrics string `protobuf:"bytes,2,opt,name=lyrics,proto3" json:"lyrics,omitempty"`
+}
+
+func (x *Lyrics) Reset() {
+	*x = Lyrics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_genius_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Lyrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lyrics) ProtoMessage() {}
+
+func (x *Lyrics) ProtoReflect() protoreflect.Message {
+	mi := &file_genius_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lyrics.ProtoReflect.Descriptor instead.
+func (*Lyrics) Descriptor() ([]byte, []int) {
+	return file_genius_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Lyrics) GetSong() *Song {
+	if x != nil {
+		return x.Song
+	}
+	return nil
+}
+
+func (x *Lyrics) GetLyrics() string {
+	if x != nil {
+		return x.Lyrics
+	}
+	return ""
+}
+
+type Album struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Artist      string `protobuf:"bytes,3,opt,name=artist,proto3" json:"artist,omitempty"`
+	Url         string `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	ReleaseDate string `protobuf:"bytes,5,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
+	CoverArtUrl string `protobuf:"bytes,6,opt,name=cover_art_url,json=coverArtUrl,proto3" json:"cover_art_url,omitempty"`
+}
+
+func (x *Album) Reset() {
+	*x = Album{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_genius_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Album) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Album) ProtoMessage() {}
+
+func (x *Album) ProtoReflect() protoreflect.Message {
+	mi := &file_genius_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Album.ProtoReflect.Descriptor instead.
+func (*Album) Descriptor() ([]byte, []int) {
+	return file_genius_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Album) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Album) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Album) GetArtist() string {
+	if x != nil {
+		return x.Artist
+	}
+	return ""
+}
+
+func (x *Album) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Album) GetReleaseDate() string {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return ""
+}
+
+func (x *Album) GetCoverArtUrl() string {
+	if x != nil {
+		return x.CoverArtUrl
+	}
+	return ""
+}
+
+type AlbumTrack struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// album is the album of the track, the same in every track of a stream.
+	Album  *Album `protobuf:"bytes,1,opt,name=album,proto3" json:"album,omitempty"`
+	Number int32  `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	Disc   int32  `protobuf:"varint,3,opt,name=disc,proto3" json:"disc,omitempty"`
+	Song   *Song  `protobuf:"bytes,4,opt,name=song,proto3" json:"song,omitempty"`
+	// lyrics are the lyrics of the song if they were requested.
+	Lyrics string `protobuf:"bytes,5,opt,name=lyrics,proto3" json:"lyrics,omitempty"`
+}
+
+func (x *AlbumTrack) Reset() {
+	*x = AlbumTrack{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_genius_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AlbumTrack) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlbumTrack) ProtoMessage() {}
+
+func (x *AlbumTrack) ProtoReflect() protoreflect.Message {
+	mi := &file_genius_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlbumTrack.ProtoReflect.Descriptor instead.
+func (*AlbumTrack) Descriptor() ([]byte, []int) {
+	return file_genius_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AlbumTrack) GetAlbum() *Album {
+	if x != nil {
+		return x.Album
+	}
+	return nil
+}
+
+func (x *AlbumTrack) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *AlbumTrack) GetDisc() int32 {
+	if x != nil {
+		return x.Disc
+	}
+	return 0
+}
+
+func (x *AlbumTrack) GetSong() *Song {
+	if x != nil {
+		return x.Song
+	}
+	return nil
+}
+
+func (x *AlbumTrack) GetLyrics() string {
+	if x != nil {
+		return x.Lyrics
+	}
+	return ""
+}
+
+var File_genius_proto protoreflect.FileDescriptor
+
+var file_genius_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x67, 0x65, 0x6e, 0x69, 0x75, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09,
+	0x67, 0x65, 0x6e, 0x69, 0x75, 0x73, 0x2e, 0x76, 0x31, 0x22, 0x4b, 0x0a, 0x12, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x3c, 0x0a, 0x13, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a,
+	0x05, 0x73, 0x6f, 0x6e, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67,
+	0x65, 0x6e, 0x69, 0x75, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6f, 0x6e, 0x67, 0x52, 0x05, 0x73,
+	0x6f, 0x6e, 0x67, 0x73, 0x22, 0x20, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x62, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4c, 0x79, 0x72,
+	0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x16, 0x0a, 0x06,
+	0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x72,
+	0x74, 0x69, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x22, 0x39, 0x0a, 0x0f, 0x47, 0x65,
+	0x74, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x6c, 0x79, 0x72, 0x69, 0x63, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c,
+	0x79, 0x72, 0x69, 0x63, 0x73, 0x22, 0xac, 0x01, 0x0a, 0x04, 0x53, 0x6f, 0x6e, 0x67, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
+	0x69, 0x74, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x72, 0x74, 0x69, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x14,
+	0x0a, 0x05, 0x61, 0x6c, 0x62, 0x75, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61,
+	0x6c, 0x62, 0x75, 0x6d, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f,
+	0x64, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x44, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x5f, 0x75, 0x72, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6d, 0x61, 0x67,
+	0x65, 0x55, 0x72, 0x6c, 0x22, 0x45, 0x0a, 0x06, 0x4c, 0x79, 0x72, 0x69, 0x63, 0x73, 0x12, 0x23,
+	0x0a, 0x04, 0x73, 0x6f, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67,
+	0x65, 0x6e, 0x69, 0x75, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6f, 0x6e, 0x67, 0x52, 0x04, 0x73,
+	0x6f, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x79, 0x72, 0x69, 0x63, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x79, 0x72, 0x69, 0x63, 0x73, 0x22, 0x9c, 0x01, 0x0a, 0x05,
+	0x41, 0x6c, 0x62, 0x75, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x72, 0x74,
+	0x69, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x72, 0x74, 0x69, 0x73,
+	0x74, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x75, 0x72, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x64,
+	0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x44, 0x61, 0x74, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x5f,
+	0x61, 0x72, 0x74, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63,
+	0x6f, 0x76, 0x65, 0x72, 0x41, 0x72, 0x74, 0x55, 0x72, 0x6c, 0x22, 0x9d, 0x01, 0x0a, 0x0a, 0x41,
+	0x6c, 0x62, 0x75, 0x6d, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x12, 0x26, 0x0a, 0x05, 0x61, 0x6c, 0x62,
+	0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x67, 0x65, 0x6e, 0x69, 0x75,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x52, 0x05, 0x61, 0x6c, 0x62, 0x75,
+	0x6d, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x69, 0x73,
+	0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x64, 0x69, 0x73, 0x63, 0x12, 0x23, 0x0a,
+	0x04, 0x73, 0x6f, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x67, 0x65,
+	0x6e, 0x69, 0x75, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6f, 0x6e, 0x67, 0x52, 0x04, 0x73, 0x6f,
+	0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x79, 0x72, 0x69, 0x63, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6c, 0x79, 0x72, 0x69, 0x63, 0x73, 0x32, 0x8b, 0x02, 0x0a, 0x06, 0x47,
+	0x65, 0x6e, 0x69, 0x75, 0x73, 0x12, 0x4c, 0x0a, 0x0b, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x53,
+	0x6f, 0x6e, 0x67, 0x73, 0x12, 0x1d, 0x2e, 0x67, 0x65, 0x6e, 0x69, 0x75, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x67, 0x65, 0x6e, 0x69, 0x75, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x53, 0x6f, 0x6e, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x53, 0x6f, 0x6e, 0x67, 0x12, 0x19,
+	0x2e, 0x67, 0x65, 0x6e, 0x69, 0x75, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x6f,
+	0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x67, 0x65, 0x6e, 0x69,
+	0x75, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x6f, 0x6e, 0x67, 0x12, 0x3b, 0x0a, 0x09, 0x47, 0x65,
+	0x74, 0x4c, 0x79, 0x72, 0x69, 0x63, 0x73, 0x12, 0x1b, 0x2e, 0x67, 0x65, 0x6e, 0x69, 0x75, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x79, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x67, 0x65, 0x6e, 0x69, 0x75, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x79, 0x72, 0x69, 0x63, 0x73, 0x12, 0x3f, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x41, 0x6c,
+	0x62, 0x75, 0x6d, 0x12, 0x1a, 0x2e, 0x67, 0x65, 0x6e, 0x69, 0x75, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x41, 0x6c, 0x62, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x15, 0x2e, 0x67, 0x65, 0x6e, 0x69, 0x75, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6c, 0x62, 0x75,
+	0x6d, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x30, 0x01, 0x42, 0x24, 0x5a, 0x22, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6e, 0x61, 0x74, 0x65, 0x63, 0x68, 0x61, 0x6d, 0x2f,
+	0x67, 0x65, 0x6e, 0x69, 0x75, 0x73, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x61, 0x70, 0x69, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_genius_proto_rawDescOnce sync.Once
+	file_genius_proto_rawDescData = file_genius_proto_rawDesc
+)
+
+func file_genius_proto_rawDescGZIP() []byte {
+	file_genius_proto_rawDescOnce.Do(func() {
+		file_genius_proto_rawDescData = protoimpl.X.CompressGZIP(file_genius_proto_rawDescData)
+	})
+	return file_genius_proto_rawDescData
+}
+
+var file_genius_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_genius_proto_goTypes = []any{
+	(*SearchSongsRequest)(nil),  // 0: genius.v1.SearchSongsRequest
+	(*SearchSongsResponse)(nil), // 1: genius.v1.SearchSongsResponse
+	(*GetSongRequest)(nil),      // 2: genius.v1.GetSongRequest
+	(*GetLyricsRequest)(nil),    // 3: genius.v1.GetLyricsRequest
+	(*GetAlbumRequest)(nil),     // 4: genius.v1.GetAlbumRequest
+	(*Song)(nil),                // 5: genius.v1.Song
+	(*Lyrics)(nil),              // 6: genius.v1.Lyrics
+	(*Album)(nil),               // 7: genius.v1.Album
+	(*AlbumTrack)(nil),          // 8: genius.v1.AlbumTrack
+}
+var file_genius_proto_depIdxs = []int32{
+	5, // 0: genius.v1.SearchSongsResponse.songs:type_name -> genius.v1.Song
+	5, // 1: genius.v1.Lyrics.song:type_name -> genius.v1.Song
+	7, // 2: genius.v1.AlbumTrack.album:type_name -> genius.v1.Album
+	5, // 3: genius.v1.AlbumTrack.song:type_name -> genius.v1.Song
+	0, // 4: genius.v1.Genius.SearchSongs:input_type -> genius.v1.SearchSongsRequest
+	2, // 5: genius.v1.Genius.GetSong:input_type -> genius.v1.GetSongRequest
+	3, // 6: genius.v1.Genius.GetLyrics:input_type -> genius.v1.GetLyricsRequest
+	4, // 7: genius.v1.Genius.GetAlbum:input_type -> genius.v1.GetAlbumRequest
+	1, // 8: genius.v1.Genius.SearchSongs:output_type -> genius.v1.SearchSongsResponse
+	5, // 9: genius.v1.Genius.GetSong:output_type -> genius.v1.Song
+	6, // 10: genius.v1.Genius.GetLyrics:output_type -> genius.v1.Lyrics
+	8, // 11: genius.v1.Genius.GetAlbum:output_type -> genius.v1.AlbumTrack
+	8, // [8:12] is the sub-list for method output_type
+	4, // [4:8] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_genius_proto_init() }
+func file_genius_proto_init() {
+	if File_genius_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_genius_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*SearchSongsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_genius_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*SearchSongsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_genius_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*GetSongRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_genius_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*GetLyricsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_genius_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*GetAlbumRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_genius_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*Song); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_genius_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*Lyrics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_genius_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*Album); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_genius_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*AlbumTrack); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_genius_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_genius_proto_goTypes,
+		DependencyIndexes: file_genius_proto_depIdxs,
+		MessageInfos:      file_genius_proto_msgTypes,
+	}.Build()
+	File_genius_proto = out.File
+	file_genius_proto_rawDesc = nil
+	file_genius_proto_goTypes = nil
+	file_genius_proto_depIdxs = nil
+}
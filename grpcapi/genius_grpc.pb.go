@@ -0,0 +1,253 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: genius.proto
+
+// Package genius.v1 serves songs, albums and lyrics fetched from Genius.
+
+package grpcapi
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Genius_SearchSongs_FullMethodName = "/genius.v1.Genius/SearchSongs"
+	Genius_GetSong_FullMethodName     = "/genius.v1.Genius/GetSong"
+	Genius_GetLyrics_FullMethodName   = "/genius.v1.Genius/GetLyrics"
+	Genius_GetAlbum_FullMethodName    = "/genius.v1.Genius/GetAlbum"
+)
+
+// GeniusClient is the client API for Genius service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Genius looks up songs, albums and lyrics on Genius.
+type GeniusClient interface {
+	// SearchSongs returns the songs found for a query.
+	SearchSongs(ctx context.Context, in *SearchSongsRequest, opts ...grpc.CallOption) (*SearchSongsResponse, error)
+	// GetSong returns a song by its ID.
+	GetSong(ctx context.Context, in *GetSongRequest, opts ...grpc.CallOption) (*Song, error)
+	// GetLyrics returns the lyrics of a song by its ID, genius.com URL or artist and title.
+	GetLyrics(ctx context.Context, in *GetLyricsRequest, opts ...grpc.CallOption) (*Lyrics, error)
+	// GetAlbum streams the tracks of an album in order.
+	GetAlbum(ctx context.Context, in *GetAlbumRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AlbumTrack], error)
+}
+
+type geniusClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGeniusClient(cc grpc.ClientConnInterface) GeniusClient {
+	return &geniusClient{cc}
+}
+
+func (c *geniusClient) SearchSongs(ctx context.Context, in *SearchSongsRequest, opts ...grpc.CallOption) (*SearchSongsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchSongsResponse)
+	err := c.cc.Invoke(ctx, Genius_SearchSongs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geniusClient) GetSong(ctx context.Context, in *GetSongRequest, opts ...grpc.CallOption) (*Song, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Song)
+	err := c.cc.Invoke(ctx, Genius_GetSong_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geniusClient) GetLyrics(ctx context.Context, in *GetLyricsRequest, opts ...grpc.CallOption) (*Lyrics, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Lyrics)
+	err := c.cc.Invoke(ctx, Genius_GetLyrics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geniusClient) GetAlbum(ctx context.Context, in *GetAlbumRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AlbumTrack], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Genius_ServiceDesc.Streams[0], Genius_GetAlbum_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetAlbumRequest, AlbumTrack]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Genius_GetAlbumClient = grpc.ServerStreamingClient[AlbumTrack]
+
+// GeniusServer is the server API for Genius service.
+// All implementations must embed UnimplementedGeniusServer
+// for forward compatibility.
+//
+// Genius looks up songs, albums and lyrics on Genius.
+type GeniusServer interface {
+	// SearchSongs returns the songs found for a query.
+	SearchSongs(context.Context, *SearchSongsRequest) (*SearchSongsResponse, error)
+	// GetSong returns a song by its ID.
+	GetSong(context.Context, *GetSongRequest) (*Song, error)
+	// GetLyrics returns the lyrics of a song by its ID, genius.com URL or artist and title.
+	GetLyrics(context.Context, *GetLyricsRequest) (*Lyrics, error)
+	// GetAlbum streams the tracks of an album in order.
+	GetAlbum(*GetAlbumRequest, grpc.ServerStreamingServer[AlbumTrack]) error
+	mustEmbedUnimplementedGeniusServer()
+}
+
+// UnimplementedGeniusServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedGeniusServer struct{}
+
+func (UnimplementedGeniusServer) SearchSongs(context.Context, *SearchSongsRequest) (*SearchSongsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchSongs not implemented")
+}
+func (UnimplementedGeniusServer) GetSong(context.Context, *GetSongRequest) (*Song, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSong not implemented")
+}
+func (UnimplementedGeniusServer) GetLyrics(context.Context, *GetLyricsRequest) (*Lyrics, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLyrics not implemented")
+}
+func (UnimplementedGeniusServer) GetAlbum(*GetAlbumRequest, grpc.ServerStreamingServer[AlbumTrack]) error {
+	return status.Errorf(codes.Unimplemented, "method GetAlbum not implemented")
+}
+func (UnimplementedGeniusServer) mustEmbedUnimplementedGeniusServer() {}
+func (UnimplementedGeniusServer) testEmbeddedByValue()                {}
+
+// UnsafeGeniusServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GeniusServer will
+// result in compilation errors.
+type UnsafeGeniusServer interface {
+	mustEmbedUnimplementedGeniusServer()
+}
+
+func RegisterGeniusServer(s grpc.ServiceRegistrar, srv GeniusServer) {
+	// If the following call pancis, it indicates UnimplementedGeniusServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Genius_ServiceDesc, srv)
+}
+
+func _Genius_SearchSongs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchSongsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeniusServer).SearchSongs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Genius_SearchSongs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeniusServer).SearchSongs(ctx, req.(*SearchSongsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Genius_GetSong_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSongRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeniusServer).GetSong(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Genius_GetSong_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeniusServer).GetSong(ctx, req.(*GetSongRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Genius_GetLyrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLyricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeniusServer).GetLyrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Genius_GetLyrics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeniusServer).GetLyrics(ctx, req.(*GetLyricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Genius_GetAlbum_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetAlbumRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GeniusServer).GetAlbum(m, &grpc.GenericServerStream[GetAlbumRequest, AlbumTrack]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Genius_GetAlbumServer = grpc.ServerStreamingServer[AlbumTrack]
+
+// Genius_ServiceDesc is the grpc.ServiceDesc for Genius service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Genius_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "genius.v1.Genius",
+	HandlerType: (*GeniusServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SearchSongs",
+			Handler:    _Genius_SearchSongs_Handler,
+		},
+		{
+			MethodName: "GetSong",
+			Handler:    _Genius_GetSong_Handler,
+		},
+		{
+			MethodName: "GetLyrics",
+			Handler:    _Genius_GetLyrics_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetAlbum",
+			Handler:       _Genius_GetAlbum_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "genius.proto",
+}
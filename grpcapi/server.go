@@ -0,0 +1,206 @@
+// Package grpcapi serves songs, albums and lyrics fetched from Genius over gRPC, the Genius service of genius.proto,
+// for programs not written in Go:
+//
+//	s := grpc.NewServer()
+//	grpcapi.RegisterGeniusServer(s, grpcapi.NewServer(client))
+//
+// The Go code is generated from genius.proto with go generate, which needs protoc, protoc-gen-go and
+// protoc-gen-go-grpc.
+package grpcapi
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative genius.proto
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/natecham/genius"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultMaxResults is the number of songs SearchSongs returns if the request doesn't limit them.
+const defaultMaxResults = 10
+
+// Server implements GeniusServer with a client.
+//
+// Errors have the InvalidArgument code for invalid requests, NotFound if no song matches and Unavailable if Genius
+// failed.
+type Server struct {
+	UnimplementedGeniusServer
+
+	client genius.GeniusAPI
+}
+
+// NewServer returns a server fetching from Genius with client.
+func NewServer(client genius.GeniusAPI) *Server {
+	return &Server{client: client}
+}
+
+// SearchSongs returns the songs found for the query.
+func (s *Server) SearchSongs(ctx context.Context, req *SearchSongsRequest) (*SearchSongsResponse, error) {
+	if req.GetQuery() == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+	maxResults := int(req.GetMaxResults())
+	if maxResults <= 0 {
+		maxResults = defaultMaxResults
+	}
+
+	response := &SearchSongsResponse{}
+	for hit, err := range s.client.SearchHits(ctx, req.GetQuery(), &genius.ListOptions{MaxItems: maxResults}) {
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		if song, err := hit.AsSong(); err == nil {
+			response.Songs = append(response.Songs, newSong(song))
+		}
+	}
+	return response, nil
+}
+
+// GetSong returns the song with the ID.
+func (s *Server) GetSong(ctx context.Context, req *GetSongRequest) (*Song, error) {
+	if req.GetId() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	song, err := s.client.GetSong(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return newSong(song), nil
+}
+
+// GetLyrics returns the lyrics of the song with the ID, at the URL or best matching the artist and title, see
+// Client.MatchTrack.
+func (s *Server) GetLyrics(ctx context.Context, req *GetLyricsRequest) (*Lyrics, error) {
+	var song *genius.Song
+	var err error
+	switch {
+	case req.GetId() > 0:
+		song, err = s.client.GetSong(ctx, int(req.GetId()))
+	case req.GetUrl() != "":
+		song, err = s.client.GetSongByPath(ctx, req.GetUrl())
+	case req.GetArtist() != "" && req.GetTitle() != "":
+		song, err = s.client.MatchTrack(ctx, req.GetTitle(), req.GetArtist(), 0)
+	default:
+		return nil, status.Error(codes.InvalidArgument, "id, url or artist and title are required")
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	lyrics, err := s.client.GetLyrics(ctx, song.URL)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &Lyrics{Song: newSong(song), Lyrics: lyrics}, nil
+}
+
+// GetAlbum streams the tracks of the album with the ID, their lyrics are fetched as they are sent if requested.
+func (s *Server) GetAlbum(req *GetAlbumRequest, stream grpc.ServerStreamingServer[AlbumTrack]) error {
+	if req.GetId() <= 0 {
+		return status.Error(codes.InvalidArgument, "id is required")
+	}
+	ctx := stream.Context()
+
+	album, err := s.client.GetAlbum(ctx, int(req.GetId()), false)
+	if err != nil {
+		return toStatus(err)
+	}
+	a := newAlbum(album)
+
+	for track, err := range s.client.AlbumTracks(ctx, album.ID, nil) {
+		if err != nil {
+			return toStatus(err)
+		}
+
+		t := &AlbumTrack{Album: a, Number: int32(track.Number), Disc: int32(track.Disc), Song: newSong(&track.Song)}
+		if req.GetLyrics() {
+			if t.Lyrics, err = s.client.GetLyrics(ctx, track.Song.URL); err != nil {
+				return toStatus(err)
+			}
+		}
+		if err = stream.Send(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toStatus returns err as a status error. Errors of Genius are mapped to the code of their status, so that clients
+// don't retry requests for songs that don't exist. Unavailable, which clients retry, is reserved for failures to
+// reach Genius and its server errors.
+func toStatus(err error) error {
+	var upstreamErr *genius.StatusError
+	var netErr net.Error
+	switch {
+	case errors.Is(err, genius.ErrNoMatch), errors.Is(err, genius.ErrNoLyrics), errors.Is(err, genius.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, genius.ErrBlocked):
+		// genius.com blocks clients making too many requests, retrying right away doesn't help.
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.As(err, &upstreamErr):
+		return status.Error(statusCode(upstreamErr.StatusCode), err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.As(err, &netErr):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// statusCode returns the code of an error Genius answered with statusCode.
+func statusCode(statusCode int) codes.Code {
+	switch statusCode {
+	case http.StatusNotFound, http.StatusGone:
+		return codes.NotFound
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case http.StatusUnauthorized, http.StatusForbidden:
+		// The server's token was rejected, not the client's request.
+		return codes.Internal
+	default:
+		if statusCode >= http.StatusInternalServerError {
+			return codes.Unavailable
+		}
+		return codes.Internal
+	}
+}
+
+func newSong(song *genius.Song) *Song {
+	s := &Song{
+		Id:          int64(song.ID),
+		Title:       song.Title,
+		Artist:      song.ArtistNames,
+		Url:         song.URL,
+		ReleaseDate: song.ReleaseDate,
+		ImageUrl:    song.SongArtImageURL,
+	}
+	if song.Album != nil {
+		s.Album = song.Album.Name
+	}
+	return s
+}
+
+func newAlbum(album *genius.Album) *Album {
+	a := &Album{
+		Id:          int64(album.ID),
+		Name:        album.Name,
+		Url:         album.URL,
+		ReleaseDate: album.ReleaseDate,
+		CoverArtUrl: album.CoverArtURL,
+	}
+	if album.Artist != nil {
+		a.Artist = album.Artist.Name
+	}
+	return a
+}
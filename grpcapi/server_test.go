@@ -0,0 +1,187 @@
+package grpcapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/geniustest"
+	"github.com/natecham/genius/grpcapi"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeGenius serves a search for, the song and lyrics page of HUMBLE. and its album DAMN.
+func fakeGenius(w http.ResponseWriter, r *http.Request) {
+	song := map[string]any{
+		"id":           1,
+		"title":        "HUMBLE.",
+		"artist_names": "Kendrick Lamar",
+		"url":          "http://" + r.Host + "/humble-lyrics",
+		"album":        map[string]any{"id": 2, "name": "DAMN."},
+	}
+	switch r.URL.Path {
+	case "/search":
+		writeResponse(w, map[string]any{"hits": []map[string]any{{"index": "song", "type": "song", "result": song}}})
+	case "/songs/1":
+		writeResponse(w, map[string]any{"song": song})
+	case "/albums/2":
+		writeResponse(w, map[string]any{"album": map[string]any{"id": 2, "name": "DAMN.", "artist": map[string]any{"name": "Kendrick Lamar"}}})
+	case "/albums/2/tracks":
+		writeResponse(w, map[string]any{"tracks": []map[string]any{{"number": 8, "song": song}}, "next_page": nil})
+	case "/humble-lyrics":
+		fmt.Fprint(w, `<div id="lyrics-root"><div data-lyrics-container="true">[Chorus]<br/>Sit down<br/>Be humble</div></div>`)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeResponse(w http.ResponseWriter, response any) {
+	_ = json.NewEncoder(w).Encode(map[string]any{"meta": map[string]any{"status": 200}, "response": response})
+}
+
+// newClient returns a client of a Genius server backed by the fake Genius.
+func newClient(t *testing.T) grpcapi.GeniusClient {
+	t.Helper()
+
+	fake := httptest.NewServer(http.HandlerFunc(fakeGenius))
+	t.Cleanup(fake.Close)
+
+	return serve(t, genius.NewClient(nil, "token", genius.WithBaseURL(fake.URL)))
+}
+
+// serve returns a client of a Genius server backed by api.
+func serve(t *testing.T, api genius.GeniusAPI) grpcapi.GeniusClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	grpcapi.RegisterGeniusServer(server, grpcapi.NewServer(api))
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return grpcapi.NewGeniusClient(conn)
+}
+
+func TestServer(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	search, err := client.SearchSongs(ctx, &grpcapi.SearchSongsRequest{Query: "humble"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(search.GetSongs()) != 1 || search.GetSongs()[0].GetTitle() != "HUMBLE." {
+		t.Errorf("unexpected search results %v", search.GetSongs())
+	}
+
+	song, err := client.GetSong(ctx, &grpcapi.GetSongRequest{Id: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if song.GetArtist() != "Kendrick Lamar" || song.GetAlbum() != "DAMN." {
+		t.Errorf("unexpected song %v", song)
+	}
+
+	lyrics, err := client.GetLyrics(ctx, &grpcapi.GetLyricsRequest{Id: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "[Chorus]\nSit down\nBe humble"; lyrics.GetLyrics() != want {
+		t.Errorf("got lyrics %q, want %q", lyrics.GetLyrics(), want)
+	}
+}
+
+func TestServerGetAlbum(t *testing.T) {
+	client := newClient(t)
+
+	stream, err := client.GetAlbum(context.Background(), &grpcapi.GetAlbumRequest{Id: 2, Lyrics: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tracks []*grpcapi.AlbumTrack
+	for {
+		track, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		tracks = append(tracks, track)
+	}
+
+	if len(tracks) != 1 {
+		t.Fatalf("got %d tracks, want 1", len(tracks))
+	}
+	track := tracks[0]
+	if track.GetNumber() != 8 || track.GetAlbum().GetArtist() != "Kendrick Lamar" || track.GetLyrics() == "" {
+		t.Errorf("unexpected track %v", track)
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	client := newClient(t)
+	ctx := context.Background()
+
+	if _, err := client.GetLyrics(ctx, &grpcapi.GetLyricsRequest{Artist: "Kendrick Lamar"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument without a title, got %v", err)
+	}
+	if _, err := client.GetLyrics(ctx, &grpcapi.GetLyricsRequest{Artist: "Nobody", Title: "Nothing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound without a match, got %v", err)
+	}
+	if _, err := client.GetSong(ctx, &grpcapi.GetSongRequest{Id: 3}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for a missing song, got %v", err)
+	}
+}
+
+func TestServerUpstreamErrors(t *testing.T) {
+	fake := geniustest.NewFake()
+	client := serve(t, fake)
+
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{&genius.StatusError{StatusCode: http.StatusNotFound}, codes.NotFound},
+		{&genius.StatusError{StatusCode: http.StatusGone}, codes.NotFound},
+		{&genius.StatusError{StatusCode: http.StatusBadRequest}, codes.InvalidArgument},
+		{&genius.StatusError{StatusCode: http.StatusTooManyRequests}, codes.ResourceExhausted},
+		{&genius.StatusError{StatusCode: http.StatusUnauthorized}, codes.Internal},
+		{&genius.StatusError{StatusCode: http.StatusInternalServerError}, codes.Unavailable},
+		{&genius.StatusError{StatusCode: http.StatusServiceUnavailable}, codes.Unavailable},
+		{fmt.Errorf("scraping lyrics: %w", genius.ErrNoLyrics), codes.NotFound},
+		{fmt.Errorf("song 1 %w", genius.ErrNotFound), codes.NotFound},
+		{genius.ErrNoMatch, codes.NotFound},
+		{&genius.BlockedError{URL: "https://genius.com/humble", StatusCode: http.StatusForbidden}, codes.ResourceExhausted},
+		{context.DeadlineExceeded, codes.DeadlineExceeded},
+		{&url.Error{Op: "Get", URL: "/songs/1", Err: errors.New("connection reset")}, codes.Unavailable},
+		{genius.ErrResponseTooLarge, codes.Internal},
+	}
+
+	for _, tt := range tests {
+		fake.SetError(tt.err)
+		if _, err := client.GetSong(context.Background(), &grpcapi.GetSongRequest{Id: 1}); status.Code(err) != tt.code {
+			t.Errorf("GetSong failing with %v = %v, want %v", tt.err, status.Code(err), tt.code)
+		}
+	}
+}
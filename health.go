@@ -0,0 +1,71 @@
+package genius
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// healthCheckSongID is the song CheckHealth fetches, HUMBLE. by Kendrick Lamar.
+const healthCheckSongID = 3039923
+
+// ErrLyricsMissing is returned by CheckHealth when the lyrics of a song that has lyrics are extracted empty.
+var ErrLyricsMissing = errors.New("extracted lyrics are empty")
+
+// StatusError is returned for requests Genius answered with an error status that isn't retried.
+type StatusError struct {
+	StatusCode int
+	// Body is the start of the response body, usually a message.
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	if strings.TrimSpace(e.Body) == "" {
+		return http.StatusText(e.StatusCode)
+	}
+	return e.Body
+}
+
+// Health is the result of Client.CheckHealth, every check is nil if it passed.
+type Health struct {
+	// Upstream is why Genius couldn't be reached, the other checks are nil if it is set.
+	Upstream error
+	// Token is why Genius rejected the access token.
+	Token error
+	// Extractor is why the lyrics of a known song couldn't be extracted, which happens when genius.com changes its
+	// pages.
+	Extractor error
+}
+
+// OK reports whether all checks passed.
+func (h *Health) OK() bool {
+	return h.Upstream == nil && h.Token == nil && h.Extractor == nil
+}
+
+// CheckHealth checks that Genius is reachable, accepts the client's token and serves lyrics it can extract by
+// fetching a known song and its lyrics, bypassing the memo and cache.
+func (c *Client) CheckHealth(ctx context.Context) *Health {
+	health := &Health{}
+
+	song, err := c.getSong(ctx, healthCheckSongID, FormatPlain)
+	var statusErr *StatusError
+	switch {
+	case errors.As(err, &statusErr) && (statusErr.StatusCode == http.StatusUnauthorized || statusErr.StatusCode == http.StatusForbidden):
+		health.Token = err
+		return health
+	case err != nil:
+		health.Upstream = err
+		return health
+	}
+
+	// The desktop page is checked without falling back to the AMP page, which would hide changes to it.
+	lyrics, err := c.scrapeLyrics(ctx, song.URL, "")
+	switch {
+	case err != nil:
+		health.Extractor = err
+	case strings.TrimSpace(lyrics) == "":
+		health.Extractor = ErrLyricsMissing
+	}
+	return health
+}
@@ -0,0 +1,64 @@
+package genius
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Hit types, the type discriminator of search hits and search/multi sections.
+const (
+	HitTypeSong   = "song"
+	HitTypeLyric  = "lyric"
+	HitTypeArtist = "artist"
+	HitTypeAlbum  = "album"
+	HitTypeUser   = "user"
+)
+
+// ErrHitType is returned when decoding a search hit as a type it doesn't have.
+var ErrHitType = errors.New("search hit has a different type")
+
+// AsSong decodes the result of a song hit. Lyrics hits, which match a song's lyrics rather than its title, are songs
+// as well.
+func (h *Hit) AsSong() (*Song, error) {
+	if h.Type != HitTypeSong && h.Type != HitTypeLyric {
+		return nil, fmt.Errorf("%w: %s, not song", ErrHitType, h.Type)
+	}
+	return decodeHit[Song](h)
+}
+
+// AsArtist decodes the result of an artist hit.
+func (h *Hit) AsArtist() (*Artist, error) {
+	if h.Type != HitTypeArtist {
+		return nil, fmt.Errorf("%w: %s, not artist", ErrHitType, h.Type)
+	}
+	return decodeHit[Artist](h)
+}
+
+// AsAlbum decodes the result of an album hit.
+func (h *Hit) AsAlbum() (*Album, error) {
+	if h.Type != HitTypeAlbum {
+		return nil, fmt.Errorf("%w: %s, not album", ErrHitType, h.Type)
+	}
+	return decodeHit[Album](h)
+}
+
+// AsUser decodes the result of a user hit.
+func (h *Hit) AsUser() (*User, error) {
+	if h.Type != HitTypeUser {
+		return nil, fmt.Errorf("%w: %s, not user", ErrHitType, h.Type)
+	}
+	return decodeHit[User](h)
+}
+
+func decodeHit[T any](h *Hit) (*T, error) {
+	return decodeResult[T](h.Result)
+}
+
+func decodeResult[T any](raw json.RawMessage) (*T, error) {
+	var result T
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,98 @@
+package genius_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+func TestHitAs(t *testing.T) {
+	tests := []struct {
+		hit  string
+		want string
+	}{
+		{`{"type": "song", "result": {"id": 1, "title": "HUMBLE."}}`, genius.HitTypeSong},
+		{`{"type": "lyric", "result": {"id": 1, "title": "HUMBLE."}}`, genius.HitTypeSong},
+		{`{"type": "artist", "result": {"id": 1, "name": "Kendrick Lamar"}}`, genius.HitTypeArtist},
+		{`{"type": "album", "result": {"id": 1, "name": "DAMN."}}`, genius.HitTypeAlbum},
+		{`{"type": "user", "result": {"id": 1, "login": "kendrick"}}`, genius.HitTypeUser},
+	}
+
+	for _, tt := range tests {
+		var hit genius.Hit
+		if err := json.Unmarshal([]byte(tt.hit), &hit); err != nil {
+			t.Fatal(err)
+		}
+
+		ids := map[string]func() (int, error){
+			genius.HitTypeSong: func() (int, error) {
+				song, err := hit.AsSong()
+				if err != nil {
+					return 0, err
+				}
+				return song.ID, nil
+			},
+			genius.HitTypeArtist: func() (int, error) {
+				artist, err := hit.AsArtist()
+				if err != nil {
+					return 0, err
+				}
+				return artist.ID, nil
+			},
+			genius.HitTypeAlbum: func() (int, error) {
+				album, err := hit.AsAlbum()
+				if err != nil {
+					return 0, err
+				}
+				return album.ID, nil
+			},
+			genius.HitTypeUser: func() (int, error) {
+				user, err := hit.AsUser()
+				if err != nil {
+					return 0, err
+				}
+				return user.ID, nil
+			},
+		}
+		for hitType, id := range ids {
+			got, err := id()
+			switch {
+			case hitType == tt.want && (err != nil || got != 1):
+				t.Errorf("%s hit as %s: got %d, %v", hit.Type, hitType, got, err)
+			case hitType != tt.want && !errors.Is(err, genius.ErrHitType):
+				t.Errorf("%s hit as %s: got %v, want ErrHitType", hit.Type, hitType, err)
+			}
+		}
+	}
+}
+
+func TestHitAsMalformedResult(t *testing.T) {
+	hit := genius.Hit{Type: genius.HitTypeSong, Result: json.RawMessage(`{"id": "one"}`)}
+	if _, err := hit.AsSong(); err == nil || errors.Is(err, genius.ErrHitType) {
+		t.Errorf("got %v, want a decoding error", err)
+	}
+}
+
+func TestGetArtistFromSearchResponse(t *testing.T) {
+	response := newWebSearchResponse(t, `[
+		{"type": "song", "hits": [{"type": "song", "result": {"id": 1, "title": "Kendrick Lamar"}}]},
+		{"type": "artist", "hits": [
+			{"type": "artist", "result": {"id": 10, "name": "Kendrick Lamar Fans"}},
+			{"type": "artist", "result": {"id": 11, "name": "Kendrick Lamar"}}
+		]}
+	]`)
+
+	artist, err := genius.GetArtistFromSearchResponse(response, "kendrick lamar")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if artist.ID != 11 {
+		t.Errorf("got artist %d, want the exact match 11", artist.ID)
+	}
+
+	if _, err = genius.GetArtistFromSearchResponse(newWebSearchResponse(t, `[]`), "kendrick lamar"); err == nil {
+		t.Error("expected an error without artist hits")
+	}
+}
@@ -0,0 +1,58 @@
+package httpapi
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// responseCache keeps up to size response bodies until they expire, evicting the least recently used.
+type responseCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type cachedResponse struct {
+	key     string
+	body    []byte
+	expires time.Time
+}
+
+func newResponseCache(size int) *responseCache {
+	return &responseCache{size: size, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+func (c *responseCache) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	response := e.Value.(*cachedResponse)
+	if time.Now().After(response.expires) {
+		c.order.Remove(e)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return response.body, true
+}
+
+func (c *responseCache) put(key string, body []byte, expires time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		c.order.Remove(e)
+	}
+	c.entries[key] = c.order.PushFront(&cachedResponse{key: key, body: body, expires: expires})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cachedResponse).key)
+	}
+}
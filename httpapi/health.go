@@ -0,0 +1,133 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/natecham/genius"
+)
+
+// healthChecker checks the health of a client at most once per interval.
+type healthChecker struct {
+	client   genius.GeniusAPI
+	interval time.Duration
+	timeout  time.Duration
+
+	// mu guards the fields below, it isn't held while checking.
+	mu        sync.Mutex
+	last      *genius.Health
+	checkedAt time.Time
+	// running is the check in progress, nil if there is none.
+	running *healthCheck
+}
+
+// healthCheck is a health check shared by the requests waiting for it.
+type healthCheck struct {
+	done      chan struct{}
+	health    *genius.Health
+	checkedAt time.Time
+}
+
+// lastCheck returns the last health check, nil if none was made yet.
+func (c *healthChecker) lastCheck() (*genius.Health, time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.last, c.checkedAt
+}
+
+// check returns the last health check, checking again if it is older than the interval. The check isn't bound to
+// ctx, so that requests giving up don't fail it for the others, it times out on its own. Checks failing with a
+// context error aren't reused.
+func (c *healthChecker) check(ctx context.Context) (*genius.Health, time.Time) {
+	c.mu.Lock()
+	if c.last != nil && time.Since(c.checkedAt) < c.interval {
+		defer c.mu.Unlock()
+		return c.last, c.checkedAt
+	}
+	running := c.running
+	if running == nil {
+		running = &healthCheck{done: make(chan struct{})}
+		c.running = running
+		go c.run(context.WithoutCancel(ctx), running)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-running.done:
+		return running.health, running.checkedAt
+	case <-ctx.Done():
+		return &genius.Health{Upstream: ctx.Err()}, time.Now()
+	}
+}
+
+// run makes the health check running and records it.
+func (c *healthChecker) run(ctx context.Context, running *healthCheck) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	running.health, running.checkedAt = c.client.CheckHealth(ctx), time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.running = nil
+	if !isContextError(running.health) {
+		c.last, c.checkedAt = running.health, running.checkedAt
+	}
+	close(running.done)
+}
+
+// isContextError reports whether a check of health failed because its context ended rather than because of Genius.
+func isContextError(health *genius.Health) bool {
+	for _, err := range []error{health.Upstream, health.Token, health.Extractor} {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return true
+		}
+	}
+	return false
+}
+
+// healthz reports the last health check without making one, as the handler is alive regardless of Genius.
+func (h *handler) healthz(w http.ResponseWriter, r *http.Request) {
+	health, checkedAt := h.health.lastCheck()
+	writeHealth(w, health, checkedAt, http.StatusOK)
+}
+
+func (h *handler) readyz(w http.ResponseWriter, r *http.Request) {
+	health, checkedAt := h.health.check(r.Context())
+	status := http.StatusOK
+	if !health.OK() {
+		status = http.StatusServiceUnavailable
+	}
+	writeHealth(w, health, checkedAt, status)
+}
+
+// writeHealth writes the HealthResponse of health, which is nil if no check was made yet.
+func writeHealth(w http.ResponseWriter, health *genius.Health, checkedAt time.Time, status int) {
+	response := HealthResponse{Status: "ok", CheckedAt: checkedAt.UTC()}
+	if health != nil {
+		response.Checks = map[string]string{
+			"upstream":  checkResult(health.Upstream),
+			"token":     checkResult(health.Token),
+			"extractor": checkResult(health.Extractor),
+		}
+		if !health.OK() {
+			response.Status = "unavailable"
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func checkResult(err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return "ok"
+}
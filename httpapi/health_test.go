@@ -0,0 +1,171 @@
+package httpapi_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/httpapi"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestHealth(t *testing.T) {
+	var requests atomic.Int32
+	fake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fakeGenius(w, r)
+	}))
+	t.Cleanup(fake.Close)
+
+	registry := prometheus.NewRegistry()
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(fake.URL), genius.WithMetrics(registry))
+	server := httptest.NewServer(httpapi.NewHandler(client, &httpapi.Options{HealthInterval: time.Hour, Metrics: registry}))
+	t.Cleanup(server.Close)
+
+	var health httpapi.HealthResponse
+	resp := getJSON(t, server.URL+"/readyz", &health)
+	if resp.StatusCode != http.StatusOK || health.Status != "ok" || health.Checks["extractor"] != "ok" {
+		t.Fatalf("unexpected readiness %d %+v", resp.StatusCode, health)
+	}
+
+	made := requests.Load()
+	if resp = getJSON(t, server.URL+"/healthz", &health); resp.StatusCode != http.StatusOK || requests.Load() != made {
+		t.Errorf("expected the health check to be reused, got %d with %d more requests", resp.StatusCode, requests.Load()-made)
+	}
+
+	resp, err := http.Get(server.URL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	metrics, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(metrics), "genius_requests_total") {
+		t.Errorf("expected the client's metrics, got %q", metrics)
+	}
+}
+
+func TestHealthUnavailable(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		check   string
+	}{
+		{"rejected token", func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"error":"invalid_token"}`, http.StatusUnauthorized)
+		}, "token"},
+		{"changed lyrics page", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/humble-lyrics" {
+				w.Write([]byte("<html><body>A new layout</body></html>"))
+				return
+			}
+			fakeGenius(w, r)
+		}, "extractor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := httptest.NewServer(tt.handler)
+			t.Cleanup(fake.Close)
+			client := genius.NewClient(nil, "token", genius.WithBaseURL(fake.URL))
+			server := httptest.NewServer(httpapi.NewHandler(client, nil))
+			t.Cleanup(server.Close)
+
+			var health httpapi.HealthResponse
+			resp := getJSON(t, server.URL+"/readyz", &health)
+			if resp.StatusCode != http.StatusServiceUnavailable || health.Status != "unavailable" || health.Checks[tt.check] == "ok" {
+				t.Errorf("expected the %s check to fail, got %d %+v", tt.check, resp.StatusCode, health)
+			}
+			if resp = getJSON(t, server.URL+"/healthz", &health); resp.StatusCode != http.StatusOK {
+				t.Errorf("expected /healthz to stay up, got %d", resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestHealthzWithoutCheck(t *testing.T) {
+	var requests atomic.Int32
+	fake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fakeGenius(w, r)
+	}))
+	t.Cleanup(fake.Close)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(fake.URL))
+	server := httptest.NewServer(httpapi.NewHandler(client, nil))
+	t.Cleanup(server.Close)
+
+	var health httpapi.HealthResponse
+	resp := getJSON(t, server.URL+"/healthz", &health)
+	if resp.StatusCode != http.StatusOK || health.Status != "ok" || health.Checks != nil {
+		t.Errorf("unexpected liveness %d %+v", resp.StatusCode, health)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("/healthz made %d requests to Genius", n)
+	}
+}
+
+func TestHealthTimeout(t *testing.T) {
+	var slow atomic.Bool
+	slow.Store(true)
+	fake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if slow.Load() {
+			<-r.Context().Done()
+			return
+		}
+		fakeGenius(w, r)
+	}))
+	t.Cleanup(fake.Close)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(fake.URL))
+	opts := &httpapi.Options{HealthInterval: time.Hour, HealthTimeout: 50 * time.Millisecond}
+	server := httptest.NewServer(httpapi.NewHandler(client, opts))
+	t.Cleanup(server.Close)
+
+	var health httpapi.HealthResponse
+	if resp := getJSON(t, server.URL+"/readyz", &health); resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected the check to time out, got %d %+v", resp.StatusCode, health)
+	}
+
+	// The timed out check isn't reused for the interval.
+	slow.Store(false)
+	if resp := getJSON(t, server.URL+"/readyz", &health); resp.StatusCode != http.StatusOK {
+		t.Errorf("expected a new check, got %d %+v", resp.StatusCode, health)
+	}
+}
+
+func TestHealthCheckOutlivesRequest(t *testing.T) {
+	release := make(chan struct{})
+	fake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		fakeGenius(w, r)
+	}))
+	t.Cleanup(fake.Close)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(fake.URL))
+	server := httptest.NewServer(httpapi.NewHandler(client, &httpapi.Options{HealthInterval: time.Hour}))
+	t.Cleanup(server.Close)
+
+	// The probe gives up before the check finishes, which isn't cancelled by it.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/readyz", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp, err := http.DefaultClient.Do(req); err == nil {
+		resp.Body.Close()
+		t.Fatalf("expected the probe to time out, got %d", resp.StatusCode)
+	}
+	close(release)
+
+	var health httpapi.HealthResponse
+	if resp := getJSON(t, server.URL+"/readyz", &health); resp.StatusCode != http.StatusOK || health.Status != "ok" {
+		t.Errorf("unexpected readiness %d %+v", resp.StatusCode, health)
+	}
+}
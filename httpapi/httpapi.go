@@ -0,0 +1,266 @@
+// Package httpapi serves lyrics, songs and search results fetched from Genius over HTTP as JSON, for programs not
+// written in Go or Go services mounting it under their own router:
+//
+//	mux.Handle("/genius/", http.StripPrefix("/genius", httpapi.NewHandler(client, nil)))
+package httpapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/natecham/genius"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	// DefaultMaxCachedResponses bounds the responses a handler keeps if Options don't.
+	DefaultMaxCachedResponses = 1000
+	// DefaultHealthInterval is how long a handler reuses the result of a health check if Options don't set it.
+	DefaultHealthInterval = time.Minute
+	// DefaultHealthTimeout is how long a health check may take if Options don't set it.
+	DefaultHealthTimeout = 10 * time.Second
+)
+
+// Options configure a handler.
+type Options struct {
+	// CacheTTL is how long successful responses are kept and served again, 0 disables caching.
+	CacheTTL time.Duration
+	// MaxCachedResponses bounds the responses kept, the least recently used are evicted. DefaultMaxCachedResponses
+	// is used if it isn't set.
+	MaxCachedResponses int
+	// HealthInterval is how long the result of a health check is reused by /readyz, so that probes don't make
+	// requests to Genius each time. DefaultHealthInterval is used if it isn't set.
+	HealthInterval time.Duration
+	// HealthTimeout is how long a health check may take, independent of the requests waiting for it.
+	// DefaultHealthTimeout is used if it isn't set.
+	HealthTimeout time.Duration
+	// Metrics is served at /metrics if it is set, usually the prometheus.Registry passed to genius.WithMetrics.
+	Metrics prometheus.Gatherer
+}
+
+// Song is a song in responses.
+type Song struct {
+	ID     int    `json:"id"`
+	Title  string `json:"title"`
+	Artist string `json:"artist"`
+	URL    string `json:"url"`
+}
+
+// LyricsResponse is the response of GET /lyrics.
+type LyricsResponse struct {
+	Song   Song   `json:"song"`
+	Lyrics string `json:"lyrics"`
+}
+
+// ErrorResponse is the response of failed requests.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// HealthResponse is the response of GET /healthz and GET /readyz.
+type HealthResponse struct {
+	// Status is "ok" if all checks passed, else "unavailable".
+	Status string `json:"status"`
+	// Checks are "ok" or the error of the upstream, token and extractor checks, see genius.Health. They are only
+	// missing from /healthz responses before the first check.
+	Checks map[string]string `json:"checks,omitempty"`
+	// CheckedAt is when the checks were made.
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// handler serves the routes of NewHandler.
+type handler struct {
+	client genius.GeniusAPI
+	cache  *responseCache
+	ttl    time.Duration
+	health *healthChecker
+}
+
+// statusError is an error with the status it is served with.
+type statusError struct {
+	status int
+	err    error
+}
+
+func (e *statusError) Error() string { return e.err.Error() }
+func (e *statusError) Unwrap() error { return e.err }
+
+// NewHandler returns a handler serving, opts may be nil:
+//
+//	GET /lyrics?artist=&title=  the LyricsResponse of the song best matching artist and title, see Client.MatchTrack
+//	GET /lyrics?id=             the LyricsResponse of the song with the ID
+//	GET /song/{id}              the genius.Song with the ID
+//	GET /search?q=              the Songs found for q
+//	GET /healthz                the HealthResponse of the last health check, always with status 200 as the handler is alive
+//	GET /readyz                 the HealthResponse of a health check, with status 503 if a check failed
+//	GET /metrics                opts.Metrics in the Prometheus format, if set
+//
+// Health checks are made by /readyz with the CheckHealth of the client when the last one is older than
+// opts.HealthInterval, /healthz doesn't request Genius. Checks that time out after opts.HealthTimeout aren't reused.
+//
+// Failed requests are answered with an ErrorResponse, with status 400 for invalid requests, 404 if no song matches
+// and 502 if Genius failed. Successful responses are cached for opts.CacheTTL, the X-Cache header tells whether a
+// response was a HIT or a MISS of the cache and Cache-Control lets clients cache it as long.
+func NewHandler(client genius.GeniusAPI, opts *Options) http.Handler {
+	var o Options
+	if opts != nil {
+		o = *opts
+	}
+	if o.MaxCachedResponses <= 0 {
+		o.MaxCachedResponses = DefaultMaxCachedResponses
+	}
+	if o.HealthInterval <= 0 {
+		o.HealthInterval = DefaultHealthInterval
+	}
+	if o.HealthTimeout <= 0 {
+		o.HealthTimeout = DefaultHealthTimeout
+	}
+
+	h := &handler{
+		client: client,
+		cache:  newResponseCache(o.MaxCachedResponses),
+		ttl:    o.CacheTTL,
+		health: &healthChecker{client: client, interval: o.HealthInterval, timeout: o.HealthTimeout},
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /lyrics", h.handle(h.lyrics))
+	mux.Handle("GET /song/{id}", h.handle(h.song))
+	mux.Handle("GET /search", h.handle(h.search))
+	mux.HandleFunc("GET /healthz", h.healthz)
+	mux.HandleFunc("GET /readyz", h.readyz)
+	if o.Metrics != nil {
+		mux.Handle("GET /metrics", promhttp.HandlerFor(o.Metrics, promhttp.HandlerOpts{}))
+	}
+	return mux
+}
+
+// handle adapts fn, which returns the response body, to an http.Handler encoding it as JSON and caching it.
+func (h *handler) handle(fn func(r *http.Request) (any, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		key := r.URL.RequestURI()
+		if body, ok := h.cache.get(key); ok {
+			h.writeCached(w, body, "HIT")
+			return
+		}
+
+		v, err := fn(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		var b bytes.Buffer
+		if err = json.NewEncoder(&b).Encode(v); err != nil {
+			writeError(w, err)
+			return
+		}
+		if h.ttl > 0 {
+			h.cache.put(key, b.Bytes(), time.Now().Add(h.ttl))
+		}
+		h.writeCached(w, b.Bytes(), "MISS")
+	})
+}
+
+func (h *handler) writeCached(w http.ResponseWriter, body []byte, cache string) {
+	w.Header().Set("X-Cache", cache)
+	if h.ttl > 0 {
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(h.ttl.Seconds())))
+	}
+	_, _ = w.Write(body)
+}
+
+// notFound reports whether Genius answered with statusCode because the requested resource doesn't exist.
+func notFound(statusCode int) bool {
+	return statusCode == http.StatusNotFound || statusCode == http.StatusGone
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusBadGateway
+	var statusErr *statusError
+	var upstreamErr *genius.StatusError
+	switch {
+	case errors.As(err, &statusErr):
+		status = statusErr.status
+	case errors.Is(err, genius.ErrNoMatch), errors.Is(err, genius.ErrNoLyrics), errors.Is(err, genius.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.As(err, &upstreamErr) && notFound(upstreamErr.StatusCode):
+		status = http.StatusNotFound
+	}
+
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(ErrorResponse{Error: err.Error()})
+}
+
+func (h *handler) lyrics(r *http.Request) (any, error) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	var song *genius.Song
+	var err error
+	switch {
+	case query.Has("id"):
+		var id int
+		if id, err = songID(query.Get("id")); err == nil {
+			song, err = h.client.GetSong(ctx, id)
+		}
+	case query.Get("artist") != "" && query.Get("title") != "":
+		song, err = h.client.MatchTrack(ctx, query.Get("title"), query.Get("artist"), 0)
+	default:
+		err = &statusError{http.StatusBadRequest, errors.New("artist and title or id are required")}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	lyrics, err := h.client.GetLyrics(ctx, song.URL)
+	if err != nil {
+		return nil, err
+	}
+	return LyricsResponse{Song: summarize(song), Lyrics: lyrics}, nil
+}
+
+func (h *handler) song(r *http.Request) (any, error) {
+	id, err := songID(r.PathValue("id"))
+	if err != nil {
+		return nil, err
+	}
+	return h.client.GetSong(r.Context(), id)
+}
+
+func (h *handler) search(r *http.Request) (any, error) {
+	q := r.URL.Query().Get("q")
+	if q == "" {
+		return nil, &statusError{http.StatusBadRequest, errors.New("q is required")}
+	}
+
+	songs := []Song{}
+	for hit, err := range h.client.SearchHits(r.Context(), q, &genius.ListOptions{MaxItems: 10}) {
+		if err != nil {
+			return nil, err
+		}
+		if song, err := hit.AsSong(); err == nil {
+			songs = append(songs, summarize(song))
+		}
+	}
+	return songs, nil
+}
+
+func songID(s string) (int, error) {
+	id, err := strconv.Atoi(s)
+	if err != nil || id <= 0 {
+		return 0, &statusError{http.StatusBadRequest, fmt.Errorf("invalid song id %q", s)}
+	}
+	return id, nil
+}
+
+func summarize(song *genius.Song) Song {
+	return Song{ID: song.ID, Title: song.Title, Artist: song.ArtistNames, URL: song.URL}
+}
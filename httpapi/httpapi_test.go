@@ -0,0 +1,158 @@
+package httpapi_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/httpapi"
+)
+
+// fakeGenius serves a search for and the song and lyrics page of HUMBLE.
+func fakeGenius(w http.ResponseWriter, r *http.Request) {
+	song := map[string]any{
+		"id":             1,
+		"title":          "HUMBLE.",
+		"artist_names":   "Kendrick Lamar",
+		"primary_artist": map[string]any{"name": "Kendrick Lamar"},
+		"url":            "http://" + r.Host + "/humble-lyrics",
+	}
+	switch r.URL.Path {
+	case "/search":
+		writeResponse(w, map[string]any{"hits": []map[string]any{{"index": "song", "type": "song", "result": song}}})
+	case "/songs/1", "/songs/3039923":
+		writeResponse(w, map[string]any{"song": song})
+	case "/songs/2":
+		song["id"], song["url"] = 2, "http://"+r.Host+"/missing-lyrics"
+		writeResponse(w, map[string]any{"song": song})
+	case "/missing-lyrics":
+		fmt.Fprint(w, `<html><body>Not a lyrics page</body></html>`)
+	case "/humble-lyrics":
+		fmt.Fprint(w, `<div id="lyrics-root"><div data-lyrics-container="true">[Intro]<br/>Nobody pray for me<br/><br/>[Chorus]<br/>Sit down<br/>Be humble</div></div>`)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeResponse(w http.ResponseWriter, response any) {
+	_ = json.NewEncoder(w).Encode(map[string]any{"meta": map[string]any{"status": 200}, "response": response})
+}
+
+func newServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	fake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fakeGenius(w, r)
+	}))
+	t.Cleanup(fake.Close)
+
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(fake.URL))
+	server := httptest.NewServer(httpapi.NewHandler(client, &httpapi.Options{CacheTTL: time.Minute}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func getJSON(t *testing.T, u string, v any) *http.Response {
+	t.Helper()
+
+	resp, err := http.Get(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if err = json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestLyrics(t *testing.T) {
+	server, requests := newServer(t)
+	u := server.URL + "/lyrics?" + url.Values{"artist": {"Kendrick Lamar"}, "title": {"HUMBLE."}}.Encode()
+
+	var lyrics httpapi.LyricsResponse
+	resp := getJSON(t, u, &lyrics)
+	if resp.StatusCode != http.StatusOK || lyrics.Song.ID != 1 || lyrics.Lyrics == "" {
+		t.Fatalf("unexpected response %d %+v", resp.StatusCode, lyrics)
+	}
+	if resp.Header.Get("X-Cache") != "MISS" || resp.Header.Get("Cache-Control") != "public, max-age=60" {
+		t.Errorf("unexpected cache headers %v", resp.Header)
+	}
+
+	made := requests.Load()
+	resp = getJSON(t, u, &lyrics)
+	if resp.Header.Get("X-Cache") != "HIT" || requests.Load() != made {
+		t.Errorf("expected the second response from the cache")
+	}
+}
+
+func TestSongAndSearch(t *testing.T) {
+	server, _ := newServer(t)
+
+	var song struct {
+		ID    int    `json:"id"`
+		Title string `json:"title"`
+	}
+	if resp := getJSON(t, server.URL+"/song/1", &song); resp.StatusCode != http.StatusOK || song.Title != "HUMBLE." {
+		t.Errorf("unexpected song %d %+v", resp.StatusCode, song)
+	}
+
+	var songs []httpapi.Song
+	if resp := getJSON(t, server.URL+"/search?q=humble", &songs); resp.StatusCode != http.StatusOK || len(songs) != 1 {
+		t.Errorf("unexpected search results %d %+v", resp.StatusCode, songs)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	server, _ := newServer(t)
+
+	tests := []struct {
+		path   string
+		status int
+	}{
+		{"/lyrics?artist=Kendrick+Lamar", http.StatusBadRequest},
+		{"/song/abc", http.StatusBadRequest},
+		{"/search", http.StatusBadRequest},
+		{"/lyrics?artist=Nobody&title=Nothing", http.StatusNotFound},
+		{"/song/999999", http.StatusNotFound},
+		{"/lyrics?id=2", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		var body httpapi.ErrorResponse
+		resp := getJSON(t, server.URL+tt.path, &body)
+		if resp.StatusCode != tt.status || body.Error == "" {
+			t.Errorf("GET %s = %d %v, want %d with an error", tt.path, resp.StatusCode, body, tt.status)
+		}
+	}
+}
+
+func TestMountedWithoutCache(t *testing.T) {
+	fake := httptest.NewServer(http.HandlerFunc(fakeGenius))
+	t.Cleanup(fake.Close)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(fake.URL))
+
+	mux := http.NewServeMux()
+	mux.Handle("/genius/", http.StripPrefix("/genius", httpapi.NewHandler(client, nil)))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	var lyrics httpapi.LyricsResponse
+	resp := getJSON(t, server.URL+"/genius/lyrics?id=1", &lyrics)
+	if resp.StatusCode != http.StatusOK || lyrics.Song.Title != "HUMBLE." {
+		t.Fatalf("unexpected response %d %+v", resp.StatusCode, lyrics)
+	}
+	resp = getJSON(t, server.URL+"/genius/lyrics?id=1", &lyrics)
+	if resp.Header.Get("X-Cache") != "MISS" || resp.Header.Get("Cache-Control") != "" {
+		t.Errorf("expected no caching without a TTL, got %v", resp.Header)
+	}
+}
@@ -0,0 +1,220 @@
+package genius
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+)
+
+// ImageSize is a size variant of the images of songs, albums and artists.
+type ImageSize string
+
+const (
+	// ImageThumbnail is 300 pixels wide.
+	ImageThumbnail ImageSize = "thumbnail"
+	// ImageMedium is 500 pixels wide, or the full size image if it is smaller.
+	ImageMedium ImageSize = "medium"
+	// ImageFull is the original image, usually 1000 pixels wide.
+	ImageFull ImageSize = "full"
+)
+
+// Widths of the resized image variants.
+const (
+	thumbnailWidth = 300
+	mediumWidth    = 500
+)
+
+// ErrNoImage is returned when downloading an image that the song, album or artist doesn't have.
+var ErrNoImage = errors.New("no image")
+
+// imageDimensions matches the dimensions in the name of images on images.genius.com, e.g. .1000x1000x1.jpg, which
+// are served in other sizes when the dimensions are changed.
+var imageDimensions = regexp.MustCompile(`\.(\d+)x(\d+)x(\d+)(\.[a-z]+)$`)
+
+// resizedImageURL returns the URL of the variant of the image at imageURL that is width pixels wide, or "" if the
+// URL has no dimensions or the image isn't wider than width.
+func resizedImageURL(imageURL string, width int) string {
+	match := imageDimensions.FindStringSubmatchIndex(imageURL)
+	if match == nil {
+		return ""
+	}
+	w, _ := strconv.Atoi(imageURL[match[2]:match[3]])
+	h, _ := strconv.Atoi(imageURL[match[4]:match[5]])
+	if w <= width {
+		return ""
+	}
+
+	height := h * width / w
+	return fmt.Sprintf("%s.%dx%dx%s%s", imageURL[:match[0]], width, height, imageURL[match[6]:match[7]], imageURL[match[8]:match[9]])
+}
+
+// imageCandidates returns the URLs of the size variant of an image in the order they are tried, the thumbnail and
+// full size URLs as Genius returns them followed by the variants derived from them.
+func imageCandidates(size ImageSize, thumbnailURL string, fullURL string) ([]string, error) {
+	var candidates []string
+	switch size {
+	case ImageThumbnail:
+		candidates = []string{thumbnailURL, resizedImageURL(fullURL, thumbnailWidth), fullURL}
+	case ImageMedium:
+		candidates = []string{resizedImageURL(fullURL, mediumWidth), fullURL}
+	case ImageFull, "":
+		candidates = []string{fullURL}
+	default:
+		return nil, fmt.Errorf("unknown image size %q", string(size))
+	}
+
+	var urls []string
+	for _, candidate := range candidates {
+		if candidate != "" && (len(urls) == 0 || urls[len(urls)-1] != candidate) {
+			urls = append(urls, candidate)
+		}
+	}
+	if len(urls) == 0 {
+		return nil, ErrNoImage
+	}
+	return urls, nil
+}
+
+// CoverArt returns the URL of the cover art of the album in the size, "" if it has none or the size is unknown.
+//
+// Genius only returns the thumbnail and full size URLs, the medium variant and the thumbnails of albums without a
+// thumbnail URL are derived from the dimensions in the full size URL. DownloadAlbumArt falls back to the full size
+// image if the image CDN doesn't have the variant.
+func (a *Album) CoverArt(size ImageSize) string {
+	urls, err := imageCandidates(size, a.CoverArtThumbnailURL, a.CoverArtURL)
+	if err != nil {
+		return ""
+	}
+	return urls[0]
+}
+
+// DownloadImage writes the image at imageURL to w, e.g. a URL returned by Album.CoverArt. Redirects of the image CDN
+// are followed.
+func (c *Client) DownloadImage(ctx context.Context, imageURL string, w io.Writer) error {
+	return c.copyImage(ctx, imageURL, w)
+}
+
+// DownloadSongArt writes the song art of song in the size to w.
+//
+// Images are served by Genius's image CDN, whose redirects are followed. Variants the CDN doesn't have fall back to
+// the next larger one.
+func (c *Client) DownloadSongArt(ctx context.Context, song *Song, size ImageSize, w io.Writer) error {
+	return c.downloadImage(ctx, size, song.SongArtImageThumbnailURL, song.SongArtImageURL, w)
+}
+
+// DownloadAlbumArt writes the cover art of album in the size to w, see DownloadSongArt.
+func (c *Client) DownloadAlbumArt(ctx context.Context, album *Album, size ImageSize, w io.Writer) error {
+	return c.downloadImage(ctx, size, album.CoverArtThumbnailURL, album.CoverArtURL, w)
+}
+
+// DownloadArtistImage writes the image of artist, its avatar, in the size to w, see DownloadSongArt.
+func (c *Client) DownloadArtistImage(ctx context.Context, artist *Artist, size ImageSize, w io.Writer) error {
+	return c.downloadImage(ctx, size, "", artist.ImageURL, w)
+}
+
+// DownloadArtistHeader writes the header image of artist, the banner of its page, in the size to w, see
+// DownloadSongArt.
+func (c *Client) DownloadArtistHeader(ctx context.Context, artist *Artist, size ImageSize, w io.Writer) error {
+	return c.downloadImage(ctx, size, "", artist.HeaderImageURL, w)
+}
+
+// SaveArtistImages saves the image and header image of artist in the size to dir, as avatar and header with the
+// extension of the image, e.g. avatar.jpg. Images the artist doesn't have are skipped, the paths of the saved files
+// are returned.
+func (c *Client) SaveArtistImages(ctx context.Context, artist *Artist, size ImageSize, dir string) ([]string, error) {
+	images := []struct {
+		name     string
+		url      string
+		download func(context.Context, *Artist, ImageSize, io.Writer) error
+	}{
+		{"avatar", artist.ImageURL, c.DownloadArtistImage},
+		{"header", artist.HeaderImageURL, c.DownloadArtistHeader},
+	}
+
+	var paths []string
+	for _, image := range images {
+		if image.url == "" {
+			continue
+		}
+		file := filepath.Join(dir, image.name+imageExt(image.url))
+		err := saveFile(file, func(w io.Writer) error {
+			return image.download(ctx, artist, size, w)
+		})
+		if err != nil {
+			return paths, err
+		}
+		paths = append(paths, file)
+	}
+	return paths, nil
+}
+
+// imageExt returns the extension of the image at imageURL, .jpg if it has none.
+func imageExt(imageURL string) string {
+	if u, err := url.Parse(imageURL); err == nil {
+		if ext := path.Ext(u.Path); ext != "" {
+			return ext
+		}
+	}
+	return ".jpg"
+}
+
+// saveFile writes the file with write, removing it if write fails.
+func saveFile(file string, write func(w io.Writer) error) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if err = write(f); err != nil {
+		f.Close()
+		os.Remove(file)
+		return err
+	}
+	return f.Close()
+}
+
+func (c *Client) downloadImage(ctx context.Context, size ImageSize, thumbnailURL string, fullURL string, w io.Writer) error {
+	urls, err := imageCandidates(size, thumbnailURL, fullURL)
+	if err != nil {
+		return err
+	}
+
+	for i, imageURL := range urls {
+		err = c.copyImage(ctx, imageURL, w)
+		var statusErr *StatusError
+		if i < len(urls)-1 && errors.As(err, &statusErr) &&
+			(statusErr.StatusCode == http.StatusNotFound || statusErr.StatusCode == http.StatusForbidden) {
+			continue
+		}
+		return err
+	}
+	return err
+}
+
+// copyImage writes the image at imageURL to w, failing with a StatusError before writing if it can't be fetched.
+func (c *Client) copyImage(ctx context.Context, imageURL string, w io.Writer) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, maxErrorBodySize))
+		return fmt.Errorf("image %s: %w", imageURL, &StatusError{StatusCode: res.StatusCode})
+	}
+	_, err = io.Copy(w, res.Body)
+	return err
+}
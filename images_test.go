@@ -0,0 +1,163 @@
+package genius_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+// newImageServer serves images named after their path, resized variants are redirected to the CDN path.
+func newImageServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	images := map[string]string{
+		"/song.300x300x1.jpg":    "song thumbnail",
+		"/song.1000x1000x1.jpg":  "song full",
+		"/cdn/song.500x500x1":    "song medium",
+		"/album.1000x1000x1.png": "album full",
+		"/artist.1000x800x1.jpg": "artist full",
+		"/artist.300x240x1.jpg":  "artist thumbnail",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/song.500x500x1.jpg" {
+			http.Redirect(w, req, "/cdn/song.500x500x1", http.StatusFound)
+			return
+		}
+		if req.Header.Get("Authorization") != "" {
+			t.Errorf("image request %s sent the access token", req.URL)
+		}
+		image, ok := images[req.URL.Path]
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		w.Header().Set("Content-Type", "image/jpeg")
+		_, _ = w.Write([]byte(image))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDownloadImages(t *testing.T) {
+	server := newImageServer(t)
+	client := genius.NewClient(nil, "token")
+	song := &genius.Song{SongArtImageThumbnailURL: server.URL + "/song.300x300x1.jpg", SongArtImageURL: server.URL + "/song.1000x1000x1.jpg"}
+	album := &genius.Album{CoverArtURL: server.URL + "/album.1000x1000x1.png"}
+	artist := &genius.Artist{ImageURL: server.URL + "/artist.1000x800x1.jpg"}
+
+	tests := []struct {
+		name     string
+		download func(ctx context.Context, w *bytes.Buffer) error
+		want     string
+	}{
+		{"song thumbnail", func(ctx context.Context, w *bytes.Buffer) error {
+			return client.DownloadSongArt(ctx, song, genius.ImageThumbnail, w)
+		}, "song thumbnail"},
+		{"song medium", func(ctx context.Context, w *bytes.Buffer) error {
+			return client.DownloadSongArt(ctx, song, genius.ImageMedium, w)
+		}, "song medium"},
+		{"song full", func(ctx context.Context, w *bytes.Buffer) error {
+			return client.DownloadSongArt(ctx, song, genius.ImageFull, w)
+		}, "song full"},
+		{"album medium falls back", func(ctx context.Context, w *bytes.Buffer) error {
+			return client.DownloadAlbumArt(ctx, album, genius.ImageMedium, w)
+		}, "album full"},
+		{"artist thumbnail resized", func(ctx context.Context, w *bytes.Buffer) error {
+			return client.DownloadArtistImage(ctx, artist, genius.ImageThumbnail, w)
+		}, "artist thumbnail"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var b bytes.Buffer
+			if err := test.download(context.Background(), &b); err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != test.want {
+				t.Errorf("got %q, want %q", b.String(), test.want)
+			}
+		})
+	}
+}
+
+func TestDownloadImageErrors(t *testing.T) {
+	server := newImageServer(t)
+	client := genius.NewClient(nil, "token")
+	var b bytes.Buffer
+
+	err := client.DownloadArtistImage(context.Background(), &genius.Artist{}, genius.ImageFull, &b)
+	if !errors.Is(err, genius.ErrNoImage) {
+		t.Errorf("got %v, want ErrNoImage", err)
+	}
+
+	err = client.DownloadAlbumArt(context.Background(), &genius.Album{CoverArtURL: server.URL + "/missing.1000x1000x1.png"}, genius.ImageMedium, &b)
+	var statusErr *genius.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Errorf("got %v, want a 404", err)
+	}
+	if b.Len() != 0 {
+		t.Errorf("got %q written for failed downloads", b.String())
+	}
+}
+
+func TestSaveArtistImages(t *testing.T) {
+	server := newImageServer(t)
+	client := genius.NewClient(nil, "token")
+	dir := t.TempDir()
+
+	artist := &genius.Artist{ImageURL: server.URL + "/song.1000x1000x1.jpg", HeaderImageURL: server.URL + "/artist.1000x800x1.jpg"}
+	paths, err := client.SaveArtistImages(context.Background(), artist, genius.ImageThumbnail, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(dir, "avatar.jpg"), filepath.Join(dir, "header.jpg")}
+	if !slices.Equal(paths, want) {
+		t.Fatalf("got paths %v, want %v", paths, want)
+	}
+	for path, image := range map[string]string{want[0]: "song thumbnail", want[1]: "artist thumbnail"} {
+		if data, err := os.ReadFile(path); err != nil || string(data) != image {
+			t.Errorf("got %s %q, %v, want %q", path, data, err, image)
+		}
+	}
+
+	artist.HeaderImageURL = server.URL + "/missing.png"
+	if _, err = client.SaveArtistImages(context.Background(), artist, genius.ImageFull, dir); err == nil {
+		t.Error("expected the missing header to fail")
+	}
+	if _, err = os.Stat(filepath.Join(dir, "header.png")); !os.IsNotExist(err) {
+		t.Errorf("expected the failed header not to be saved, got %v", err)
+	}
+}
+
+func TestAlbumCoverArt(t *testing.T) {
+	album := &genius.Album{
+		CoverArtThumbnailURL: "https://images.genius.com/c2aa0b3d.300x300x1.png",
+		CoverArtURL:          "https://images.genius.com/c2aa0b3d.1000x1000x1.png",
+	}
+	tests := []struct {
+		album *genius.Album
+		size  genius.ImageSize
+		want  string
+	}{
+		{album, genius.ImageThumbnail, "https://images.genius.com/c2aa0b3d.300x300x1.png"},
+		{album, genius.ImageMedium, "https://images.genius.com/c2aa0b3d.500x500x1.png"},
+		{album, genius.ImageFull, "https://images.genius.com/c2aa0b3d.1000x1000x1.png"},
+		{album, "huge", ""},
+		{&genius.Album{CoverArtURL: "https://images.genius.com/c2aa0b3d.1000x750x1.jpg"}, genius.ImageThumbnail, "https://images.genius.com/c2aa0b3d.300x225x1.jpg"},
+		{&genius.Album{CoverArtURL: "https://images.genius.com/c2aa0b3d.400x400x1.jpg"}, genius.ImageMedium, "https://images.genius.com/c2aa0b3d.400x400x1.jpg"},
+		{&genius.Album{CoverArtURL: "https://assets.genius.com/images/default_cover_image.png"}, genius.ImageMedium, "https://assets.genius.com/images/default_cover_image.png"},
+		{&genius.Album{}, genius.ImageFull, ""},
+	}
+	for _, test := range tests {
+		if got := test.album.CoverArt(test.size); got != test.want {
+			t.Errorf("%s of %s: got %q, want %q", test.size, test.album.CoverArtURL, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,347 @@
+package genius
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	defaultPerPage       = 50
+	defaultSearchPerPage = 20
+)
+
+// ListOptions configure paginated fetchers.
+type ListOptions struct {
+	// PerPage is the number of items requested per page, the endpoint default is used when 0.
+	PerPage int
+	// MaxItems caps the number of items returned, all items are returned when 0.
+	MaxItems int
+	// StartPage is the first page fetched, pages are counted from 1.
+	StartPage int
+	// Concurrency is the number of pages fetched in parallel once the first page showed there are more.
+	// Pages are still returned in order. The client's concurrency, see WithConcurrency, is used when 0.
+	Concurrency int
+}
+
+// ArtistSongsOptions configure fetching an artist's songs.
+type ArtistSongsOptions struct {
+	ListOptions
+
+	// Sort is the order songs are returned in, SortTitle when empty.
+	Sort Sort
+	// MinPageviews skips songs with fewer page views, such as the stub pages of the long tail of prolific artists.
+	// Genius omits the page views of rarely viewed songs, those are skipped as well. Sorted by SortPopularity,
+	// fetching stops after a page's worth of consecutive songs below the threshold.
+	MinPageviews int
+	// From and To keep only the songs released in the range, both inclusive, no bound is set when zero. Release
+	// dates are compared as midnight UTC, see Song.Released, songs without one are skipped. Songs are sorted by
+	// SortReleaseDate if Sort is empty, and fetching stops once songs are listed past the range in that order.
+	From time.Time
+	To   time.Time
+	// Languages keeps only the songs in one of the languages, e.g. "en", ignoring case. This drops the translations
+	// of the translation community listed with non-English artists. Songs Genius lists without a language are
+	// skipped.
+	Languages []string
+}
+
+// sort returns the order songs are listed in.
+func (o *ArtistSongsOptions) sort() Sort {
+	if o == nil {
+		return ""
+	}
+	if o.Sort == "" && (!o.From.IsZero() || !o.To.IsZero()) {
+		return SortReleaseDate
+	}
+	return o.Sort
+}
+
+// ReferentsOptions configure fetching the referents of a song.
+type ReferentsOptions struct {
+	ListOptions
+
+	// VerifiedOnly keeps only the annotations verified by the artist or a verified contributor, dropping referents
+	// without any.
+	VerifiedOnly bool
+	// States keeps only the annotations in one of the states, dropping referents without any. Annotations in any
+	// state are kept when empty.
+	States []AnnotationState
+}
+
+// Sort is the order of an artist's songs.
+type Sort string
+
+const (
+	SortTitle       Sort = "title"
+	SortPopularity  Sort = "popularity"
+	SortReleaseDate Sort = "release_date"
+)
+
+// ErrInvalidSort is returned for Sort values the API doesn't support.
+var ErrInvalidSort = errors.New("unsupported sort")
+
+// validate reports unsupported values, the API silently falls back to sorting by title for those.
+func (s Sort) validate() error {
+	switch s {
+	case "", SortTitle, SortPopularity, SortReleaseDate:
+		return nil
+	default:
+		return fmt.Errorf("%w %q: use SortTitle, SortPopularity or SortReleaseDate", ErrInvalidSort, string(s))
+	}
+}
+
+func (o *ListOptions) perPage(def int) int {
+	if o == nil || o.PerPage <= 0 {
+		return def
+	}
+	return o.PerPage
+}
+
+func (o *ListOptions) maxItems() int {
+	if o == nil {
+		return 0
+	}
+	return o.MaxItems
+}
+
+func (o *ListOptions) concurrency() int {
+	if o == nil || o.Concurrency < 1 {
+		return 1
+	}
+	return o.Concurrency
+}
+
+func (o *ListOptions) startPage() int {
+	if o == nil || o.StartPage < 1 {
+		return 1
+	}
+	return o.StartPage
+}
+
+// pageFetcher fetches a single page.
+type pageFetcher[T any] func(ctx context.Context, page int, perPage int) (*Page[T], error)
+
+// paginate lazily walks the pages returned by fetch, stopping on the last page, an empty page, an error, after
+// opts.MaxItems items or when the consumer stops iterating. defaultPerPage is used if opts don't set a page size.
+//
+// After the first page, up to opts.Concurrency following pages are fetched in parallel. Pages past the last one
+// in such a batch are discarded.
+func paginate[T any](ctx context.Context, opts *ListOptions, defaultPerPage int, fetch pageFetcher[T]) iter.Seq2[T, error] {
+	start := position{page: opts.startPage()}
+
+	return func(yield func(T, error) bool) {
+		for item, err := range walkPages(ctx, opts, defaultPerPage, start, fetch) {
+			if !yield(item.value, err) {
+				return
+			}
+		}
+	}
+}
+
+// position is a point in a paginated list: skip items into page, after fetched items were returned.
+type position struct {
+	page    int
+	skip    int
+	fetched int
+}
+
+// pageItem is an item of a paginated list together with the position following it.
+type pageItem[T any] struct {
+	value T
+	next  position
+}
+
+// walkPages implements paginate, additionally reporting the position after each item and starting at an arbitrary
+// position. MaxItems counts the items fetched before start.
+func walkPages[T any](ctx context.Context, opts *ListOptions, defaultPerPage int, start position, fetch pageFetcher[T]) iter.Seq2[pageItem[T], error] {
+	perPage := opts.perPage(defaultPerPage)
+	maxItems := opts.maxItems()
+	concurrency := opts.concurrency()
+
+	return func(yield func(pageItem[T], error) bool) {
+		fetched := start.fetched
+		skip := start.skip
+		page := start.page
+		batch := 1
+		for page > 0 {
+			if maxItems > 0 && fetched >= maxItems {
+				return
+			}
+
+			pages, err := fetchPages(ctx, page, batch, perPage, fetch)
+			for i, p := range pages {
+				current := page + i
+				for index := skip; index < len(p.Items); index++ {
+					fetched++
+
+					next := position{page: current, skip: index + 1, fetched: fetched}
+					if index+1 == len(p.Items) {
+						next = position{page: p.NextPage, fetched: fetched}
+					}
+
+					if !yield(pageItem[T]{value: p.Items[index], next: next}, nil) {
+						return
+					}
+					if maxItems > 0 && fetched >= maxItems {
+						return
+					}
+				}
+				skip = 0
+
+				if len(p.Items) == 0 {
+					return
+				}
+			}
+
+			if len(pages) > 0 {
+				page = pages[len(pages)-1].NextPage
+			}
+			if err != nil {
+				yield(pageItem[T]{}, err)
+				return
+			}
+			batch = concurrency
+		}
+	}
+}
+
+// fetchPages concurrently fetches count consecutive pages starting at first. The returned pages are in order and end
+// with the first page that is the last one, or before the first page that failed, whose error is returned.
+func fetchPages[T any](ctx context.Context, first int, count int, perPage int, fetch pageFetcher[T]) ([]*Page[T], error) {
+	if count == 1 {
+		p, err := fetch(ctx, first, perPage)
+		if err != nil {
+			return nil, err
+		}
+		return []*Page[T]{p}, nil
+	}
+
+	pages := make([]*Page[T], count)
+	errs := make([]error, count)
+
+	// Errors are collected per page rather than through the group, as a failing page must not cancel the pages
+	// before it.
+	var g errgroup.Group
+	for i := range count {
+		g.Go(func() error {
+			pages[i], errs[i] = fetch(ctx, first+i, perPage)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	for i := range count {
+		if errs[i] != nil {
+			return pages[:i], errs[i]
+		}
+		if pages[i].NextPage == 0 || len(pages[i].Items) == 0 {
+			return pages[:i+1], nil
+		}
+	}
+
+	return pages, nil
+}
+
+// nextPageBySize guesses the next page for endpoints that don't report next_page: a full page means there might be
+// more.
+func nextPageBySize(page int, perPage int, count int) int {
+	if count < perPage {
+		return 0
+	}
+	return page + 1
+}
+
+// ArtistSongs lazily iterates over the songs of an artist, fetching pages as they are consumed.
+// Iteration stops at the first error, which is yielded with a nil song. Songs filtered by opts don't count against
+// MaxItems.
+func (c *Client) ArtistSongs(ctx context.Context, id int, opts *ArtistSongsOptions) iter.Seq2[*Song, error] {
+	sort := opts.sort()
+	var listOpts *ListOptions
+	if opts != nil {
+		listOpts = &opts.ListOptions
+	}
+
+	if err := sort.validate(); err != nil {
+		return func(yield func(*Song, error) bool) {
+			yield(nil, err)
+		}
+	}
+
+	songs := func(listOpts *ListOptions) iter.Seq2[*Song, error] {
+		return paginate(ctx, c.listOptions(listOpts), defaultPerPage, func(ctx context.Context, page int, perPage int) (*Page[*Song], error) {
+			return c.getArtistSongsPage(ctx, id, sort, perPage, page)
+		})
+	}
+	if opts.filtered() {
+		return filterSongs(songs, opts)
+	}
+	return songs(listOpts)
+}
+
+// ArtistAlbums lazily iterates over the albums of an artist.
+func (c *Client) ArtistAlbums(ctx context.Context, id int, opts *ListOptions) iter.Seq2[*Album, error] {
+	return paginate(ctx, c.listOptions(opts), defaultPerPage, func(ctx context.Context, page int, perPage int) (*Page[*Album], error) {
+		return c.getArtistAlbumsPage(ctx, id, perPage, page)
+	})
+}
+
+// AlbumTracks lazily iterates over the tracks of an album.
+func (c *Client) AlbumTracks(ctx context.Context, id int, opts *ListOptions) iter.Seq2[*AlbumTrack, error] {
+	return paginate(ctx, c.listOptions(opts), defaultPerPage, func(ctx context.Context, page int, perPage int) (*Page[*AlbumTrack], error) {
+		return c.getAlbumTracksPage(ctx, id, perPage, page)
+	})
+}
+
+// Referents lazily iterates over the referents, annotated fragments, of a song, filtered according to opts which may
+// be nil. The bodies of their annotations are plain text.
+func (c *Client) Referents(ctx context.Context, songID int, opts *ReferentsOptions) iter.Seq2[*Referent, error] {
+	return c.referents(ctx, songID, FormatPlain, opts)
+}
+
+func (c *Client) referents(ctx context.Context, songID int, textFormat TextFormat, opts *ReferentsOptions) iter.Seq2[*Referent, error] {
+	var o ReferentsOptions
+	if opts != nil {
+		o = *opts
+	}
+	if !o.filtered() {
+		return paginate(ctx, c.listOptions(&o.ListOptions), defaultPerPage, func(ctx context.Context, page int, perPage int) (*Page[*Referent], error) {
+			return c.getReferentsPage(ctx, songID, textFormat, perPage, page)
+		})
+	}
+
+	// Filtered referents are counted against MaxItems after filtering, as pages may be filtered empty.
+	maxItems := o.MaxItems
+	o.MaxItems = 0
+	referents := paginate(ctx, c.listOptions(&o.ListOptions), defaultPerPage, func(ctx context.Context, page int, perPage int) (*Page[*Referent], error) {
+		return c.getReferentsPage(ctx, songID, textFormat, perPage, page)
+	})
+	return func(yield func(*Referent, error) bool) {
+		count := 0
+		for referent, err := range referents {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !o.keep(referent) {
+				continue
+			}
+			if !yield(referent, nil) {
+				return
+			}
+			if count++; maxItems > 0 && count >= maxItems {
+				return
+			}
+		}
+	}
+}
+
+// SearchHits lazily iterates over all search hits for q.
+func (c *Client) SearchHits(ctx context.Context, q string, opts *ListOptions) iter.Seq2[*Hit, error] {
+	return paginate(ctx, c.listOptions(opts), defaultSearchPerPage, func(ctx context.Context, page int, perPage int) (*Page[*Hit], error) {
+		return c.searchPage(ctx, q, perPage, page)
+	})
+}
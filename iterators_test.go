@@ -0,0 +1,188 @@
+package genius_test
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+// newListServer serves count albums of artist 1, tracks of album 1 and song hits of any search, counting the
+// requests. Search pages don't report next_page, like Genius's.
+func newListServer(t *testing.T, count int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+
+		items := []map[string]any{}
+		for id := (page-1)*perPage + 1; id <= page*perPage && id <= count; id++ {
+			switch r.URL.Path {
+			case "/artists/1/albums":
+				items = append(items, map[string]any{"id": id})
+			case "/albums/1/tracks":
+				items = append(items, map[string]any{"number": id, "song": map[string]any{"id": id}})
+			case "/search":
+				items = append(items, map[string]any{"index": "song", "type": "song", "result": map[string]any{"id": id}})
+			}
+		}
+
+		var next any
+		if page*perPage < count {
+			next = page + 1
+		}
+		response := map[string]any{"next_page": next}
+		switch r.URL.Path {
+		case "/artists/1/albums":
+			response["albums"] = items
+		case "/albums/1/tracks":
+			response["tracks"] = items
+		case "/search":
+			response = map[string]any{"hits": items}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"meta": map[string]any{"status": 200}, "response": response})
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestIterators(t *testing.T) {
+	opts := &genius.ListOptions{PerPage: 10}
+	tests := []struct {
+		name     string
+		ids      func(client *genius.Client) ([]int, error)
+		count    int
+		requests int32
+	}{
+		{"ArtistAlbums", func(client *genius.Client) ([]int, error) {
+			albums := client.ArtistAlbums(context.Background(), 1, opts)
+			return collectIDs(albums, func(album *genius.Album) int { return album.ID })
+		}, 25, 3},
+		{"AlbumTracks", func(client *genius.Client) ([]int, error) {
+			tracks := client.AlbumTracks(context.Background(), 1, opts)
+			return collectIDs(tracks, func(track *genius.AlbumTrack) int { return track.Song.ID })
+		}, 25, 3},
+		{"SearchHits", searchHitIDs, 25, 3},
+		// A full last page of hits is followed by an empty one, as the search doesn't report the last page.
+		{"SearchHits full last page", searchHitIDs, 20, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := newListServer(t, tt.count)
+			client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL), genius.WithUnofficialURL(server.URL))
+
+			ids, err := tt.ids(client)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ids) != tt.count {
+				t.Fatalf("got %d items, want %d", len(ids), tt.count)
+			}
+			for i, id := range ids {
+				if id != i+1 {
+					t.Fatalf("item %d has ID %d, want %d", i, id, i+1)
+				}
+			}
+			if n := requests.Load(); n != tt.requests {
+				t.Errorf("got %d requests, want %d", n, tt.requests)
+			}
+		})
+	}
+}
+
+func TestIteratorsFetchLazily(t *testing.T) {
+	server, requests := newListServer(t, 100)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL), genius.WithUnofficialURL(server.URL))
+
+	for album, err := range client.ArtistAlbums(context.Background(), 1, &genius.ListOptions{PerPage: 10}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if album.ID == 15 {
+			break
+		}
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("got %d requests for the first 15 albums, want 2", n)
+	}
+}
+
+func searchHitIDs(client *genius.Client) ([]int, error) {
+	hits := client.SearchHits(context.Background(), "humble", &genius.ListOptions{PerPage: 10})
+	return collectIDs(hits, func(hit *genius.Hit) int {
+		song, err := hit.AsSong()
+		if err != nil {
+			return 0
+		}
+		return song.ID
+	})
+}
+
+// collectIDs returns the IDs of items, up to the first error.
+func collectIDs[T any](items iter.Seq2[T, error], id func(T) int) ([]int, error) {
+	var ids []int
+	for item, err := range items {
+		if err != nil {
+			return ids, err
+		}
+		ids = append(ids, id(item))
+	}
+	return ids, nil
+}
+
+func TestListOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     *genius.ListOptions
+		first    int
+		count    int
+		requests int32
+	}{
+		{"nil", nil, 1, 25, 1},
+		{"PerPage", &genius.ListOptions{PerPage: 10}, 1, 25, 3},
+		{"MaxItems", &genius.ListOptions{PerPage: 10, MaxItems: 12}, 1, 12, 2},
+		{"StartPage", &genius.ListOptions{PerPage: 10, StartPage: 2}, 11, 15, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := newListServer(t, 25)
+			client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL), genius.WithUnofficialURL(server.URL))
+
+			albums, err := client.GetArtistAlbums(context.Background(), 1, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tracks, err := client.GetAlbumTracks(context.Background(), 1, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(albums) != tt.count || len(tracks) != tt.count {
+				t.Fatalf("got %d albums and %d tracks, want %d", len(albums), len(tracks), tt.count)
+			}
+			for i := range albums {
+				if want := tt.first + i; albums[i].ID != want || tracks[i].Song.ID != want {
+					t.Fatalf("album %d and track %d at %d, want %d", albums[i].ID, tracks[i].Song.ID, i, want)
+				}
+			}
+			if n := requests.Load(); n != 2*tt.requests {
+				t.Errorf("got %d requests, want %d for each list", n, tt.requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package genius
+
+import "net/http"
+
+// WithAcceptLanguage sends lang, an Accept-Language value such as "en-US" or "de-DE, en;q=0.8", with API requests
+// and lyrics page requests. genius.com localizes parts of its pages and search results to the language of the
+// request, which otherwise depends on the HTTP client, so scrapers set it for deterministic output.
+func WithAcceptLanguage(lang string) ClientOption {
+	return func(client *Client) {
+		client.acceptLanguage = lang
+	}
+}
+
+// setAcceptLanguage sets the Accept-Language header of req to the language set with WithAcceptLanguage, if any.
+func (c *Client) setAcceptLanguage(req *http.Request) {
+	if c.acceptLanguage != "" {
+		req.Header.Set("Accept-Language", c.acceptLanguage)
+	}
+}
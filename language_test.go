@@ -0,0 +1,53 @@
+package genius_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+func TestAcceptLanguage(t *testing.T) {
+	page, err := os.ReadFile("testdata/extractor/verses.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var languages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		languages = append(languages, r.Header.Get("Accept-Language"))
+
+		if r.URL.Path == "/lyrics" {
+			_, _ = w.Write(page)
+			return
+		}
+		_, _ = w.Write([]byte(`{"meta":{"status":200},"response":{"song":{"id":1,"title":"HUMBLE."}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL), genius.WithAcceptLanguage("de-DE"))
+	if _, err := client.GetSong(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetLyrics(context.Background(), server.URL+"/lyrics"); err != nil {
+		t.Fatal(err)
+	}
+
+	client = genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+	if _, err := client.GetSong(context.Background(), 2); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"de-DE", "de-DE", ""}
+	if len(languages) != len(want) {
+		t.Fatalf("got %d requests, want %d", len(languages), len(want))
+	}
+	for i := range want {
+		if languages[i] != want[i] {
+			t.Errorf("request %d: Accept-Language %q, want %q", i, languages[i], want[i])
+		}
+	}
+}
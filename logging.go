@@ -0,0 +1,75 @@
+package genius
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Logger receives structured logs of the client's requests, *slog.Logger satisfies it.
+type Logger interface {
+	LogAttrs(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr)
+}
+
+// redacted replaces credentials in logs.
+const redacted = "REDACTED"
+
+// WithLogger logs every request to logger once it is done, with its method, URL, request headers, final status,
+// duration, number of retries and correlation ID if it has one. Failed requests are logged as warnings, others as info.
+//
+// The Authorization header and access_token query parameters are always redacted.
+func WithLogger(logger Logger) ClientOption {
+	return func(client *Client) {
+		client.logger = logger
+	}
+}
+
+func (c *Client) logRequest(req *http.Request, status int, retries int, duration time.Duration, err error) {
+	if c.logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", redactURL(req.URL)),
+		slog.Any("headers", redactHeaders(req.Header)),
+		slog.Int("status", status),
+		slog.Duration("duration", duration),
+		slog.Int("retries", retries),
+	}
+	if id := req.Header.Get(CorrelationIDHeader); id != "" {
+		attrs = append(attrs, slog.String("correlation_id", id))
+	}
+
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelWarn
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	c.logger.LogAttrs(req.Context(), level, "genius request", attrs...)
+}
+
+func redactURL(u *url.URL) string {
+	query := u.Query()
+	if !query.Has("access_token") {
+		return u.String()
+	}
+
+	query.Set("access_token", redacted)
+	redactedURL := *u
+	redactedURL.RawQuery = query.Encode()
+	return redactedURL.String()
+}
+
+func redactHeaders(header http.Header) http.Header {
+	headers := header.Clone()
+	for _, name := range []string{"Authorization", "Cookie", "Proxy-Authorization"} {
+		if headers.Get(name) != "" {
+			headers.Set(name, redacted)
+		}
+	}
+	return headers
+}
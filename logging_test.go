@@ -0,0 +1,64 @@
+package genius_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+func TestLoggerRedactsToken(t *testing.T) {
+	server := newFlakyServer(t, http.StatusInternalServerError, 1)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	client := genius.NewClient(nil, "secret-token", genius.WithBaseURL(server.URL), genius.WithLogger(logger))
+
+	if _, err := client.GetSong(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(buf.String(), "secret-token") {
+		t.Fatalf("token logged: %s", buf.String())
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatal(err)
+	}
+	if entry["method"] != "GET" || entry["status"] != 200.0 || entry["retries"] != 1.0 {
+		t.Errorf("unexpected log entry %v", entry)
+	}
+}
+
+func TestLoggerFailedRequestStatus(t *testing.T) {
+	for _, status := range []int{http.StatusNotFound, http.StatusInternalServerError} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(status)
+		}))
+		t.Cleanup(server.Close)
+
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewJSONHandler(&buf, nil))
+		client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL), genius.WithLogger(logger))
+
+		if _, err := client.GetSong(context.Background(), 1); err == nil {
+			t.Fatalf("status %d: expected an error", status)
+		}
+
+		var entry map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatal(err)
+		}
+		if entry["status"] != float64(status) || entry["level"] != "WARN" {
+			t.Errorf("status %d: unexpected log entry %v", status, entry)
+		}
+	}
+}
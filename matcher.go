@@ -0,0 +1,244 @@
+package genius
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ErrNoMatch is returned by MatchTrack and GetAlbumByName when no search hit is close enough to the requested track
+// or album.
+var ErrNoMatch = errors.New("no matching song or album found")
+
+// minMatchScore is the lowest combined title/artist score MatchTrack and GetAlbumByName accept.
+const minMatchScore = 0.75
+
+const (
+	// durationTolerance is how much the length of a song may differ from the track's before MatchTrack penalizes it,
+	// as streaming services report lengths of the same recording a few seconds apart.
+	durationTolerance = 10 * time.Second
+	// durationPenalty is subtracted from the score of songs whose length is off by more than durationTolerance. It
+	// prefers the right version of a song over others, e.g. radio edits, but doesn't rule them out.
+	durationPenalty = 0.2
+)
+
+var (
+	featuringPattern = regexp.MustCompile(`(?i)[\(\[]\s*(feat\.?|ft\.?|featuring|with)\s+[^\)\]]*[\)\]]|\s+(feat\.?|ft\.?|featuring)\s+.*$`)
+	suffixPattern    = regexp.MustCompile(`(?i)\s+-\s+.*\b(remaster(ed)?|version|mono|stereo|deluxe|edit|mix(ed)?)\b.*$`)
+	bracketPattern   = regexp.MustCompile(`(?i)[\(\[][^\)\]]*\b(remaster(ed)?|version|mono|stereo|deluxe|edit)\b[^\)\]]*[\)\]]`)
+	apostrophes      = strings.NewReplacer("'", "", "’", "", "‘", "", "`", "", "´", "")
+)
+
+// NormalizeTitle reduces a song title to a comparable form: lower case, without featured artist credits,
+// remaster/version suffixes, apostrophes and punctuation.
+//
+// For example "Don’t Stop Me Now - Remastered 2011" and "Don't Stop Me Now" both normalize to "dont stop me now".
+func NormalizeTitle(title string) string {
+	title = featuringPattern.ReplaceAllString(title, "")
+	title = suffixPattern.ReplaceAllString(title, "")
+	title = bracketPattern.ReplaceAllString(title, "")
+	return normalize(title)
+}
+
+// NormalizeArtist reduces an artist name to a comparable form, see NormalizeTitle.
+func NormalizeArtist(artist string) string {
+	artist = featuringPattern.ReplaceAllString(artist, "")
+	return normalize(artist)
+}
+
+func normalize(s string) string {
+	s = apostrophes.Replace(strings.ToLower(s))
+	s = strings.ReplaceAll(s, "&", " and ")
+
+	var b strings.Builder
+	space := true
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			b.WriteRune(r)
+			space = false
+			continue
+		}
+		if !space {
+			b.WriteRune(' ')
+			space = true
+		}
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+// MatchTrack finds the Genius song matching track metadata from a streaming service such as Spotify or Apple Music.
+//
+// Title and artist are matched after normalization, so differences in "feat." formatting, apostrophes and remaster
+// suffixes don't prevent a match. Multiple artists may be passed comma separated, the first is treated as primary.
+// If durationMS is positive, songs whose DurationMS is known and differs by more than a few seconds score lower, so
+// that songs with the same title and artist are told apart by their length.
+//
+// The candidates of CandidateQueries are searched in order until one has a match. ErrNoMatch is returned if no
+// candidate scores high enough.
+func (c *Client) MatchTrack(ctx context.Context, title string, artist string, durationMS int) (*Song, error) {
+	primary := primaryArtist(artist)
+	for _, q := range CandidateQueries(title, artist) {
+		response, err := c.Search(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+
+		var best *Song
+		bestScore := 0.0
+		for _, hit := range response.Response.Hits {
+			if hit == nil || hit.Type != HitTypeSong {
+				continue
+			}
+			song, err := hit.AsSong()
+			if err != nil {
+				return nil, err
+			}
+			if score := matchScore(song, title, primary, durationMS); score > bestScore {
+				best, bestScore = song, score
+			}
+		}
+
+		if bestScore >= minMatchScore {
+			return best, nil
+		}
+	}
+
+	return nil, ErrNoMatch
+}
+
+// GetAlbumByName returns the album titled albumTitle by artist with its tracks, see GetAlbum, for callers that don't
+// know the album's ID.
+//
+// The album is looked up in the album hits of a search for both, matched like MatchTrack matches songs. If the search
+// has no match, the album page genius.com would have for the names, such as /albums/Kendrick-lamar/Damn, is tried.
+// ErrNoMatch is returned if neither finds the album.
+func (c *Client) GetAlbumByName(ctx context.Context, artist string, albumTitle string, opts ...RequestOption) (*Album, error) {
+	response, err := c.WebSearch(ctx, 10, NormalizeTitle(albumTitle)+" "+NormalizeArtist(artist))
+	if err != nil {
+		return nil, err
+	}
+
+	var best *Album
+	bestScore := 0.0
+	for _, section := range response.Response.Sections {
+		if section.Type != HitTypeAlbum {
+			continue
+		}
+		for _, hit := range section.Hits {
+			album, err := hit.AsAlbum()
+			if err != nil {
+				return nil, err
+			}
+			if score := albumMatchScore(album, albumTitle, artist); score > bestScore {
+				best, bestScore = album, score
+			}
+		}
+	}
+
+	if bestScore < minMatchScore {
+		best, err = c.GetAlbumByPath(ctx, "/albums/"+pageSlug(artist)+"/"+pageSlug(albumTitle))
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("%w: %s by %s", ErrNoMatch, albumTitle, artist)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c.GetAlbum(ctx, best.ID, true, opts...)
+}
+
+// albumMatchScore rates how well album matches title and artist, from 0 to 1, see matchScore.
+func albumMatchScore(album *Album, title string, artist string) float64 {
+	var albumArtist string
+	if album.Artist != nil {
+		albumArtist = album.Artist.Name
+	}
+
+	titleScore := similarity(NormalizeTitle(album.Name), NormalizeTitle(title))
+	artistScore := similarity(NormalizeArtist(albumArtist), NormalizeArtist(artist))
+	return titleScore*0.6 + artistScore*0.4
+}
+
+// pageSlug returns the slug genius.com uses for name in page paths, e.g. Kendrick-lamar for Kendrick Lamar.
+func pageSlug(name string) string {
+	words := strings.FieldsFunc(apostrophes.Replace(strings.ToLower(name)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	slug := []rune(strings.Join(words, "-"))
+	if len(slug) > 0 {
+		slug[0] = unicode.ToUpper(slug[0])
+	}
+	return string(slug)
+}
+
+// matchScore rates how well song matches title, artist and, if positive, durationMS, from 0 to 1.
+func matchScore(song *Song, title string, artist string, durationMS int) float64 {
+	var songArtist string
+	if song.PrimaryArtist != nil {
+		songArtist = song.PrimaryArtist.Name
+	}
+
+	if isTranslationArtist(songArtist) {
+		return 0
+	}
+
+	titleScore := similarity(NormalizeTitle(song.Title), NormalizeTitle(title))
+
+	wantArtist := NormalizeArtist(artist)
+	artistScore := similarity(NormalizeArtist(songArtist), wantArtist)
+	if names := normalize(song.ArtistNames); artistScore < 1 && wantArtist != "" && containsWords(names, wantArtist) {
+		artistScore = 1
+	}
+
+	score := titleScore*0.6 + artistScore*0.4
+	if durationMS > 0 && song.DurationMS > 0 {
+		if diff := time.Duration(song.DurationMS-durationMS) * time.Millisecond; diff.Abs() > durationTolerance {
+			score -= durationPenalty
+		}
+	}
+	return score
+}
+
+// isTranslationArtist reports whether artist is one of the pseudo artists Genius translation pages are attributed to,
+// such as "Genius English Translations".
+func isTranslationArtist(artist string) bool {
+	return strings.HasPrefix(artist, "Genius ") && strings.Contains(artist, "Translation")
+}
+
+// similarity compares two normalized strings, returning 1 for equal strings and the word overlap ratio otherwise.
+func similarity(a string, b string) float64 {
+	if a == b {
+		return 1
+	}
+	if a == "" || b == "" {
+		return 0
+	}
+
+	aWords := strings.Fields(a)
+	bWords := make(map[string]bool)
+	for _, w := range strings.Fields(b) {
+		bWords[w] = true
+	}
+
+	common := 0
+	for _, w := range aWords {
+		if bWords[w] {
+			common++
+			delete(bWords, w)
+		}
+	}
+
+	return float64(2*common) / float64(len(aWords)+len(strings.Fields(b)))
+}
+
+func containsWords(s string, words string) bool {
+	return strings.Contains(" "+s+" ", " "+words+" ")
+}
@@ -0,0 +1,208 @@
+package genius_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+func TestNormalizeTitle(t *testing.T) {
+	tests := map[string]string{
+		"Don’t Stop Me Now - Remastered 2011":   "dont stop me now",
+		"Don't Stop Me Now":                     "dont stop me now",
+		"Stay (feat. Justin Bieber)":            "stay",
+		"Stay [ft. Justin Bieber]":              "stay",
+		"Stay feat. Justin Bieber":              "stay",
+		"Bohemian Rhapsody - 2011 Remaster":     "bohemian rhapsody",
+		"Heroes (2017 Remastered Version)":      "heroes",
+		"Rock & Roll":                           "rock and roll",
+		"HUMBLE.":                               "humble",
+		"Something - Mono Version":              "something",
+		"Where Is My Mind? - Remastered":        "where is my mind",
+		"Bad Guy":                               "bad guy",
+		"Blinding Lights (with Rosalía) - Edit": "blinding lights",
+	}
+
+	for in, want := range tests {
+		if got := genius.NormalizeTitle(in); got != want {
+			t.Errorf("NormalizeTitle(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestNormalizeArtist(t *testing.T) {
+	tests := map[string]string{
+		"Simon & Garfunkel":            "simon and garfunkel",
+		"Guns N' Roses":                "guns n roses",
+		"Kendrick Lamar feat. Rihanna": "kendrick lamar",
+		"AC/DC":                        "ac dc",
+	}
+
+	for in, want := range tests {
+		if got := genius.NormalizeArtist(in); got != want {
+			t.Errorf("NormalizeArtist(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func newSearchServer(t *testing.T, hits []map[string]any) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search" {
+			http.NotFound(w, r)
+			return
+		}
+
+		var wrapped []map[string]any
+		for _, hit := range hits {
+			wrapped = append(wrapped, map[string]any{"index": "song", "type": "song", "result": hit})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"meta":     map[string]any{"status": 200},
+			"response": map[string]any{"hits": wrapped},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func song(id int, title string, artist string, artistNames string) map[string]any {
+	return map[string]any{
+		"id":             id,
+		"title":          title,
+		"artist_names":   artistNames,
+		"primary_artist": map[string]any{"name": artist},
+	}
+}
+
+func TestMatchTrack(t *testing.T) {
+	hits := []map[string]any{
+		song(1, "Don’t Stop Me Now (Live)", "Queen", "Queen"),
+		song(2, "Don’t Stop Me Now", "Genius English Translations", "Genius English Translations"),
+		song(3, "Don’t Stop Me Now", "Queen", "Queen"),
+		song(4, "Stay", "The Kid LAROI & Justin Bieber", "The Kid LAROI & Justin Bieber"),
+		song(5, "HUMBLE.", "Kendrick Lamar", "Kendrick Lamar"),
+	}
+	server := newSearchServer(t, hits)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	tests := []struct {
+		title  string
+		artist string
+		want   int
+	}{
+		{"Don't Stop Me Now - Remastered 2011", "Queen", 3},
+		{"STAY (with Justin Bieber)", "The Kid LAROI, Justin Bieber", 4},
+		{"HUMBLE.", "Kendrick Lamar", 5},
+	}
+
+	for _, tt := range tests {
+		got, err := client.MatchTrack(context.Background(), tt.title, tt.artist, 180000)
+		if err != nil {
+			t.Fatalf("MatchTrack(%q, %q) failed: %v", tt.title, tt.artist, err)
+		}
+		if got.ID != tt.want {
+			t.Errorf("MatchTrack(%q, %q) = song %d, want %d", tt.title, tt.artist, got.ID, tt.want)
+		}
+	}
+}
+
+func TestMatchTrackDuration(t *testing.T) {
+	radioEdit := song(1, "Bohemian Rhapsody", "Queen", "Queen")
+	radioEdit["duration_ms"] = 180000
+	album := song(2, "Bohemian Rhapsody", "Queen", "Queen")
+	album["duration_ms"] = 354000
+	server := newSearchServer(t, []map[string]any{radioEdit, album})
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	tests := []struct {
+		durationMS int
+		want       int
+	}{
+		{0, 1},
+		{355320, 2},
+		{181000, 1},
+	}
+
+	for _, tt := range tests {
+		got, err := client.MatchTrack(context.Background(), "Bohemian Rhapsody", "Queen", tt.durationMS)
+		if err != nil {
+			t.Fatalf("MatchTrack(%d) failed: %v", tt.durationMS, err)
+		}
+		if got.ID != tt.want {
+			t.Errorf("MatchTrack(%d) = song %d, want %d", tt.durationMS, got.ID, tt.want)
+		}
+	}
+}
+
+func TestMatchTrackNoMatch(t *testing.T) {
+	server := newSearchServer(t, []map[string]any{song(1, "Something Else", "Nobody", "Nobody")})
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	_, err := client.MatchTrack(context.Background(), "Bohemian Rhapsody", "Queen", 0)
+	if !errors.Is(err, genius.ErrNoMatch) {
+		t.Fatalf("expected ErrNoMatch, got %v", err)
+	}
+}
+
+func TestGetAlbumByName(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/search/multi", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"meta": {"status": 200}, "response": {"sections": [
+			{"type": "song", "hits": [{"type": "song", "result": {"id": 1, "title": "DNA."}}]},
+			{"type": "album", "hits": [
+				{"type": "album", "result": {"id": 100, "name": "DAMN. COLLECTORS EDITION.", "artist": {"name": "Kendrick Lamar"}}},
+				{"type": "album", "result": {"id": 101, "name": "DAMN.", "artist": {"name": "Kendrick Lamar"}}}
+			]}
+		]}}`))
+	})
+	mux.HandleFunc("/page_data/album", func(w http.ResponseWriter, r *http.Request) {
+		if path := r.URL.Query().Get("page_path"); path != "/albums/Sza/Sos" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"meta": {"status": 200}, "response": {"page_data": {"album": {"id": 102, "name": "SOS"}}}}`))
+	})
+	mux.HandleFunc("/albums/{id}", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"meta": {"status": 200}, "response": {"album": {"id": ` + r.PathValue("id") + `}}}`))
+	})
+	mux.HandleFunc("/albums/{id}/tracks", func(w http.ResponseWriter, r *http.Request) {
+		tracks := `[]`
+		if r.URL.Query().Get("page") == "1" {
+			tracks = `[{"number": 1, "song": {"id": 1, "title": "BLOOD."}}]`
+		}
+		_, _ = w.Write([]byte(`{"meta": {"status": 200}, "response": {"tracks": ` + tracks + `, "next_page": null}}`))
+	})
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL), genius.WithUnofficialURL(server.URL))
+
+	tests := []struct {
+		artist, title string
+		want          int
+	}{
+		{"Kendrick Lamar", "Damn.", 101},
+		{"SZA", "SOS", 102},
+	}
+	for _, tt := range tests {
+		album, err := client.GetAlbumByName(context.Background(), tt.artist, tt.title)
+		if err != nil {
+			t.Fatalf("GetAlbumByName(%q, %q) failed: %v", tt.artist, tt.title, err)
+		}
+		if album.ID != tt.want || len(album.Tracks) != 1 {
+			t.Errorf("GetAlbumByName(%q, %q) = album %d with %d tracks, want %d with its tracks", tt.artist, tt.title, album.ID, len(album.Tracks), tt.want)
+		}
+	}
+
+	if _, err := client.GetAlbumByName(context.Background(), "Queen", "A Night at the Opera"); !errors.Is(err, genius.ErrNoMatch) {
+		t.Errorf("expected ErrNoMatch, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package genius
+
+import (
+	"container/list"
+	"sync"
+)
+
+// defaultMemoSize is how many artists, albums and songs a client memoizes by default.
+const defaultMemoSize = 512
+
+// WithMemoSize sets how many artists, albums and songs the client memoizes, 0 turns memoization off.
+//
+// GetArtist, GetAlbum and GetSong remember their results for the lifetime of the client, as operations such as
+// crawling an artist's discography look up the same objects repeatedly. The least recently used results are dropped
+// once size is exceeded. Requests with WithRefresh replace the remembered results.
+//
+// Memoized results are returned as shallow copies: callers may set their fields, e.g. Song.Lyrics, but must not
+// modify the values they point to, such as Song.PrimaryArtist or the elements of Album.Tracks, which are shared.
+func WithMemoSize(size int) ClientOption {
+	return func(client *Client) {
+		client.memo = newLRU[memoKey, any](size)
+	}
+}
+
+// memoKey identifies a memoized lookup, endpoint is the path it requests, e.g. "/songs/:id".
+type memoKey struct {
+	endpoint   string
+	id         int
+	textFormat TextFormat
+	tracks     bool
+}
+
+// memoized returns the memoized result for key. Results are stored and returned as shallow copies, so that callers
+// setting fields, e.g. Song.Lyrics, don't change them. Pointers, slices and maps are shared, see WithMemoSize.
+func memoized[T any](c *Client, key memoKey) (*T, bool) {
+	if c.memo.size <= 0 {
+		return nil, false
+	}
+
+	value, ok := c.memo.get(key)
+	c.metrics.observeCache(key.endpoint, ok)
+	if !ok {
+		return nil, false
+	}
+
+	result := *value.(*T)
+	return &result, true
+}
+
+func memoize[T any](c *Client, key memoKey, result *T) {
+	stored := *result
+	c.memo.add(key, &stored)
+}
+
+// lru is a size bounded map dropping the least recently used entries, safe for concurrent use. An lru of size 0
+// stores nothing.
+type lru[K comparable, V any] struct {
+	mu    sync.Mutex
+	size  int
+	items map[K]*list.Element
+	order *list.List
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func newLRU[K comparable, V any](size int) *lru[K, V] {
+	return &lru[K, V]{size: size, items: make(map[K]*list.Element), order: list.New()}
+}
+
+func (l *lru[K, V]) get(key K) (V, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	element, ok := l.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	l.order.MoveToFront(element)
+	return element.Value.(*lruEntry[K, V]).value, true
+}
+
+func (l *lru[K, V]) add(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.size <= 0 {
+		return
+	}
+
+	if element, ok := l.items[key]; ok {
+		element.Value.(*lruEntry[K, V]).value = value
+		l.order.MoveToFront(element)
+		return
+	}
+
+	l.items[key] = l.order.PushFront(&lruEntry[K, V]{key: key, value: value})
+	if l.order.Len() > l.size {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.items, oldest.Value.(*lruEntry[K, V]).key)
+	}
+}
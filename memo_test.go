@@ -0,0 +1,67 @@
+package genius_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+func newCountingSongServer(t *testing.T) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		_, _ = w.Write([]byte(`{"meta":{"status":200},"response":{"song":{"id":1,"title":"HUMBLE."}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestMemoization(t *testing.T) {
+	server, requests := newCountingSongServer(t)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	song, err := client.GetSong(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	song.Lyrics = "changed by the caller"
+
+	again, err := client.GetSong(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *requests != 1 {
+		t.Errorf("got %d requests, want 1", *requests)
+	}
+	if again.Title != "HUMBLE." || again.Lyrics != "" {
+		t.Errorf("unexpected memoized song %+v", again)
+	}
+
+	if _, err = client.GetSong(context.Background(), 1, genius.WithTextFormat(genius.FormatPlain)); err != nil {
+		t.Fatal(err)
+	}
+	if *requests != 2 {
+		t.Errorf("other text format not requested, got %d requests", *requests)
+	}
+}
+
+func TestMemoizationDisabled(t *testing.T) {
+	server, requests := newCountingSongServer(t)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL), genius.WithMemoSize(0))
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetSong(context.Background(), 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if *requests != 2 {
+		t.Errorf("got %d requests, want 2", *requests)
+	}
+}
@@ -0,0 +1,117 @@
+package genius
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// metrics are the Prometheus collectors of a client, all labelled by endpoint.
+type metrics struct {
+	requests    *prometheus.CounterVec
+	duration    *prometheus.HistogramVec
+	retries     *prometheus.CounterVec
+	rateLimited *prometheus.CounterVec
+	cache       *prometheus.CounterVec
+}
+
+// WithMetrics registers Prometheus metrics of the client's requests with registerer:
+//
+//   - genius_requests_total, requests by endpoint and status code, "error" for failed requests
+//   - genius_request_duration_seconds, request latencies by endpoint
+//   - genius_retries_total, retried requests by endpoint
+//   - genius_rate_limited_total, responses by endpoint telling the client to back off
+//   - genius_cache_lookups_total, lookups of memoized results by endpoint and result, "hit" or "miss"
+//
+// Endpoints are request paths with IDs replaced by ":id", e.g. "/songs/:id". Clients sharing a registerer share
+// their metrics.
+func WithMetrics(registerer prometheus.Registerer) ClientOption {
+	return func(client *Client) {
+		client.metrics = &metrics{
+			requests: register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "genius_requests_total",
+				Help: "Requests made to Genius by endpoint and status code.",
+			}, []string{"endpoint", "code"})),
+			duration: register(registerer, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+				Name:    "genius_request_duration_seconds",
+				Help:    "Latency of requests made to Genius by endpoint.",
+				Buckets: prometheus.DefBuckets,
+			}, []string{"endpoint"})),
+			retries: register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "genius_retries_total",
+				Help: "Requests to Genius that were retried by endpoint.",
+			}, []string{"endpoint"})),
+			rateLimited: register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "genius_rate_limited_total",
+				Help: "Rate limited responses from Genius by endpoint.",
+			}, []string{"endpoint"})),
+			cache: register(registerer, prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "genius_cache_lookups_total",
+				Help: "Lookups of memoized Genius results by endpoint and result.",
+			}, []string{"endpoint", "result"})),
+		}
+	}
+}
+
+// register registers collector, returning the already registered one if an identical collector exists.
+func register[T prometheus.Collector](registerer prometheus.Registerer, collector T) T {
+	if err := registerer.Register(collector); err != nil {
+		var registered prometheus.AlreadyRegisteredError
+		if errors.As(err, &registered) {
+			if existing, ok := registered.ExistingCollector.(T); ok {
+				return existing
+			}
+		}
+		panic(err)
+	}
+	return collector
+}
+
+var idSegment = regexp.MustCompile(`/\d+(/|$)`)
+
+// endpoint returns the path of req with numeric IDs replaced, to keep the number of label values bounded.
+func endpoint(req *http.Request) string {
+	// Replacing twice handles consecutive ID segments, as matches can't overlap.
+	path := idSegment.ReplaceAllString(req.URL.Path, "/:id$1")
+	return idSegment.ReplaceAllString(path, "/:id$1")
+}
+
+func (m *metrics) observeRequest(endpoint string, resp *http.Response, err error, duration time.Duration) {
+	if m == nil {
+		return
+	}
+
+	code := "error"
+	if err == nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+	m.requests.WithLabelValues(endpoint, code).Inc()
+	m.duration.WithLabelValues(endpoint).Observe(duration.Seconds())
+}
+
+func (m *metrics) observeRetry(endpoint string, rateLimited bool) {
+	if m == nil {
+		return
+	}
+
+	m.retries.WithLabelValues(endpoint).Inc()
+	if rateLimited {
+		m.rateLimited.WithLabelValues(endpoint).Inc()
+	}
+}
+
+func (m *metrics) observeCache(endpoint string, hit bool) {
+	if m == nil {
+		return
+	}
+
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.cache.WithLabelValues(endpoint, result).Inc()
+}
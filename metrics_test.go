@@ -0,0 +1,40 @@
+package genius_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/natecham/genius"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"meta":{"status":200},"response":{"song":{"id":1}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	registry := prometheus.NewRegistry()
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL), genius.WithMetrics(registry))
+	// A second client shares the metrics instead of failing to register them.
+	other := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL), genius.WithMetrics(registry))
+
+	for _, c := range []*genius.Client{client, other} {
+		if _, err := c.GetSong(context.Background(), 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := `
+# HELP genius_requests_total Requests made to Genius by endpoint and status code.
+# TYPE genius_requests_total counter
+genius_requests_total{code="200",endpoint="/songs/:id"} 2
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(want), "genius_requests_total"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,42 @@
+package genius
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// mobileUserAgent is the User-Agent of lyrics requests falling back to the AMP page, that of a current Android Chrome.
+const mobileUserAgent = "Mozilla/5.0 (Linux; Android 14; Pixel 8) AppleWebKit/537.36 (KHTML, like Gecko) " +
+	"Chrome/129.0.0.0 Mobile Safari/537.36"
+
+// ampURL returns the URL of the AMP version of the song page at uri, e.g.
+// https://genius.com/amp/Kendrick-lamar-humble-lyrics for https://genius.com/Kendrick-lamar-humble-lyrics.
+func ampURL(uri string) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+
+	u.Path = path.Join("/amp", u.Path)
+	u.RawPath = ""
+	return u.String(), nil
+}
+
+// fallBackToAMP reports whether the lyrics at a song page URL are worth fetching from the AMP page after the
+// desktop page failed with err. Missing songs, pages without lyrics and cancelled requests aren't, anything else,
+// from dropped connections to anti-bot challenges, may be specific to the desktop page.
+func fallBackToAMP(err error) bool {
+	if errors.Is(err, ErrNoLyrics) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode != http.StatusNotFound && statusErr.StatusCode != http.StatusGone
+	}
+
+	return true
+}
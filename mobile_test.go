@@ -0,0 +1,111 @@
+package genius_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+func TestGetLyricsAMPFallback(t *testing.T) {
+	page, err := os.ReadFile("testdata/extractor/amp.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+
+		switch r.URL.Path {
+		case "/Kendrick-lamar-humble-lyrics":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`<html><title>Just a moment...</title></html>`))
+		case "/amp/Kendrick-lamar-humble-lyrics":
+			if !strings.Contains(r.Header.Get("User-Agent"), "Mobile") {
+				t.Errorf("AMP page requested with User-Agent %q", r.Header.Get("User-Agent"))
+			}
+			_, _ = w.Write(page)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client := genius.NewClient(nil, "token")
+
+	lyrics, err := client.GetLyrics(context.Background(), server.URL+"/Kendrick-lamar-humble-lyrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(lyrics, "[Intro]\nNobody pray for me\n") {
+		t.Errorf("unexpected lyrics %q", lyrics)
+	}
+
+	paths = nil
+	_, err = client.GetLyrics(context.Background(), server.URL+"/Missing-song-lyrics")
+	var statusErr *genius.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected a 404 StatusError, got %v", err)
+	}
+	if len(paths) != 1 {
+		t.Errorf("expected missing songs not to fall back to the AMP page, requested %q", paths)
+	}
+}
+
+func TestGetLyricsAMPFallbackFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/amp/") {
+			_, _ = w.Write([]byte(`<html><body>No lyrics here</body></html>`))
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := genius.NewClient(nil, "token").GetLyrics(context.Background(), server.URL+"/Kendrick-lamar-humble-lyrics")
+	var statusErr *genius.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected the desktop page's StatusError, got %v", err)
+	}
+}
+
+func TestGetLyricsNoLyricsSkipsAMP(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`<html><body>No lyrics here</body></html>`))
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := genius.NewClient(nil, "token").GetLyrics(context.Background(), server.URL+"/Kendrick-lamar-humble-lyrics")
+	if !errors.Is(err, genius.ErrNoLyrics) {
+		t.Errorf("expected ErrNoLyrics, got %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected pages without lyrics not to fall back to the AMP page, got %d requests", requests)
+	}
+}
+
+func TestGetLyricsContextCancelled(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	}))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := genius.NewClient(nil, "token").GetLyrics(ctx, server.URL+"/Kendrick-lamar-humble-lyrics")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("expected no requests, got %d", requests)
+	}
+}
@@ -0,0 +1,54 @@
+package genius
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Page is a single page of a paginated list endpoint.
+type Page[T any] struct {
+	// Items are the entries of this page.
+	Items []T
+	// NextPage is the number of the following page, 0 if this is the last one.
+	NextPage int
+	// PerPage is the page size that was requested.
+	PerPage int
+	// Meta is the meta object of the raw response.
+	Meta *Meta
+}
+
+// pageResponse is the envelope of list endpoints, the items are stored under an endpoint specific key.
+type pageResponse struct {
+	Meta     *Meta                      `json:"meta"`
+	Response map[string]json.RawMessage `json:"response"`
+}
+
+// fetchPage makes req and decodes the list stored under key in the response into a Page.
+func fetchPage[T any](c *Client, req *http.Request, key string, perPage int) (*Page[T], error) {
+	var response pageResponse
+	if err := c.doRequest(req, &response); err != nil {
+		return nil, err
+	}
+
+	page := &Page[T]{PerPage: perPage, Meta: response.Meta}
+
+	if raw, ok := response.Response[key]; ok {
+		if err := c.decodeAt("response."+key, raw, &page.Items); err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", key, err)
+		}
+	}
+
+	if raw, ok := response.Response["next_page"]; ok {
+		// next_page is null on the last page.
+		var next *int
+		if err := json.Unmarshal(raw, &next); err != nil {
+			return nil, fmt.Errorf("decoding next_page: %w", err)
+		}
+		if next != nil {
+			page.NextPage = *next
+		}
+	}
+
+	return page, nil
+}
@@ -0,0 +1,252 @@
+package genius_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/natecham/genius"
+)
+
+// newArtistSongsServer serves count songs for any artist. With emptyLastPage the page after the last song is
+// reported as next_page and served empty, as Genius occasionally does.
+func newArtistSongsServer(t *testing.T, count int, emptyLastPage bool) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var requests int32
+	songs := artistSongsHandler(count, emptyLastPage)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) > 100 {
+			http.Error(w, "too many requests, pagination doesn't terminate", http.StatusInternalServerError)
+			return
+		}
+		songs(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+// artistSongsHandler serves the pages of count songs with IDs from 1, see newArtistSongsServer.
+func artistSongsHandler(count int, emptyLastPage bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		if page < 1 {
+			http.Error(w, "invalid page "+r.URL.Query().Get("page"), http.StatusBadRequest)
+			return
+		}
+
+		songs := []map[string]any{}
+		for id := (page-1)*perPage + 1; id <= page*perPage && id <= count; id++ {
+			songs = append(songs, map[string]any{"id": id})
+		}
+
+		var next any
+		if page*perPage < count || (emptyLastPage && len(songs) > 0) {
+			next = page + 1
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"meta":     map[string]any{"status": 200},
+			"response": map[string]any{"songs": songs, "next_page": next},
+		})
+	}
+}
+
+func TestGetArtistSongsTerminates(t *testing.T) {
+	tests := []struct {
+		name          string
+		available     int
+		total         int
+		emptyLastPage bool
+		want          int
+	}{
+		{"all", 120, -1, false, 120},
+		{"fewer than available", 120, 70, false, 70},
+		{"exactly one page", 120, 50, false, 50},
+		{"more than available", 120, 500, false, 120},
+		{"more than available, empty last page", 100, 500, true, 100},
+		{"all, empty last page", 100, -1, true, 100},
+		{"none available", 0, 10, false, 0},
+		{"zero requested", 120, 0, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := newArtistSongsServer(t, tt.available, tt.emptyLastPage)
+			client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+			songs, err := client.GetArtistSongs(context.Background(), 1, genius.SortTitle, tt.total)
+			if err != nil {
+				t.Fatalf("GetArtistSongs failed after %d requests: %v", *requests, err)
+			}
+			if len(songs) != tt.want {
+				t.Fatalf("got %d songs, want %d", len(songs), tt.want)
+			}
+			for i, song := range songs {
+				if song.ID != i+1 {
+					t.Fatalf("song %d has ID %d, want %d", i, song.ID, i+1)
+				}
+			}
+		})
+	}
+}
+
+func TestGetArtistSongsInvalidSort(t *testing.T) {
+	client := genius.NewClient(nil, "token", genius.WithBaseURL("http://127.0.0.1:0"))
+
+	if _, err := client.GetArtistSongs(context.Background(), 1, genius.Sort("newest"), -1); err == nil {
+		t.Fatal("expected an error for an unsupported sort")
+	}
+}
+
+func TestArtistSongsWithClientConcurrency(t *testing.T) {
+	server, _ := newArtistSongsServer(t, 230, false)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL), genius.WithConcurrency(3))
+
+	id := 0
+	for song, err := range client.ArtistSongs(context.Background(), 1, nil) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		id++
+		if song.ID != id {
+			t.Fatalf("song %d has ID %d, songs are out of order", id, song.ID)
+		}
+	}
+	if id != 230 {
+		t.Fatalf("got %d songs, want 230", id)
+	}
+}
+
+func TestArtistSongsConcurrentOrder(t *testing.T) {
+	songs := artistSongsHandler(250, false)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Later pages are answered first.
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		time.Sleep(time.Duration(6-page) * 10 * time.Millisecond)
+		songs(w, r)
+	}))
+	t.Cleanup(server.Close)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	opts := &genius.ArtistSongsOptions{ListOptions: genius.ListOptions{Concurrency: 4}}
+	id := 0
+	for song, err := range client.ArtistSongs(context.Background(), 1, opts) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id++; song.ID != id {
+			t.Fatalf("song %d has ID %d, songs are out of order", id, song.ID)
+		}
+	}
+	if id != 250 {
+		t.Fatalf("got %d songs, want 250", id)
+	}
+}
+
+func TestArtistSongsErrorMidBatch(t *testing.T) {
+	songs := artistSongsHandler(250, false)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "3" {
+			http.Error(w, "bad page", http.StatusBadRequest)
+			return
+		}
+		songs(w, r)
+	}))
+	t.Cleanup(server.Close)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	opts := &genius.ArtistSongsOptions{ListOptions: genius.ListOptions{Concurrency: 4}}
+	var ids []int
+	var statusErr *genius.StatusError
+	for song, err := range client.ArtistSongs(context.Background(), 1, opts) {
+		if err != nil {
+			if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusBadRequest {
+				t.Fatalf("unexpected error %v", err)
+			}
+			continue
+		}
+		ids = append(ids, song.ID)
+	}
+	if statusErr == nil {
+		t.Fatal("expected the error of page 3")
+	}
+	// Pages 1 and 2 are yielded, pages 4 and 5 of the batch are discarded with the failed page 3.
+	if len(ids) != 100 || ids[0] != 1 || ids[99] != 100 {
+		t.Fatalf("got %d songs before the error, want songs 1 to 100", len(ids))
+	}
+}
+
+func TestArtistSongsStartPageAndMaxItems(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  genius.ListOptions
+		first int
+		count int
+	}{
+		{"start page", genius.ListOptions{StartPage: 3}, 101, 130},
+		{"start page, concurrent", genius.ListOptions{StartPage: 2, Concurrency: 3}, 51, 180},
+		{"start page past the last", genius.ListOptions{StartPage: 6}, 0, 0},
+		{"max items", genius.ListOptions{MaxItems: 70}, 1, 70},
+		{"max items, concurrent", genius.ListOptions{MaxItems: 120, Concurrency: 3}, 1, 120},
+		{"max items from start page", genius.ListOptions{StartPage: 2, MaxItems: 30, Concurrency: 2}, 51, 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newArtistSongsServer(t, 230, false)
+			client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+			var ids []int
+			for song, err := range client.ArtistSongs(context.Background(), 1, &genius.ArtistSongsOptions{ListOptions: tt.opts}) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				ids = append(ids, song.ID)
+			}
+			if len(ids) != tt.count {
+				t.Fatalf("got %d songs, want %d", len(ids), tt.count)
+			}
+			for i, id := range ids {
+				if id != tt.first+i {
+					t.Fatalf("song %d has ID %d, want %d", i, id, tt.first+i)
+				}
+			}
+		})
+	}
+}
+
+func TestGetArtistSongsByPopularity(t *testing.T) {
+	tests := []struct {
+		limit    int
+		want     int
+		requests int32
+	}{
+		{5, 5, 1},
+		{120, 120, 3},
+		{0, 200, 4},
+	}
+	for _, test := range tests {
+		server, requests := newPopularSongsServer(t, 200, 200)
+		client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+		songs, err := client.GetArtistSongsByPopularity(context.Background(), 1, test.limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(songs) != test.want || songs[0].ID != 1 {
+			t.Errorf("limit %d: got %d songs, want the first %d", test.limit, len(songs), test.want)
+		}
+		if n := atomic.LoadInt32(requests); n != test.requests {
+			t.Errorf("limit %d: got %d requests, want %d", test.limit, n, test.requests)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package genius
+
+import (
+	"net/http"
+	"net/url"
+	"sync/atomic"
+)
+
+// WithProxy routes the requests for genius.com pages, lyrics pages and the unofficial API, through proxy. Requests to
+// the official API, see WithBaseURL, and image downloads stay direct.
+//
+// Proxies apply to HTTP clients whose transport is an *http.Transport, such as the one NewClient creates, and replace
+// the proxy it is configured with for page requests.
+func WithProxy(proxy *url.URL) ClientOption {
+	return WithProxyPool([]*url.URL{proxy})
+}
+
+// WithProxyPool routes page requests through proxies like WithProxy, rotating through them with every request, so
+// heavy scraping is spread over the proxies' addresses.
+func WithProxyPool(proxies []*url.URL) ClientOption {
+	return func(client *Client) {
+		if len(proxies) == 0 {
+			client.proxies = nil
+			return
+		}
+		client.proxies = &proxyPool{proxies: proxies}
+	}
+}
+
+// proxyPool hands out its proxies in turn.
+type proxyPool struct {
+	proxies []*url.URL
+	next    atomic.Uint64
+}
+
+// proxy implements http.Transport.Proxy, returning the next proxy of the pool for every request.
+func (p *proxyPool) proxy(*http.Request) (*url.URL, error) {
+	i := p.next.Add(1) - 1
+	return p.proxies[i%uint64(len(p.proxies))], nil
+}
+
+// newPageClient returns the HTTP client for page requests: the client's own, with the cookie jar of WithCookieJar
+// and WithCookies and a transport using the proxies, if any are set.
+func (c *Client) newPageClient() *http.Client {
+	jar := c.cookieJar()
+	if c.proxies == nil && jar == nil {
+		return c.client
+	}
+
+	pageClient := *c.client
+	if jar != nil {
+		pageClient.Jar = jar
+	}
+	if c.proxies == nil {
+		return &pageClient
+	}
+
+	base := c.client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if transport, ok := base.(*http.Transport); ok {
+		transport = transport.Clone()
+		transport.Proxy = c.proxies.proxy
+		pageClient.Transport = transport
+	}
+	return &pageClient
+}
+
+// httpClient returns the HTTP client for req, the page client for anything but requests to the official API.
+func (c *Client) httpClient(req *http.Request) *http.Client {
+	if base, err := url.Parse(c.baseURL); err == nil && req.URL.Host == base.Host {
+		return c.client
+	}
+	return c.pageClient
+}
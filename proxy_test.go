@@ -0,0 +1,61 @@
+package genius_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+func TestProxyPool(t *testing.T) {
+	page, err := os.ReadFile("testdata/extractor/verses.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var proxied []string
+	newProxy := func(name string) *url.URL {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			proxied = append(proxied, name+" "+r.URL.String())
+			_, _ = w.Write(page)
+		}))
+		t.Cleanup(server.Close)
+
+		proxy, err := url.Parse(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return proxy
+	}
+	proxies := []*url.URL{newProxy("a"), newProxy("b")}
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"meta":{"status":200},"response":{"song":{"id":1,"title":"HUMBLE."}}}`))
+	}))
+	t.Cleanup(api.Close)
+
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(api.URL), genius.WithProxyPool(proxies))
+
+	if _, err := client.GetSong(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	for range 3 {
+		if _, err := client.GetLyrics(context.Background(), "http://genius.test/Kendrick-lamar-humble-lyrics"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []string{
+		"a http://genius.test/Kendrick-lamar-humble-lyrics",
+		"b http://genius.test/Kendrick-lamar-humble-lyrics",
+		"a http://genius.test/Kendrick-lamar-humble-lyrics",
+	}
+	if !slices.Equal(proxied, want) {
+		t.Errorf("got proxied requests %q, want %q", proxied, want)
+	}
+}
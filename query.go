@@ -0,0 +1,164 @@
+package genius
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxQueryLength is the length in runes Query.String truncates queries to. Long queries, such as whole lyrics lines
+// pasted by users, rarely match anything.
+const MaxQueryLength = 100
+
+// maxSnippetWords is the number of words of a lyrics snippet Query.Lyrics keeps.
+const maxSnippetWords = 10
+
+// Query composes a search query from titles, artists, quoted phrases and lyrics snippets, for example:
+//
+//	q := genius.NewQuery().Title("HUMBLE.").Artist("Kendrick Lamar").String()
+//
+// Control characters and repeated whitespace are removed from all parts. The query is URL encoded by the search
+// methods, so it must not be encoded by the caller.
+type Query struct {
+	terms []queryTerm
+}
+
+// queryTerm is a part of a Query, its words are quoted as a phrase if quoted is set.
+type queryTerm struct {
+	words  []string
+	quoted bool
+}
+
+// render returns the term with its first n words.
+func (t queryTerm) render(n int) string {
+	s := strings.Join(t.words[:n], " ")
+	if t.quoted {
+		return `"` + s + `"`
+	}
+	return s
+}
+
+// NewQuery returns an empty query.
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// Title adds a song or album title, normalized as by NormalizeTitle, so featured artist credits and remaster
+// suffixes don't narrow the search.
+func (q *Query) Title(title string) *Query {
+	return q.add(NormalizeTitle(title), false)
+}
+
+// Artist adds the primary artist of comma separated artists, normalized as by NormalizeArtist.
+func (q *Query) Artist(artist string) *Query {
+	return q.add(NormalizeArtist(primaryArtist(artist)), false)
+}
+
+// Terms adds words as they are, e.g. to narrow a search with "remix" or "live".
+func (q *Query) Terms(terms string) *Query {
+	return q.add(terms, false)
+}
+
+// Phrase adds a quoted phrase, which hits must contain as a whole. Quotes within phrase are dropped.
+func (q *Query) Phrase(phrase string) *Query {
+	return q.add(strings.ReplaceAll(phrase, `"`, ""), true)
+}
+
+// Lyrics adds a snippet of lyrics as a phrase. Only the first lyrics line of snippet is used, skipping section
+// headers such as [Chorus], and it is cut to its first few words, as a search rarely matches long lyrics verbatim.
+func (q *Query) Lyrics(snippet string) *Query {
+	var line string
+	for _, l := range strings.Split(snippet, "\n") {
+		if l = strings.TrimSpace(l); l != "" && !strings.HasPrefix(l, "[") {
+			line = l
+			break
+		}
+	}
+
+	words := strings.Fields(strings.ReplaceAll(line, `"`, ""))
+	return q.add(strings.Join(words[:min(len(words), maxSnippetWords)], " "), true)
+}
+
+func (q *Query) add(s string, quoted bool) *Query {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == utf8.RuneError {
+			return ' '
+		}
+		return r
+	}, s)
+
+	if words := strings.Fields(s); len(words) > 0 {
+		q.terms = append(q.terms, queryTerm{words: words, quoted: quoted})
+	}
+	return q
+}
+
+// String returns the query, its parts separated by spaces and truncated to MaxQueryLength runes. Parts are cut
+// between words and phrases stay quoted, parts following a cut are dropped.
+func (q *Query) String() string {
+	var parts []string
+	length := -1 // No separator precedes the first part.
+	for _, term := range q.terms {
+		n := len(term.words)
+		for ; n > 0; n-- {
+			part := term.render(n)
+			if size := length + 1 + utf8.RuneCountInString(part); size <= MaxQueryLength {
+				parts = append(parts, part)
+				length = size
+				break
+			}
+		}
+		if n < len(term.words) {
+			break
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// CandidateQueries returns search queries for a song, from the most to the least specific, for matches that the
+// obvious query misses. Multiple artists may be passed comma separated, the first is treated as primary.
+//
+// Besides the normalized title with and without the primary artist, the candidates include the title as a quoted
+// phrase, for titles normalization mangles, the title without any parenthesized or dashed suffix, and the title with
+// each further artist, as Genius sometimes lists a song under a featured artist.
+func CandidateQueries(title string, artist string) []string {
+	candidates := []*Query{
+		NewQuery().Title(title).Artist(artist),
+		NewQuery().Title(title),
+		NewQuery().Phrase(title).Artist(artist),
+		NewQuery().Title(baseTitle(title)).Artist(artist),
+	}
+	for _, other := range strings.Split(artist, ",")[1:] {
+		candidates = append(candidates, NewQuery().Title(title).Artist(other))
+	}
+
+	var queries []string
+	seen := make(map[string]bool)
+	for _, candidate := range candidates {
+		q := candidate.String()
+		if q == "" || seen[q] {
+			continue
+		}
+		seen[q] = true
+		queries = append(queries, q)
+	}
+
+	return queries
+}
+
+// primaryArtist returns the first of comma separated artists.
+func primaryArtist(artist string) string {
+	return strings.TrimSpace(strings.Split(artist, ",")[0])
+}
+
+// baseTitle returns title up to the first parenthesis, bracket or dash, e.g. "Song" for "Song (Live at Wembley)".
+func baseTitle(title string) string {
+	if i := strings.IndexAny(title, "(["); i > 0 {
+		title = title[:i]
+	}
+	if i := strings.Index(title, " - "); i > 0 {
+		title = title[:i]
+	}
+	return title
+}
@@ -0,0 +1,92 @@
+package genius_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/natecham/genius"
+)
+
+func TestQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query *genius.Query
+		want  string
+	}{
+		{"title and artist", genius.NewQuery().Title("Don’t Stop Me Now - Remastered 2011").Artist("Queen"), "dont stop me now queen"},
+		{"primary artist", genius.NewQuery().Artist("The Kid LAROI, Justin Bieber"), "the kid laroi"},
+		{"phrase", genius.NewQuery().Phrase(`say "hello"  again`).Terms("live"), `"say hello again" live`},
+		{"control characters", genius.NewQuery().Terms("one\ttwo\x00three\n"), "one two three"},
+		{"lyrics", genius.NewQuery().Lyrics("[Verse 1]\n\nIs this the real life? Is this just fantasy? Caught in a landslide\nNo escape"), `"Is this the real life? Is this just fantasy? Caught"`},
+		{"empty parts", genius.NewQuery().Title("").Phrase(" ").Lyrics("[Intro]").Terms("x"), "x"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.query.String(); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestQueryTruncation(t *testing.T) {
+	long := strings.Repeat("word ", 30)
+
+	got := genius.NewQuery().Terms("first").Phrase(long).Artist("Queen").String()
+	if n := utf8.RuneCountInString(got); n > genius.MaxQueryLength {
+		t.Fatalf("query has %d runes, want at most %d", n, genius.MaxQueryLength)
+	}
+	if !strings.HasPrefix(got, `first "word word`) || !strings.HasSuffix(got, `word"`) {
+		t.Errorf("expected the phrase to be cut between words and stay quoted, got %q", got)
+	}
+}
+
+func TestCandidateQueries(t *testing.T) {
+	got := genius.CandidateQueries("Forever Young (Live at Wembley)", "Alphaville, Guest Star")
+	want := []string{
+		"forever young live at wembley alphaville",
+		"forever young live at wembley",
+		`"Forever Young (Live at Wembley)" alphaville`,
+		"forever young alphaville",
+		"forever young live at wembley guest star",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if got := genius.CandidateQueries("Bad Guy", "Billie Eilish"); len(got) != 3 {
+		t.Errorf("expected duplicate candidates to be dropped, got %q", got)
+	}
+}
+
+func TestMatchTrackCandidates(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		queries = append(queries, q)
+
+		var hits []map[string]any
+		if strings.HasPrefix(q, `"`) {
+			hits = append(hits, map[string]any{"type": "song", "result": song(7, "Forever Young", "Alphaville", "Alphaville")})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"response": map[string]any{"hits": hits}})
+	}))
+	defer server.Close()
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	got, err := client.MatchTrack(context.Background(), "Forever Young", "Alphaville", 0)
+	if err != nil {
+		t.Fatalf("MatchTrack failed: %v", err)
+	}
+	if got.ID != 7 {
+		t.Errorf("got song %d, want 7", got.ID)
+	}
+	if len(queries) != 3 {
+		t.Errorf("expected 3 searches, got %q", queries)
+	}
+}
@@ -0,0 +1,108 @@
+package genius
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+)
+
+// Ranker scores a search hit for the search term, higher scores rank first. Rankers tune which hit the search
+// response helpers such as GetSongFromSearchResponse pick.
+type Ranker interface {
+	Rank(hit *Hit, searchTerm string) float64
+}
+
+// RankerFunc adapts a function to a Ranker.
+type RankerFunc func(hit *Hit, searchTerm string) float64
+
+// Rank calls f.
+func (f RankerFunc) Rank(hit *Hit, searchTerm string) float64 {
+	return f(hit, searchTerm)
+}
+
+// hitResult are the fields of search hit results the rankers look at.
+type hitResult struct {
+	Title         string `json:"title"`
+	Name          string `json:"name"`
+	PrimaryArtist *struct {
+		Name string `json:"name"`
+	} `json:"primary_artist"`
+	Stats *Stats `json:"stats"`
+}
+
+func decodeHitResult(hit *Hit) *hitResult {
+	var result hitResult
+	_ = json.Unmarshal(hit.Result, &result)
+	return &result
+}
+
+// name returns the title of song hits and the name of other hits.
+func (r *hitResult) name() string {
+	if r.Title != "" {
+		return r.Title
+	}
+	return r.Name
+}
+
+// ExactMatch scores 1 for hits whose title, or name for artist and album hits, equals the search term ignoring case.
+// It is the ranking of the search response helpers if no rankers are given.
+var ExactMatch Ranker = RankerFunc(func(hit *Hit, searchTerm string) float64 {
+	if strings.EqualFold(decodeHitResult(hit).name(), searchTerm) {
+		return 1
+	}
+	return 0
+})
+
+// PenalizeTranslations scores -1 for song hits of the pseudo artists the translation community publishes
+// translations under, such as "Genius English Translations".
+var PenalizeTranslations Ranker = RankerFunc(func(hit *Hit, _ string) float64 {
+	if artist := decodeHitResult(hit).PrimaryArtist; artist != nil && isTranslationArtist(artist.Name) {
+		return -1
+	}
+	return 0
+})
+
+// BoostPageviews scores song hits by their page views, from 0 to about 1 for a billion page views on a logarithmic
+// scale, so it breaks ties rather than outweighing a match.
+var BoostPageviews Ranker = RankerFunc(func(hit *Hit, _ string) float64 {
+	stats := decodeHitResult(hit).Stats
+	if stats == nil || stats.Pageviews <= 0 {
+		return 0
+	}
+	return math.Log10(float64(stats.Pageviews)+1) / 9
+})
+
+// PreferArtist scores 1 for song hits whose primary artist, and artist hits whose name, is artist after
+// normalization, see NormalizeArtist.
+func PreferArtist(artist string) Ranker {
+	artist = NormalizeArtist(artist)
+	return RankerFunc(func(hit *Hit, _ string) float64 {
+		result := decodeHitResult(hit)
+		name := result.Name
+		if hit.Type != HitTypeArtist {
+			if result.PrimaryArtist == nil {
+				return 0
+			}
+			name = result.PrimaryArtist.Name
+		}
+		if NormalizeArtist(name) == artist {
+			return 1
+		}
+		return 0
+	})
+}
+
+// rank returns the index of the hit with the highest sum of the scores of rankers, the first one on ties.
+func rank(hits []Hit, searchTerm string, rankers []Ranker) int {
+	best, bestScore := 0, math.Inf(-1)
+	for i := range hits {
+		score := 0.0
+		for _, ranker := range rankers {
+			score += ranker.Rank(&hits[i], searchTerm)
+		}
+		if score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return best
+}
@@ -0,0 +1,69 @@
+package genius_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+func newWebSearchResponse(t *testing.T, sections string) *genius.WebSearchResponse {
+	t.Helper()
+
+	var response genius.WebSearchResponse
+	if err := json.Unmarshal([]byte(`{"response": {"sections": `+sections+`}}`), &response); err != nil {
+		t.Fatal(err)
+	}
+	return &response
+}
+
+func TestSearchResponseRankers(t *testing.T) {
+	response := newWebSearchResponse(t, `[
+		{"type": "song", "hits": [
+			{"type": "song", "result": {"id": 1, "title": "Alright", "primary_artist": {"name": "Genius English Translations"}}},
+			{"type": "song", "result": {"id": 2, "title": "Alright", "primary_artist": {"name": "Statik Selektah"}, "stats": {"pageviews": 1000}}},
+			{"type": "song", "result": {"id": 3, "title": "Alright", "primary_artist": {"name": "Kendrick Lamar"}, "stats": {"pageviews": 5000000}}},
+			{"type": "song", "result": {"id": 4, "title": "Alright (Remix)", "primary_artist": {"name": "Kendrick Lamar"}, "stats": {"pageviews": 9000000}}}
+		]},
+		{"type": "artist", "hits": [
+			{"type": "artist", "result": {"id": 10, "name": "Kendrick Lamar Fans"}},
+			{"type": "artist", "result": {"id": 11, "name": "Kendrick Lamar"}}
+		]}
+	]`)
+
+	byPageviews := genius.RankerFunc(func(hit *genius.Hit, _ string) float64 {
+		song, err := hit.AsSong()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return float64(song.Pageviews())
+	})
+	tests := []struct {
+		name    string
+		rankers []genius.Ranker
+		want    int
+	}{
+		{"exact match by default", nil, 1},
+		{"penalize translations", []genius.Ranker{genius.ExactMatch, genius.PenalizeTranslations}, 2},
+		{"boost pageviews", []genius.Ranker{genius.ExactMatch, genius.PenalizeTranslations, genius.BoostPageviews}, 3},
+		{"prefer artist", []genius.Ranker{genius.ExactMatch, genius.PreferArtist("kendrick lamar")}, 3},
+		{"custom", []genius.Ranker{byPageviews}, 4},
+	}
+	for _, test := range tests {
+		song, err := genius.GetSongFromSearchResponse(response, "alright", test.rankers...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if song.ID != test.want {
+			t.Errorf("%s: got song %d, want %d", test.name, song.ID, test.want)
+		}
+	}
+
+	artist, err := genius.GetArtistFromSearchResponse(response, "Kendrick", genius.PreferArtist("Kendrick Lamar"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if artist.ID != 11 {
+		t.Errorf("got artist %d, want 11", artist.ID)
+	}
+}
@@ -0,0 +1,211 @@
+package genius
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Raw returns the JSON the song was decoded from. It gives access to fields Genius added before the struct caught
+// up, and is nil for songs that weren't decoded from JSON.
+func (s *Song) Raw() json.RawMessage {
+	return s.raw
+}
+
+// UnmarshalJSON decodes the song and keeps a copy of data for Raw.
+func (s *Song) UnmarshalJSON(data []byte) error {
+	type song Song
+	if err := json.Unmarshal(data, (*song)(s)); err != nil {
+		return err
+	}
+	s.raw = append(json.RawMessage(nil), data...)
+	s.descriptions = &descriptionCache{}
+	return nil
+}
+
+// MarshalJSON encodes the song so that it matches the JSON it was decoded from, see marshalMerged.
+func (s Song) MarshalJSON() ([]byte, error) {
+	type song Song
+	return marshalMerged(s.raw, song(s))
+}
+
+// Raw returns the JSON the album was decoded from, see Song.Raw.
+func (a *Album) Raw() json.RawMessage {
+	return a.raw
+}
+
+// UnmarshalJSON decodes the album and keeps a copy of data for Raw.
+func (a *Album) UnmarshalJSON(data []byte) error {
+	type album Album
+	if err := json.Unmarshal(data, (*album)(a)); err != nil {
+		return err
+	}
+	a.raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// MarshalJSON encodes the album so that it matches the JSON it was decoded from, see marshalMerged.
+func (a Album) MarshalJSON() ([]byte, error) {
+	type album Album
+	return marshalMerged(a.raw, album(a))
+}
+
+// Raw returns the JSON the artist was decoded from, see Song.Raw.
+func (a *Artist) Raw() json.RawMessage {
+	return a.raw
+}
+
+// UnmarshalJSON decodes the artist and keeps a copy of data for Raw.
+func (a *Artist) UnmarshalJSON(data []byte) error {
+	type artist Artist
+	if err := json.Unmarshal(data, (*artist)(a)); err != nil {
+		return err
+	}
+	a.raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// MarshalJSON encodes the artist so that it matches the JSON it was decoded from, see marshalMerged.
+func (a Artist) MarshalJSON() ([]byte, error) {
+	type artist Artist
+	return marshalMerged(a.raw, artist(a))
+}
+
+// Raw returns the JSON the annotation was decoded from, see Song.Raw.
+func (a *Annotation) Raw() json.RawMessage {
+	return a.raw
+}
+
+// UnmarshalJSON decodes the annotation and keeps a copy of data for Raw.
+func (a *Annotation) UnmarshalJSON(data []byte) error {
+	type annotation Annotation
+	if err := json.Unmarshal(data, (*annotation)(a)); err != nil {
+		return err
+	}
+	a.raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// MarshalJSON encodes the annotation so that it matches the JSON it was decoded from, see marshalMerged.
+func (a Annotation) MarshalJSON() ([]byte, error) {
+	type annotation Annotation
+	return marshalMerged(a.raw, annotation(a))
+}
+
+// Raw returns the JSON the referent was decoded from, see Song.Raw.
+func (d *DescriptionAnnotation) Raw() json.RawMessage {
+	return d.raw
+}
+
+// UnmarshalJSON decodes the referent and keeps a copy of data for Raw.
+func (d *DescriptionAnnotation) UnmarshalJSON(data []byte) error {
+	type descriptionAnnotation DescriptionAnnotation
+	if err := json.Unmarshal(data, (*descriptionAnnotation)(d)); err != nil {
+		return err
+	}
+	d.raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// MarshalJSON encodes the referent so that it matches the JSON it was decoded from, see marshalMerged.
+func (d DescriptionAnnotation) MarshalJSON() ([]byte, error) {
+	type descriptionAnnotation DescriptionAnnotation
+	return marshalMerged(d.raw, descriptionAnnotation(d))
+}
+
+// marshalMerged encodes v, a model decoded from raw, so that an unmodified model re-encodes to JSON equivalent to
+// raw: fields the struct doesn't know are kept, and fields raw didn't contain are only added when they are set.
+// Changes made to the model after decoding are reflected in the output.
+func marshalMerged(raw json.RawMessage, v any) ([]byte, error) {
+	known, err := json.Marshal(v)
+	if err != nil || len(raw) == 0 {
+		return known, err
+	}
+
+	var rawValue, knownValue any
+	if err = unmarshalNumbers(raw, &rawValue); err != nil {
+		return known, nil
+	}
+	if err = unmarshalNumbers(known, &knownValue); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(mergeJSON(rawValue, knownValue))
+}
+
+func unmarshalNumbers(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// mergeJSON merges the decoded JSON values known into raw, see marshalMerged.
+func mergeJSON(raw any, known any) any {
+	if isZeroJSON(known) && isZeroJSON(raw) {
+		return raw
+	}
+
+	switch known := known.(type) {
+	case map[string]any:
+		rawMap, ok := raw.(map[string]any)
+		if !ok {
+			return known
+		}
+
+		merged := make(map[string]any, len(rawMap))
+		for k, v := range rawMap {
+			merged[k] = v
+		}
+		for k, v := range known {
+			if rv, ok := rawMap[k]; ok {
+				merged[k] = mergeJSON(rv, v)
+			} else if !isZeroJSON(v) {
+				merged[k] = v
+			}
+		}
+		return merged
+	case []any:
+		rawSlice, ok := raw.([]any)
+		if !ok || len(rawSlice) != len(known) {
+			return known
+		}
+
+		merged := make([]any, len(known))
+		for i := range known {
+			merged[i] = mergeJSON(rawSlice[i], known[i])
+		}
+		return merged
+	default:
+		return known
+	}
+}
+
+// isZeroJSON reports whether v is a decoded JSON null, false, 0, "", or an array or object of only such values.
+func isZeroJSON(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case string:
+		return v == ""
+	case json.Number:
+		f, err := v.Float64()
+		return err == nil && f == 0
+	case []any:
+		for _, e := range v {
+			if !isZeroJSON(e) {
+				return false
+			}
+		}
+		return true
+	case map[string]any:
+		for _, e := range v {
+			if !isZeroJSON(e) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,49 @@
+package genius
+
+// RelationshipType is the kind of a song relationship. Values Genius adds later are kept as they are, so they can
+// still be compared against strings.
+type RelationshipType string
+
+// Relationship types Genius is known to return.
+const (
+	RelationshipSamples        RelationshipType = "samples"
+	RelationshipSampledIn      RelationshipType = "sampled_in"
+	RelationshipInterpolates   RelationshipType = "interpolates"
+	RelationshipInterpolatedBy RelationshipType = "interpolated_by"
+	RelationshipCoverOf        RelationshipType = "cover_of"
+	RelationshipCoveredBy      RelationshipType = "covered_by"
+	RelationshipRemixOf        RelationshipType = "remix_of"
+	RelationshipRemixedBy      RelationshipType = "remixed_by"
+	RelationshipLiveVersionOf  RelationshipType = "live_version_of"
+)
+
+// IsKnown reports whether t is one of the relationship types declared in this package.
+func (t RelationshipType) IsKnown() bool {
+	switch t {
+	case RelationshipSamples, RelationshipSampledIn, RelationshipInterpolates, RelationshipInterpolatedBy,
+		RelationshipCoverOf, RelationshipCoveredBy, RelationshipRemixOf, RelationshipRemixedBy,
+		RelationshipLiveVersionOf:
+		return true
+	default:
+		return false
+	}
+}
+
+// Kind returns the type of the relationship, Genius sends it as relationship_type and, in older responses, as type.
+func (r *SongRelationship) Kind() RelationshipType {
+	if r.RelationshipType != "" {
+		return r.RelationshipType
+	}
+	return r.Type
+}
+
+// RelatedSongs returns the songs related to the song by relationship type t, e.g. the songs it samples for
+// RelationshipSamples.
+func (s *Song) RelatedSongs(t RelationshipType) []*Song {
+	for _, relationship := range s.SongRelationships {
+		if relationship != nil && relationship.Kind() == t {
+			return relationship.Songs
+		}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package genius_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+func TestRelatedSongs(t *testing.T) {
+	var song genius.Song
+	if err := json.Unmarshal(entityJSON(t, "testdata/song.json", "song"), &song); err != nil {
+		t.Fatal(err)
+	}
+
+	if songs := song.RelatedSongs(genius.RelationshipInterpolatedBy); len(songs) != 1 || songs[0].ID != 99 {
+		t.Errorf("unexpected interpolations %+v", songs)
+	}
+	if songs := song.RelatedSongs(genius.RelationshipSamples); len(songs) != 0 {
+		t.Errorf("unexpected samples %+v", songs)
+	}
+}
+
+func TestUnknownRelationshipType(t *testing.T) {
+	var relationship genius.SongRelationship
+	if err := json.Unmarshal([]byte(`{"relationship_type":"mashup_of","songs":[]}`), &relationship); err != nil {
+		t.Fatal(err)
+	}
+
+	if relationship.Kind() != "mashup_of" || relationship.Kind().IsKnown() {
+		t.Errorf("unknown type not preserved: %q", relationship.Kind())
+	}
+}
@@ -0,0 +1,55 @@
+package genius
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// get makes a GET request to endpoint, a full URL, with the query params and decodes the response into a T.
+func get[T any](ctx context.Context, c *Client, endpoint string, params url.Values) (*T, error) {
+	req, err := newRequest(ctx, endpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var response T
+	if err = c.doRequest(req, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// getPage requests a page of the list endpoint at endpoint, whose items are stored under key in the response.
+func getPage[T any](ctx context.Context, c *Client, endpoint string, params url.Values, key string, perPage int, page int) (*Page[T], error) {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("per_page", strconv.Itoa(perPage))
+	params.Set("page", strconv.Itoa(page))
+
+	req, err := newRequest(ctx, endpoint, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return fetchPage[T](c, req, key, perPage)
+}
+
+func newRequest(ctx context.Context, endpoint string, params url.Values) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(params) > 0 {
+		req.URL.RawQuery = params.Encode()
+	}
+	return req, nil
+}
+
+// textFormatParams returns the query parameters requesting textFormat.
+func textFormatParams(textFormat TextFormat) url.Values {
+	return url.Values{"text_format": {string(textFormat.apiFormat())}}
+}
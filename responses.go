@@ -0,0 +1,68 @@
+package genius
+
+// Responses of the Genius API consist of a meta object and an endpoint specific payload. Each endpoint has its own
+// response type so only the fields it actually returns are available. Paginated lists are returned as Page.
+
+// AccountResponse is the response of the /account endpoint.
+type AccountResponse struct {
+	Meta     *Meta `json:"meta"`
+	Response struct {
+		User *User `json:"user"`
+	} `json:"response"`
+}
+
+// ArtistResponse is the response of the /artists/:id endpoint.
+type ArtistResponse struct {
+	Meta     *Meta `json:"meta"`
+	Response struct {
+		Artist *Artist `json:"artist"`
+	} `json:"response"`
+}
+
+// SongResponse is the response of the /songs/:id endpoint.
+type SongResponse struct {
+	Meta     *Meta `json:"meta"`
+	Response struct {
+		Song *Song `json:"song"`
+	} `json:"response"`
+}
+
+// AlbumResponse is the response of the /albums/:id endpoint.
+type AlbumResponse struct {
+	Meta     *Meta `json:"meta"`
+	Response struct {
+		Album *Album `json:"album"`
+	} `json:"response"`
+}
+
+// AnnotationResponse is the response of the /annotations/:id endpoint.
+type AnnotationResponse struct {
+	Meta     *Meta `json:"meta"`
+	Response struct {
+		Annotation *Annotation `json:"annotation"`
+	} `json:"response"`
+}
+
+// SearchResponse is the response of the /search endpoint.
+type SearchResponse struct {
+	Meta     *Meta `json:"meta"`
+	Response struct {
+		Hits []*Hit `json:"hits"`
+	} `json:"response"`
+}
+
+// WebSearchResponse is the response of the /search/multi endpoint, its hits are grouped by type.
+type WebSearchResponse struct {
+	Meta     *Meta `json:"meta"`
+	Response struct {
+		Sections []Sections `json:"sections"`
+	} `json:"response"`
+}
+
+// PageDataResponse is the response of the unofficial /page_data endpoints.
+type PageDataResponse struct {
+	Meta     *Meta `json:"meta"`
+	Response struct {
+		PageData *PageData `json:"page_data"`
+	} `json:"response"`
+}
@@ -0,0 +1,37 @@
+package genius
+
+import (
+	"context"
+	"time"
+)
+
+// RetryInfo describes a request the client is about to retry.
+type RetryInfo struct {
+	// URL is the URL of the request.
+	URL string
+	// Attempt is the number of the attempt that failed, starting at 1.
+	Attempt int
+	// Wait is how long the client waits before retrying.
+	Wait time.Duration
+	// StatusCode is the status of the failed attempt.
+	StatusCode int
+}
+
+// RetryHook is called before the client waits to retry a request. Returning an error aborts the request with that
+// error instead of waiting.
+type RetryHook func(ctx context.Context, info RetryInfo) error
+
+// WithOnRetry sets a hook called when a request that failed with a server error is about to be retried.
+func WithOnRetry(hook RetryHook) ClientOption {
+	return func(client *Client) {
+		client.onRetry = hook
+	}
+}
+
+// WithOnRateLimited sets a hook called when Genius rate limits a request and the client is about to wait for the time
+// it asked for, which can be minutes.
+func WithOnRateLimited(hook RetryHook) ClientOption {
+	return func(client *Client) {
+		client.onRateLimited = hook
+	}
+}
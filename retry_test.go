@@ -0,0 +1,76 @@
+package genius_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+// newFlakyServer responds with status to the first failures requests and with a song afterwards.
+func newFlakyServer(t *testing.T, status int, failures int32) *httptest.Server {
+	t.Helper()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(status)
+			return
+		}
+		_, _ = w.Write([]byte(`{"meta":{"status":200},"response":{"song":{"id":1}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestOnRetry(t *testing.T) {
+	server := newFlakyServer(t, http.StatusServiceUnavailable, 2)
+
+	var attempts []int
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL),
+		genius.WithOnRetry(func(ctx context.Context, info genius.RetryInfo) error {
+			if info.StatusCode != http.StatusServiceUnavailable {
+				t.Errorf("unexpected status %d", info.StatusCode)
+			}
+			attempts = append(attempts, info.Attempt)
+			return nil
+		}),
+	)
+
+	if _, err := client.GetSong(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	if len(attempts) != 2 || attempts[0] != 1 || attempts[1] != 2 {
+		t.Fatalf("unexpected retry attempts %v", attempts)
+	}
+}
+
+func TestServerErrorRetriesAreLimited(t *testing.T) {
+	server := newFlakyServer(t, http.StatusBadGateway, 100)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	if _, err := client.GetSong(context.Background(), 1); err == nil {
+		t.Fatal("expected an error after retries are exhausted")
+	}
+}
+
+func TestOnRateLimitedAborts(t *testing.T) {
+	server := newFlakyServer(t, http.StatusTooManyRequests, 100)
+
+	errGiveUp := errors.New("giving up")
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL),
+		genius.WithOnRateLimited(func(ctx context.Context, info genius.RetryInfo) error {
+			return errGiveUp
+		}),
+	)
+
+	if _, err := client.GetSong(context.Background(), 1); !errors.Is(err, errGiveUp) {
+		t.Fatalf("expected the hook's error, got %v", err)
+	}
+}
@@ -0,0 +1,139 @@
+package genius_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+// entityJSON returns the JSON object stored under key in the response payload of the fixture file.
+func entityJSON(t *testing.T, file string, key string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var envelope struct {
+		Response map[string]json.RawMessage `json:"response"`
+	}
+	if err = json.Unmarshal(data, &envelope); err != nil {
+		t.Fatal(err)
+	}
+
+	return envelope.Response[key]
+}
+
+// assertJSONEqual compares JSON documents ignoring formatting and key order.
+func assertJSONEqual(t *testing.T, want []byte, got []byte) {
+	t.Helper()
+
+	decode := func(data []byte) any {
+		var v any
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&v); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, data)
+		}
+		return v
+	}
+
+	if !reflect.DeepEqual(decode(want), decode(got)) {
+		t.Fatalf("JSON differs\nwant: %s\ngot:  %s", want, got)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		file string
+		key  string
+		v    any
+	}{
+		{"testdata/song.json", "song", &genius.Song{}},
+		{"testdata/album.json", "album", &genius.Album{}},
+		{"testdata/artist.json", "artist", &genius.Artist{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			raw := entityJSON(t, tt.file, tt.key)
+			if err := json.Unmarshal(raw, tt.v); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := json.Marshal(tt.v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertJSONEqual(t, raw, got)
+
+			// A second pass through a fresh value must be stable as well.
+			again := reflect.New(reflect.TypeOf(tt.v).Elem()).Interface()
+			if err = json.Unmarshal(got, again); err != nil {
+				t.Fatal(err)
+			}
+			gotAgain, err := json.Marshal(again)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertJSONEqual(t, raw, gotAgain)
+		})
+	}
+}
+
+func TestRoundTripKeepsChanges(t *testing.T) {
+	var song genius.Song
+	if err := json.Unmarshal(entityJSON(t, "testdata/song.json", "song"), &song); err != nil {
+		t.Fatal(err)
+	}
+
+	song.Title = "HUMBLE. (Remix)"
+	song.Lyrics = "Sit down"
+	song.Stats.Hot = true
+	song.Album.Artist.Name = "K.Dot"
+
+	data, err := json.Marshal(&song)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded map[string]any
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded["title"] != "HUMBLE. (Remix)" || decoded["lyrics"] != "Sit down" {
+		t.Errorf("changed fields not encoded: title %v, lyrics %v", decoded["title"], decoded["lyrics"])
+	}
+	if hot := decoded["stats"].(map[string]any)["hot"]; hot != true {
+		t.Errorf("changed nested field not encoded: hot %v", hot)
+	}
+	if name := decoded["album"].(map[string]any)["artist"].(map[string]any)["name"]; name != "K.Dot" {
+		t.Errorf("changed nested model not encoded: name %v", name)
+	}
+	if _, ok := decoded["a_field_added_later"]; !ok {
+		t.Error("unknown field dropped")
+	}
+}
+
+func TestMarshalWithoutRaw(t *testing.T) {
+	song := genius.Song{ID: 1, Title: "Constructed"}
+
+	data, err := json.Marshal(song)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded genius.Song
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.ID != 1 || decoded.Title != "Constructed" {
+		t.Fatalf("unexpected song %+v", decoded)
+	}
+}
@@ -0,0 +1,75 @@
+package genius
+
+import (
+	"regexp"
+	"strings"
+)
+
+// LyricsSection is a part of a song's lyrics, e.g. a verse, started by a header like "[Verse 1: Kendrick Lamar]".
+type LyricsSection struct {
+	// Header is the header without brackets, e.g. "Verse 1: Kendrick Lamar". It is empty for lines before the first
+	// header.
+	Header string `json:"header,omitempty"`
+	// Type is the header without its number and artists, e.g. "Verse".
+	Type string `json:"type,omitempty"`
+	// Artists are the artists the header credits after a colon.
+	Artists []string `json:"artists,omitempty"`
+	// Lines are the non-empty lines of the section.
+	Lines []string `json:"lines"`
+}
+
+var (
+	sectionHeaderPattern = regexp.MustCompile(`^\[([^\]]+)\]$`)
+	sectionNumberPattern = regexp.MustCompile(`\s+\d+$`)
+	artistSeparators     = regexp.MustCompile(`\s*[,&]\s*`)
+)
+
+// ParseSections splits lyrics as returned by GetLyrics into their sections. Lyrics without headers are a single
+// section without a header.
+func ParseSections(lyrics string) []LyricsSection {
+	var sections []LyricsSection
+	for _, line := range strings.Split(lyrics, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if match := sectionHeaderPattern.FindStringSubmatch(line); match != nil {
+			sections = append(sections, parseSectionHeader(strings.TrimSpace(match[1])))
+			continue
+		}
+
+		if len(sections) == 0 {
+			sections = append(sections, LyricsSection{})
+		}
+		current := &sections[len(sections)-1]
+		current.Lines = append(current.Lines, line)
+	}
+
+	return sections
+}
+
+func parseSectionHeader(header string) LyricsSection {
+	section := LyricsSection{Header: header}
+
+	name, artists, found := strings.Cut(header, ":")
+	section.Type = sectionNumberPattern.ReplaceAllString(strings.TrimSpace(name), "")
+	if found {
+		for _, artist := range artistSeparators.Split(strings.TrimSpace(artists), -1) {
+			if artist != "" {
+				section.Artists = append(section.Artists, artist)
+			}
+		}
+	}
+
+	return section
+}
+
+// CleanLyrics returns lyrics without section headers and empty lines, e.g. as text for language models.
+func CleanLyrics(lyrics string) string {
+	var lines []string
+	for _, section := range ParseSections(lyrics) {
+		lines = append(lines, section.Lines...)
+	}
+	return strings.Join(lines, "\n")
+}
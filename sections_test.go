@@ -0,0 +1,40 @@
+package genius_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+const sectionedLyrics = `Intro line
+
+[Verse 1: Kendrick Lamar]
+Wicked or weakness?
+You gotta see this
+
+[Chorus: Kendrick Lamar & Zacari, Rihanna]
+Love, let's talk about love
+
+[Outro]
+`
+
+func TestParseSections(t *testing.T) {
+	want := []genius.LyricsSection{
+		{Lines: []string{"Intro line"}},
+		{Header: "Verse 1: Kendrick Lamar", Type: "Verse", Artists: []string{"Kendrick Lamar"}, Lines: []string{"Wicked or weakness?", "You gotta see this"}},
+		{Header: "Chorus: Kendrick Lamar & Zacari, Rihanna", Type: "Chorus", Artists: []string{"Kendrick Lamar", "Zacari", "Rihanna"}, Lines: []string{"Love, let's talk about love"}},
+		{Header: "Outro", Type: "Outro"},
+	}
+
+	if got := genius.ParseSections(sectionedLyrics); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestCleanLyrics(t *testing.T) {
+	want := "Intro line\nWicked or weakness?\nYou gotta see this\nLove, let's talk about love"
+	if got := genius.CleanLyrics(sectionedLyrics); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
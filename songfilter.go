@@ -0,0 +1,127 @@
+package genius
+
+import (
+	"iter"
+	"slices"
+	"strings"
+	"time"
+)
+
+// songFilter filters the songs of an artist, listed in sort order with pages of perPage songs, according to the
+// filters of ArtistSongsOptions.
+type songFilter struct {
+	opts    ArtistSongsOptions
+	sort    Sort
+	perPage int
+	// below counts the consecutive songs below MinPageviews.
+	below int
+	// released is the release date of the last dated song, descending reports whether songs are listed newest first
+	// once two dates differed.
+	released   time.Time
+	descending *bool
+}
+
+// filtered reports whether the options filter songs.
+func (o *ArtistSongsOptions) filtered() bool {
+	return o != nil && (o.MinPageviews > 0 || !o.From.IsZero() || !o.To.IsZero() || len(o.Languages) > 0)
+}
+
+func newSongFilter(opts *ArtistSongsOptions, sort Sort, perPage int) *songFilter {
+	return &songFilter{opts: *opts, sort: sort, perPage: perPage}
+}
+
+// check reports whether song matches the options, and whether the songs following it in the sort order can't match
+// anymore.
+func (f *songFilter) check(song *Song) (keep bool, done bool) {
+	if !f.opts.From.IsZero() || !f.opts.To.IsZero() {
+		if keep, done = f.checkReleased(song); !keep {
+			return false, done
+		}
+	}
+
+	if f.opts.MinPageviews > 0 {
+		if song.Pageviews() < f.opts.MinPageviews {
+			// The popularity order roughly follows page views, a page of songs below the threshold ends the list.
+			f.below++
+			return false, f.sort == SortPopularity && f.below >= f.perPage
+		}
+		f.below = 0
+	}
+
+	return len(f.opts.Languages) == 0 || inLanguages(song, f.opts.Languages), false
+}
+
+// inLanguages reports whether song is in one of languages.
+func inLanguages(song *Song, languages []string) bool {
+	return song.Language != "" && slices.ContainsFunc(languages, func(language string) bool {
+		return strings.EqualFold(language, song.Language)
+	})
+}
+
+// checkReleased checks the release date of song against From and To.
+func (f *songFilter) checkReleased(song *Song) (keep bool, done bool) {
+	released, ok := song.Released()
+	if !ok {
+		return false, false
+	}
+
+	if f.sort == SortReleaseDate {
+		// The direction of the order is taken from the songs rather than assumed.
+		if f.descending == nil && !f.released.IsZero() && !released.Equal(f.released) {
+			descending := released.Before(f.released)
+			f.descending = &descending
+		}
+		f.released = released
+	}
+
+	before := !f.opts.From.IsZero() && released.Before(f.opts.From)
+	after := !f.opts.To.IsZero() && released.After(f.opts.To)
+	if !before && !after {
+		return true, false
+	}
+	return false, f.descending != nil && ((*f.descending && before) || (!*f.descending && after))
+}
+
+// Released returns the date the song was released on as midnight UTC, the first month or day of the year or month
+// for partial release dates. It reports false if the release date is unknown.
+func (s *Song) Released() (time.Time, bool) {
+	if released, err := time.Parse(time.DateOnly, s.ReleaseDate); err == nil {
+		return released, true
+	}
+	if c := s.ReleaseDateComponents; c != nil && c.Year > 0 {
+		return time.Date(c.Year, time.Month(max(c.Month, 1)), max(c.Day, 1), 0, 0, 0, 0, time.UTC), true
+	}
+	return time.Time{}, false
+}
+
+// filterSongs filters songs, fetched according to opts, counting MaxItems after filtering.
+func filterSongs(songs func(opts *ListOptions) iter.Seq2[*Song, error], opts *ArtistSongsOptions) iter.Seq2[*Song, error] {
+	listOpts := opts.ListOptions
+	maxItems := listOpts.MaxItems
+	listOpts.MaxItems = 0
+
+	return func(yield func(*Song, error) bool) {
+		// The filter tracks the songs walked so far, every walk needs its own.
+		filter := newSongFilter(opts, opts.sort(), listOpts.perPage(defaultPerPage))
+		count := 0
+		for song, err := range songs(&listOpts) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			keep, done := filter.check(song)
+			if done {
+				return
+			}
+			if !keep {
+				continue
+			}
+			if !yield(song, nil) {
+				return
+			}
+			if count++; maxItems > 0 && count >= maxItems {
+				return
+			}
+		}
+	}
+}
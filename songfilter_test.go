@@ -0,0 +1,258 @@
+package genius_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/natecham/genius"
+)
+
+// newPopularSongsServer serves count songs in popularity order, the first popular ones with page views and the rest
+// stubs without.
+func newPopularSongsServer(t *testing.T, count int, popular int) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+
+		songs := []map[string]any{}
+		for id := (page-1)*perPage + 1; id <= page*perPage && id <= count; id++ {
+			song := map[string]any{"id": id, "stats": map[string]any{}}
+			if id <= popular {
+				song["stats"] = map[string]any{"pageviews": (count - id) * 1000}
+			}
+			songs = append(songs, song)
+		}
+		var next any
+		if page*perPage < count {
+			next = page + 1
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"meta":     map[string]any{"status": 200},
+			"response": map[string]any{"songs": songs, "next_page": next},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestArtistSongsMinPageviews(t *testing.T) {
+	tests := []struct {
+		name     string
+		sort     genius.Sort
+		maxItems int
+		want     int
+		requests int32
+	}{
+		{"popularity stops early", genius.SortPopularity, 0, 25, 4},
+		{"popularity with max items", genius.SortPopularity, 5, 5, 1},
+		{"title fetches all", genius.SortTitle, 0, 25, 20},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, requests := newPopularSongsServer(t, 200, 25)
+			client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+			opts := &genius.ArtistSongsOptions{Sort: test.sort, MinPageviews: 1}
+			opts.PerPage = 10
+			opts.MaxItems = test.maxItems
+			got := 0
+			for song, err := range client.ArtistSongs(context.Background(), 1, opts) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if song.Pageviews() < 1 {
+					t.Errorf("got song %d without page views", song.ID)
+				}
+				got++
+			}
+			if got != test.want {
+				t.Errorf("got %d songs, want %d", got, test.want)
+			}
+			if n := atomic.LoadInt32(requests); n != test.requests {
+				t.Errorf("got %d requests, want %d", n, test.requests)
+			}
+		})
+	}
+}
+
+func TestArtistSongsMinPageviewsRangedTwice(t *testing.T) {
+	// The first song is a stub, the popular ones follow it.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		songs := []map[string]any{}
+		for id := (page-1)*10 + 1; id <= page*10; id++ {
+			song := map[string]any{"id": id, "stats": map[string]any{}}
+			if id > 1 && id <= 25 {
+				song["stats"] = map[string]any{"pageviews": 1000}
+			}
+			songs = append(songs, song)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"meta":     map[string]any{"status": 200},
+			"response": map[string]any{"songs": songs, "next_page": page + 1},
+		})
+	}))
+	t.Cleanup(server.Close)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	opts := &genius.ArtistSongsOptions{Sort: genius.SortPopularity, MinPageviews: 1}
+	opts.PerPage = 10
+	songs := client.ArtistSongs(context.Background(), 1, opts)
+	for i := range 2 {
+		got := 0
+		for _, err := range songs {
+			if err != nil {
+				t.Fatal(err)
+			}
+			got++
+		}
+		if got != 24 {
+			t.Errorf("range %d: got %d songs, want 24", i+1, got)
+		}
+	}
+}
+
+func TestStreamArtistSongsMinPageviews(t *testing.T) {
+	server, requests := newPopularSongsServer(t, 200, 25)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	opts := &genius.ArtistSongsOptions{Sort: genius.SortPopularity, MinPageviews: 1}
+	opts.PerPage = 10
+	got := 0
+	for result := range client.StreamArtistSongs(context.Background(), 1, opts) {
+		if result.Err != nil {
+			t.Fatal(result.Err)
+		}
+		got++
+	}
+	if got != 25 {
+		t.Errorf("got %d songs, want 25", got)
+	}
+	if n := atomic.LoadInt32(requests); n > 5 {
+		t.Errorf("got %d requests, want fetching to stop after the popular songs", n)
+	}
+}
+
+// newReleasedSongsServer serves count songs released a day apart from 2020-01-01 in the order of sort=release_date,
+// newest first if descending. Every tenth song has no release date.
+func newReleasedSongsServer(t *testing.T, count int, descending bool) (*httptest.Server, *int32) {
+	t.Helper()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if sort := r.URL.Query().Get("sort"); sort != "release_date" {
+			t.Errorf("got sort %q, want release_date", sort)
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+
+		songs := []map[string]any{}
+		for i := (page - 1) * perPage; i < page*perPage && i < count; i++ {
+			day := i
+			if descending {
+				day = count - 1 - i
+			}
+			song := map[string]any{"id": day + 1}
+			if day%10 != 9 {
+				song["release_date"] = time.Date(2020, 1, 1+day, 0, 0, 0, 0, time.UTC).Format(time.DateOnly)
+			}
+			songs = append(songs, song)
+		}
+		var next any
+		if page*perPage < count {
+			next = page + 1
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"meta":     map[string]any{"status": 200},
+			"response": map[string]any{"songs": songs, "next_page": next},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func TestArtistSongsReleased(t *testing.T) {
+	from := time.Date(2020, 1, 11, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2020, 1, 30, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		descending bool
+		from, to   time.Time
+		want       int
+		requests   int32
+	}{
+		{"oldest first", false, from, to, 18, 4},
+		{"newest first", true, from, to, 18, 20},
+		{"since", true, time.Date(2020, 7, 1, 0, 0, 0, 0, time.UTC), time.Time{}, 16, 2},
+		{"until", false, time.Time{}, to, 27, 4},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, requests := newReleasedSongsServer(t, 200, test.descending)
+			client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+			opts := &genius.ArtistSongsOptions{From: test.from, To: test.to}
+			opts.PerPage = 10
+			got := 0
+			for song, err := range client.ArtistSongs(context.Background(), 1, opts) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				released, ok := song.Released()
+				if !ok || (!test.from.IsZero() && released.Before(test.from)) || (!test.to.IsZero() && released.After(test.to)) {
+					t.Errorf("got song %d released %s", song.ID, song.ReleaseDate)
+				}
+				got++
+			}
+			if got != test.want {
+				t.Errorf("got %d songs, want %d", got, test.want)
+			}
+			if n := atomic.LoadInt32(requests); n != test.requests {
+				t.Errorf("got %d requests, want %d", n, test.requests)
+			}
+		})
+	}
+}
+
+func TestArtistSongsLanguages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		songs := `[]`
+		if r.URL.Query().Get("page") == "1" {
+			songs = `[
+				{"id": 1, "title": "Alors on danse", "language": "fr"},
+				{"id": 2, "title": "Alors on danse (English Translation)", "language": "en"},
+				{"id": 3, "title": "Papaoutai", "language": "fr"},
+				{"id": 4, "title": "Untitled"}
+			]`
+		}
+		_, _ = w.Write([]byte(`{"meta": {"status": 200}, "response": {"songs": ` + songs + `, "next_page": null}}`))
+	}))
+	defer server.Close()
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	var ids []int
+	opts := &genius.ArtistSongsOptions{Languages: []string{"FR", "nl"}}
+	for song, err := range client.ArtistSongs(context.Background(), 1, opts) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, song.ID)
+	}
+	if want := []int{1, 3}; !slices.Equal(ids, want) {
+		t.Errorf("got songs %v, want %v", ids, want)
+	}
+}
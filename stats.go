@@ -0,0 +1,62 @@
+package genius
+
+import (
+	"context"
+	"sort"
+)
+
+// IsHot reports whether Genius currently flags the song as hot, trending by its recent page views. It is false for
+// nil stats, so it can be called on the stats of songs fetched without them.
+func (s *Stats) IsHot() bool {
+	return s != nil && s.Hot
+}
+
+// IsHot reports whether Genius currently flags the song as hot, see Stats.IsHot.
+func (s *Song) IsHot() bool {
+	return s.Stats.IsHot()
+}
+
+// Pageviews returns the song's page views, 0 if they are unknown.
+func (s *Song) Pageviews() int {
+	if s.Stats == nil {
+		return 0
+	}
+	return s.Stats.Pageviews
+}
+
+// SortSongsByPageviews sorts songs by page views, most viewed first. Songs with equal page views keep their order.
+func SortSongsByPageviews(songs []*Song) {
+	sort.SliceStable(songs, func(i, j int) bool {
+		return songs[i].Pageviews() > songs[j].Pageviews()
+	})
+}
+
+// SortAlbumsByPageviews sorts albums by the accumulated page views of their songs, most viewed first.
+func SortAlbumsByPageviews(albums []*Album) {
+	sort.SliceStable(albums, func(i, j int) bool {
+		return albums[i].SongPageviews > albums[j].SongPageviews
+	})
+}
+
+// GetArtistTopSongs returns the n songs of an artist with the most page views, most viewed first, or all its songs
+// if n isn't positive.
+//
+// Genius's popularity order isn't strictly by page views, so all songs of the artist are fetched and sorted, see
+// WithConcurrency to fetch their pages in parallel. That is a request per 50 songs whatever n is, dozens for prolific
+// artists. GetArtistSongsByPopularity, or ArtistSongs with MinPageviews, fetch only the first songs of the popularity
+// order instead, which are close to the most viewed ones.
+func (c *Client) GetArtistTopSongs(ctx context.Context, id int, n int) ([]*Song, error) {
+	var songs []*Song
+	for song, err := range c.ArtistSongs(ctx, id, &ArtistSongsOptions{Sort: SortPopularity}) {
+		if err != nil {
+			return nil, err
+		}
+		songs = append(songs, song)
+	}
+
+	SortSongsByPageviews(songs)
+	if n > 0 && len(songs) > n {
+		songs = songs[:n]
+	}
+	return songs, nil
+}
@@ -0,0 +1,70 @@
+package genius_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+func TestGetArtistTopSongs(t *testing.T) {
+	const count = 60
+	// Page views peak at song 54, on the second page of the popularity order.
+	pageviews := func(id int) int { return id % 55 * 100 }
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if sort := r.URL.Query().Get("sort"); sort != "popularity" {
+			t.Errorf("got sort %q, want popularity", sort)
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+
+		songs := []map[string]any{}
+		for id := (page-1)*perPage + 1; id <= page*perPage && id <= count; id++ {
+			songs = append(songs, map[string]any{"id": id, "stats": map[string]any{"pageviews": pageviews(id), "hot": id == 54}})
+		}
+		var next any
+		if page*perPage < count {
+			next = page + 1
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"meta":     map[string]any{"status": 200},
+			"response": map[string]any{"songs": songs, "next_page": next},
+		})
+	}))
+	defer server.Close()
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	songs, err := client.GetArtistTopSongs(context.Background(), 1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []int
+	for _, song := range songs {
+		ids = append(ids, song.ID)
+	}
+	if want := []int{54, 53, 52}; !slices.Equal(ids, want) {
+		t.Errorf("got top songs %v, want %v", ids, want)
+	}
+	if !songs[0].IsHot() || songs[1].Stats.IsHot() {
+		t.Error("expected only the top song to be hot")
+	}
+
+	all, err := client.GetArtistTopSongs(context.Background(), 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != count || !slices.IsSortedFunc(all, func(a, b *genius.Song) int { return b.Pageviews() - a.Pageviews() }) {
+		t.Errorf("got %d songs, want all %d sorted by page views", len(all), count)
+	}
+
+	var stats *genius.Stats
+	if stats.IsHot() {
+		t.Error("nil stats reported as hot")
+	}
+}
@@ -0,0 +1,474 @@
+// Package bolt implements store.Store on an embedded bbolt database, a pure Go alternative to store/sqlite for
+// builds without cgo.
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/store"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Buckets of the database. Entities are stored as JSON by ID, the *ByArtist buckets index them by artist with keys
+// made of the artist ID followed by the entity ID. Previous versions of lyrics are keyed by song ID followed by a
+// sequence number. Canonical songs are stored by the ID of their duplicates, which are indexed by canonical song with
+// keys made of the canonical song ID followed by the duplicate's. The full-text index of lyrics has keys made of a term, a zero byte and the ID of a song with the
+// term in its lyrics.
+var (
+	songsBucket          = []byte("songs")
+	albumsBucket         = []byte("albums")
+	artistsBucket        = []byte("artists")
+	lyricsBucket         = []byte("lyrics")
+	lyricsHistoryBucket  = []byte("lyrics_history")
+	lyricsTermsBucket    = []byte("lyrics_terms")
+	canonicalBucket      = []byte("canonical")
+	duplicatesBucket     = []byte("duplicates")
+	songsByArtistBucket  = []byte("songs_by_artist")
+	albumsByArtistBucket = []byte("albums_by_artist")
+)
+
+// Store is a store.Store backed by a bbolt database.
+type Store struct {
+	db *bolt.DB
+}
+
+var _ store.Store = (*Store)(nil)
+
+// Open opens the database file at path, creating it if needed. The file is locked while it is open.
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{
+			songsBucket, albumsBucket, artistsBucket, lyricsBucket, lyricsHistoryBucket, songsByArtistBucket, albumsByArtistBucket,
+			canonicalBucket, duplicatesBucket,
+		} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+
+		// Lyrics stored before the full-text index existed are indexed when it is created.
+		if tx.Bucket(lyricsTermsBucket) != nil {
+			return nil
+		}
+		terms, err := tx.CreateBucket(lyricsTermsBucket)
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(lyricsBucket).ForEach(func(k []byte, data []byte) error {
+			var record lyricsRecord
+			if err := json.Unmarshal(data, &record); err != nil {
+				return err
+			}
+			for _, k := range termKeys(int(binary.BigEndian.Uint64(k)), record.Lyrics) {
+				if err := terms.Put(k, nil); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// lyricsRecord is how lyrics are stored.
+type lyricsRecord struct {
+	Lyrics    string    `json:"lyrics"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// SaveSong stores song and indexes it by its primary artists.
+func (s *Store) SaveSong(ctx context.Context, song *genius.Song) error {
+	data, err := json.Marshal(song)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		songs := tx.Bucket(songsBucket)
+		index := tx.Bucket(songsByArtistBucket)
+
+		if previous := songs.Get(key(song.ID)); previous != nil {
+			var old genius.Song
+			if err := json.Unmarshal(previous, &old); err != nil {
+				return err
+			}
+			for _, artistID := range store.SongArtistIDs(&old) {
+				if err := index.Delete(indexKey(artistID, song.ID)); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, artistID := range store.SongArtistIDs(song) {
+			if err := index.Put(indexKey(artistID, song.ID), nil); err != nil {
+				return err
+			}
+		}
+
+		return songs.Put(key(song.ID), data)
+	})
+}
+
+// SaveAlbum stores album and indexes it by its artist.
+func (s *Store) SaveAlbum(ctx context.Context, album *genius.Album) error {
+	data, err := json.Marshal(album)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		albums := tx.Bucket(albumsBucket)
+		index := tx.Bucket(albumsByArtistBucket)
+
+		if previous := albums.Get(key(album.ID)); previous != nil {
+			var old genius.Album
+			if err := json.Unmarshal(previous, &old); err != nil {
+				return err
+			}
+			if err := index.Delete(indexKey(store.AlbumArtistID(&old), album.ID)); err != nil {
+				return err
+			}
+		}
+
+		if err := index.Put(indexKey(store.AlbumArtistID(album), album.ID), nil); err != nil {
+			return err
+		}
+
+		return albums.Put(key(album.ID), data)
+	})
+}
+
+// SaveArtist stores artist.
+func (s *Store) SaveArtist(ctx context.Context, artist *genius.Artist) error {
+	data, err := json.Marshal(artist)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(artistsBucket).Put(key(artist.ID), data)
+	})
+}
+
+// SaveLyrics stores the lyrics of a song, moving different stored lyrics to its history.
+func (s *Store) SaveLyrics(ctx context.Context, songID int, lyrics string) error {
+	data, err := json.Marshal(lyricsRecord{Lyrics: lyrics, FetchedAt: time.Now().UTC()})
+	if err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		current := tx.Bucket(lyricsBucket)
+		terms := tx.Bucket(lyricsTermsBucket)
+
+		if previous := current.Get(key(songID)); previous != nil {
+			var old lyricsRecord
+			if err := json.Unmarshal(previous, &old); err != nil {
+				return err
+			}
+			if old.Lyrics == lyrics {
+				return current.Put(key(songID), data)
+			}
+
+			history := tx.Bucket(lyricsHistoryBucket)
+			seq, err := history.NextSequence()
+			if err != nil {
+				return err
+			}
+			if err = history.Put(append(key(songID), key(int(seq))...), previous); err != nil {
+				return err
+			}
+
+			for _, k := range termKeys(songID, old.Lyrics) {
+				if err = terms.Delete(k); err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, k := range termKeys(songID, lyrics) {
+			if err := terms.Put(k, nil); err != nil {
+				return err
+			}
+		}
+		return current.Put(key(songID), data)
+	})
+}
+
+// Song returns the song with ID id.
+func (s *Store) Song(ctx context.Context, id int) (*genius.Song, error) {
+	return get[genius.Song](s.db, songsBucket, id)
+}
+
+// Album returns the album with ID id.
+func (s *Store) Album(ctx context.Context, id int) (*genius.Album, error) {
+	return get[genius.Album](s.db, albumsBucket, id)
+}
+
+// Artist returns the artist with ID id.
+func (s *Store) Artist(ctx context.Context, id int) (*genius.Artist, error) {
+	return get[genius.Artist](s.db, artistsBucket, id)
+}
+
+// Lyrics returns the lyrics of the song with ID songID.
+func (s *Store) Lyrics(ctx context.Context, songID int) (string, error) {
+	record, err := get[lyricsRecord](s.db, lyricsBucket, songID)
+	if err != nil {
+		return "", err
+	}
+	return record.Lyrics, nil
+}
+
+// LyricsVersions returns the versions of the lyrics of the song with ID songID, oldest first.
+func (s *Store) LyricsVersions(ctx context.Context, songID int) ([]store.LyricsVersion, error) {
+	var versions []store.LyricsVersion
+	err := s.db.View(func(tx *bolt.Tx) error {
+		current := tx.Bucket(lyricsBucket).Get(key(songID))
+		if current == nil {
+			return store.ErrNotFound
+		}
+
+		prefix := key(songID)
+		cursor := tx.Bucket(lyricsHistoryBucket).Cursor()
+		for k, data := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, data = cursor.Next() {
+			version, err := lyricsVersion(data)
+			if err != nil {
+				return err
+			}
+			versions = append(versions, version)
+		}
+
+		version, err := lyricsVersion(current)
+		if err != nil {
+			return err
+		}
+		versions = append(versions, version)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+func lyricsVersion(data []byte) (store.LyricsVersion, error) {
+	var record lyricsRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return store.LyricsVersion{}, err
+	}
+	return store.LyricsVersion{Lyrics: record.Lyrics, FetchedAt: record.FetchedAt}, nil
+}
+
+// SearchLyrics returns the songs whose lyrics contain phrase. The full-text index finds the lyrics containing all
+// terms of the phrase, which are then checked to contain them in a row on a single line.
+func (s *Store) SearchLyrics(ctx context.Context, phrase string) ([]store.LyricsMatch, error) {
+	terms := store.Terms(phrase)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	var matches []store.LyricsMatch
+	err := s.db.View(func(tx *bolt.Tx) error {
+		index := tx.Bucket(lyricsTermsBucket)
+		lyrics := tx.Bucket(lyricsBucket)
+
+		prefix := termPrefix(terms[0])
+		cursor := index.Cursor()
+		for k, _ := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = cursor.Next() {
+			songID := k[len(prefix):]
+			if !hasTerms(index, songID, terms[1:]) {
+				continue
+			}
+
+			var record lyricsRecord
+			if err := json.Unmarshal(lyrics.Get(songID), &record); err != nil {
+				return err
+			}
+			if line, ok := store.MatchLine(record.Lyrics, phrase); ok {
+				matches = append(matches, store.LyricsMatch{SongID: int(binary.BigEndian.Uint64(songID)), Line: line})
+			}
+		}
+		return nil
+	})
+	return matches, err
+}
+
+// hasTerms reports whether the lyrics of the song with the encoded ID are indexed by all terms.
+func hasTerms(index *bolt.Bucket, songID []byte, terms []string) bool {
+	for _, term := range terms {
+		if index.Get(append(termPrefix(term), songID...)) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// SetCanonical records the canonical song of a song.
+func (s *Store) SetCanonical(ctx context.Context, songID int, canonicalID int) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		canonical := tx.Bucket(canonicalBucket)
+		duplicates := tx.Bucket(duplicatesBucket)
+
+		if previous := canonical.Get(key(songID)); previous != nil {
+			if err := duplicates.Delete(indexKey(int(binary.BigEndian.Uint64(previous)), songID)); err != nil {
+				return err
+			}
+		}
+
+		if songID == canonicalID {
+			return canonical.Delete(key(songID))
+		}
+		if err := duplicates.Put(indexKey(canonicalID, songID), nil); err != nil {
+			return err
+		}
+		return canonical.Put(key(songID), key(canonicalID))
+	})
+}
+
+// Canonical returns the ID of the canonical song of a song.
+func (s *Store) Canonical(ctx context.Context, songID int) (int, error) {
+	canonicalID := songID
+	err := s.db.View(func(tx *bolt.Tx) error {
+		if data := tx.Bucket(canonicalBucket).Get(key(songID)); data != nil {
+			canonicalID = int(binary.BigEndian.Uint64(data))
+		}
+		return nil
+	})
+	return canonicalID, err
+}
+
+// Duplicates returns the IDs of the songs duplicating a song.
+func (s *Store) Duplicates(ctx context.Context, canonicalID int) ([]int, error) {
+	var ids []int
+	err := s.db.View(func(tx *bolt.Tx) error {
+		prefix := key(canonicalID)
+		cursor := tx.Bucket(duplicatesBucket).Cursor()
+		for k, _ := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = cursor.Next() {
+			ids = append(ids, int(binary.BigEndian.Uint64(k[len(prefix):])))
+		}
+		return nil
+	})
+	return ids, err
+}
+
+// IDs returns the IDs of the stored entities of kind.
+func (s *Store) IDs(ctx context.Context, kind store.Kind) ([]int, error) {
+	buckets := map[store.Kind][]byte{
+		store.KindSong:   songsBucket,
+		store.KindAlbum:  albumsBucket,
+		store.KindArtist: artistsBucket,
+		store.KindLyrics: lyricsBucket,
+	}
+	bucket, ok := buckets[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", store.ErrUnknownKind, kind)
+	}
+
+	var ids []int
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).ForEach(func(k []byte, _ []byte) error {
+			ids = append(ids, int(binary.BigEndian.Uint64(k)))
+			return nil
+		})
+	})
+	return ids, err
+}
+
+// SongsByArtist returns the songs with the artist as a primary artist.
+func (s *Store) SongsByArtist(ctx context.Context, artistID int) ([]*genius.Song, error) {
+	return listByArtist[genius.Song](s.db, songsByArtistBucket, songsBucket, artistID)
+}
+
+// AlbumsByArtist returns the albums of the artist.
+func (s *Store) AlbumsByArtist(ctx context.Context, artistID int) ([]*genius.Album, error) {
+	return listByArtist[genius.Album](s.db, albumsByArtistBucket, albumsBucket, artistID)
+}
+
+// key encodes an ID so that keys sort by ID.
+func key(id int) []byte {
+	return binary.BigEndian.AppendUint64(nil, uint64(id))
+}
+
+func indexKey(artistID int, id int) []byte {
+	return append(key(artistID), key(id)...)
+}
+
+func termPrefix(term string) []byte {
+	return append([]byte(term), 0)
+}
+
+// termKeys returns the keys indexing the song by the distinct terms of its lyrics.
+func termKeys(songID int, lyrics string) [][]byte {
+	var keys [][]byte
+	seen := make(map[string]bool)
+	for _, term := range store.Terms(lyrics) {
+		if !seen[term] {
+			seen[term] = true
+			keys = append(keys, append(termPrefix(term), key(songID)...))
+		}
+	}
+	return keys
+}
+
+func get[T any](db *bolt.DB, bucket []byte, id int) (*T, error) {
+	var v *T
+	err := db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(bucket).Get(key(id))
+		if data == nil {
+			return store.ErrNotFound
+		}
+
+		v = new(T)
+		return json.Unmarshal(data, v)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// listByArtist returns the entities of bucket listed for the artist in the index bucket, ordered by ID.
+func listByArtist[T any](db *bolt.DB, index []byte, bucket []byte, artistID int) ([]*T, error) {
+	var values []*T
+	err := db.View(func(tx *bolt.Tx) error {
+		entities := tx.Bucket(bucket)
+		prefix := key(artistID)
+
+		cursor := tx.Bucket(index).Cursor()
+		for k, _ := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = cursor.Next() {
+			data := entities.Get(k[len(prefix):])
+			if data == nil {
+				continue
+			}
+
+			v := new(T)
+			if err := json.Unmarshal(data, v); err != nil {
+				return err
+			}
+			values = append(values, v)
+		}
+		return nil
+	})
+	return values, err
+}
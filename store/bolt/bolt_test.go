@@ -0,0 +1,20 @@
+package bolt_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/natecham/genius/store"
+	"github.com/natecham/genius/store/bolt"
+	"github.com/natecham/genius/store/storetest"
+)
+
+func TestStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.Store {
+		s, err := bolt.Open(filepath.Join(t.TempDir(), "genius.bolt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	})
+}
@@ -0,0 +1,42 @@
+package store_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/store/bolt"
+	"github.com/natecham/genius/store/storetest"
+)
+
+func TestStoreAsCache(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	s, err := bolt.Open(filepath.Join(t.TempDir(), "genius.bolt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	if err = s.SaveSong(ctx, storetest.Song(t, 1, "HUMBLE.", 1421)); err != nil {
+		t.Fatal(err)
+	}
+
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL), genius.WithCache(s))
+	song, err := client.GetSong(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if song.Title != "HUMBLE." {
+		t.Errorf("unexpected song %+v", song)
+	}
+}
@@ -0,0 +1,173 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/natecham/genius"
+)
+
+// CanonicalizeReport describes the duplicates Canonicalize found.
+type CanonicalizeReport struct {
+	// Songs is the number of stored songs.
+	Songs int
+	// Groups maps the IDs of canonical songs to the IDs of their duplicates, for songs with duplicates.
+	Groups map[int][]int
+}
+
+// Canonicalize groups the stored songs that are versions of the same song and records a canonical song for each
+// group, see Store.SetCanonical. Songs are grouped when they
+//
+//   - are translations of one another, see genius.Song.TranslationSongs, or
+//   - share a primary artist and have the same title after genius.NormalizeTitle, and are on the same album, were
+//     released at most releaseTolerance apart or one's title marks it as a version, e.g. remasters and reissues.
+//     Songs that only share a common title, such as the intros of two albums, are kept apart.
+//
+// The canonical song of a group is the one with the lowest ID that isn't a translation. Duplicates stay stored, only
+// their canonical song is recorded, and running Canonicalize again updates the groups for songs stored since.
+func Canonicalize(ctx context.Context, s Store) (*CanonicalizeReport, error) {
+	ids, err := s.IDs(ctx, KindSong)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := newUnionFind(ids)
+	translations := make(map[int]bool)
+	byTitle := make(map[string][]int)
+	versions := make(map[int]songVersion)
+
+	for _, id := range ids {
+		song, err := s.Song(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("song %d: %w", id, err)
+		}
+
+		for _, translation := range song.TranslationSongs {
+			if groups.contains(translation.ID) && translation.ID != id {
+				translations[translation.ID] = true
+				groups.union(id, translation.ID)
+			}
+		}
+
+		title := genius.NormalizeTitle(song.Title)
+		if title == "" {
+			continue
+		}
+		version := newSongVersion(song)
+		versions[id] = version
+		for _, artistID := range SongArtistIDs(song) {
+			key := fmt.Sprintf("%d %s", artistID, title)
+			for _, other := range byTitle[key] {
+				if version.sameSong(versions[other]) {
+					groups.union(id, other)
+				}
+			}
+			byTitle[key] = append(byTitle[key], id)
+		}
+	}
+
+	members := make(map[int][]int)
+	for _, id := range ids {
+		root := groups.find(id)
+		members[root] = append(members[root], id)
+	}
+
+	report := &CanonicalizeReport{Songs: len(ids), Groups: make(map[int][]int)}
+	for _, group := range members {
+		slices.Sort(group)
+
+		canonicalID := group[0]
+		for _, id := range group {
+			if !translations[id] {
+				canonicalID = id
+				break
+			}
+		}
+
+		for _, id := range group {
+			if err = s.SetCanonical(ctx, id, canonicalID); err != nil {
+				return nil, err
+			}
+			if id != canonicalID {
+				report.Groups[canonicalID] = append(report.Groups[canonicalID], id)
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// releaseTolerance is how far apart songs with the same title can be released to be grouped, e.g. a single and the
+// album it's taken from.
+const releaseTolerance = 90 * 24 * time.Hour
+
+// songVersion is what Canonicalize compares of songs with the same title.
+type songVersion struct {
+	albumID  int
+	released time.Time
+	// marked is whether the title marks a version, e.g. "Bohemian Rhapsody - Remastered 2011".
+	marked bool
+}
+
+func newSongVersion(song *genius.Song) songVersion {
+	v := songVersion{
+		// genius.NormalizeArtist only drops features, so titles it normalizes differently have a version suffix or
+		// bracket genius.NormalizeTitle drops.
+		marked: genius.NormalizeTitle(song.Title) != genius.NormalizeArtist(song.Title),
+	}
+	if song.Album != nil {
+		v.albumID = song.Album.ID
+	}
+	if released, ok := song.Released(); ok {
+		v.released = released
+	}
+	return v
+}
+
+// sameSong reports whether v and other, songs with the same title, are versions of one song.
+func (v songVersion) sameSong(other songVersion) bool {
+	switch {
+	case v.marked || other.marked:
+		return true
+	case v.albumID != 0 && v.albumID == other.albumID:
+		return true
+	case !v.released.IsZero() && !other.released.IsZero():
+		return v.released.Sub(other.released).Abs() <= releaseTolerance
+	default:
+		return false
+	}
+}
+
+// unionFind is a disjoint-set of song IDs.
+type unionFind struct {
+	parent map[int]int
+}
+
+func newUnionFind(ids []int) *unionFind {
+	parent := make(map[int]int, len(ids))
+	for _, id := range ids {
+		parent[id] = id
+	}
+	return &unionFind{parent: parent}
+}
+
+func (u *unionFind) contains(id int) bool {
+	_, ok := u.parent[id]
+	return ok
+}
+
+func (u *unionFind) find(id int) int {
+	for u.parent[id] != id {
+		u.parent[id] = u.parent[u.parent[id]]
+		id = u.parent[id]
+	}
+	return id
+}
+
+func (u *unionFind) union(a int, b int) {
+	if rootA, rootB := u.find(a), u.find(b); rootA != rootB {
+		u.parent[rootA] = rootB
+	}
+}
@@ -0,0 +1,132 @@
+package store_test
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/store"
+	"github.com/natecham/genius/store/bolt"
+)
+
+// openSongs returns a store of the songs encoded as JSON.
+func openSongs(t *testing.T, songs ...string) store.Store {
+	t.Helper()
+
+	s, err := bolt.Open(filepath.Join(t.TempDir(), "genius.bolt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	for _, data := range songs {
+		var song genius.Song
+		if err = json.Unmarshal([]byte(data), &song); err != nil {
+			t.Fatal(err)
+		}
+		if err = s.SaveSong(context.Background(), &song); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return s
+}
+
+func TestCanonicalize(t *testing.T) {
+	ctx := context.Background()
+
+	s := openSongs(t,
+		`{"id":10,"title":"Bohemian Rhapsody","primary_artist":{"id":1},"translation_songs":[{"id":5},{"id":404}]}`,
+		`{"id":11,"title":"Bohemian Rhapsody - Remastered 2011","primary_artist":{"id":1}}`,
+		`{"id":5,"title":"Queen - Bohemian Rhapsody (Traduction française)","primary_artist":{"id":2}}`,
+		`{"id":12,"title":"Bohemian Rhapsody","primary_artist":{"id":3}}`,
+		`{"id":13,"title":"Don't Stop Me Now","primary_artist":{"id":1}}`,
+	)
+
+	report, err := store.Canonicalize(ctx, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[int][]int{10: {5, 11}}; report.Songs != 5 || !reflect.DeepEqual(report.Groups, want) {
+		t.Errorf("got report %+v, want groups %v", report, want)
+	}
+
+	for songID, want := range map[int]int{5: 10, 10: 10, 11: 10, 12: 12, 13: 13} {
+		canonicalID, err := s.Canonical(ctx, songID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if canonicalID != want {
+			t.Errorf("song %d: got canonical song %d, want %d", songID, canonicalID, want)
+		}
+	}
+
+	// Duplicates are kept.
+	if _, err = s.Song(ctx, 11); err != nil {
+		t.Errorf("duplicate removed: %v", err)
+	}
+}
+
+func TestCanonicalizeSameTitle(t *testing.T) {
+	tests := []struct {
+		name  string
+		songs []string
+		want  map[int][]int
+	}{
+		{
+			name: "intros of different albums",
+			songs: []string{
+				`{"id":1,"title":"Intro","primary_artist":{"id":1},"album":{"id":100},"release_date":"2012-10-22"}`,
+				`{"id":2,"title":"Intro","primary_artist":{"id":1},"album":{"id":200},"release_date":"2015-03-15"}`,
+			},
+			want: map[int][]int{},
+		},
+		{
+			name: "intros without albums or release dates",
+			songs: []string{
+				`{"id":1,"title":"Intro","primary_artist":{"id":1}}`,
+				`{"id":2,"title":"Intro","primary_artist":{"id":1},"album":{"id":200}}`,
+			},
+			want: map[int][]int{},
+		},
+		{
+			name: "same album",
+			songs: []string{
+				`{"id":1,"title":"Intro","primary_artist":{"id":1},"album":{"id":100}}`,
+				`{"id":2,"title":"INTRO","primary_artist":{"id":1},"album":{"id":100}}`,
+			},
+			want: map[int][]int{1: {2}},
+		},
+		{
+			name: "single released before the album",
+			songs: []string{
+				`{"id":1,"title":"HUMBLE.","primary_artist":{"id":1},"album":{"id":100},"release_date":"2017-04-14"}`,
+				`{"id":2,"title":"HUMBLE.","primary_artist":{"id":1},"album":{"id":300},"release_date":"2017-03-30"}`,
+			},
+			want: map[int][]int{1: {2}},
+		},
+		{
+			name: "remaster decades later",
+			songs: []string{
+				`{"id":1,"title":"Bohemian Rhapsody","primary_artist":{"id":1},"album":{"id":100},"release_date":"1975-10-31"}`,
+				`{"id":2,"title":"Bohemian Rhapsody (Remastered 2011)","primary_artist":{"id":1},"album":{"id":200},"release_date":"2011-03-14"}`,
+			},
+			want: map[int][]int{1: {2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := store.Canonicalize(context.Background(), openSongs(t, tt.songs...))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(report.Groups, tt.want) {
+				t.Errorf("got groups %v, want %v", report.Groups, tt.want)
+			}
+		})
+	}
+}
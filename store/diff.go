@@ -0,0 +1,121 @@
+package store
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// DiffOp is how a line changed between two versions of lyrics.
+type DiffOp int
+
+const (
+	// Removed lines are only in the previous version.
+	Removed DiffOp = iota + 1
+	// Added lines are only in the current version.
+	Added
+)
+
+func (op DiffOp) String() string {
+	switch op {
+	case Removed:
+		return "-"
+	case Added:
+		return "+"
+	default:
+		return "?"
+	}
+}
+
+// LineChange is a line that was removed or added.
+type LineChange struct {
+	Op DiffOp
+	// Line is the 1-based number of the line, in the previous version for removed lines and in the current version
+	// for added lines.
+	Line int
+	Text string
+}
+
+func (c LineChange) String() string {
+	return c.Op.String() + " " + c.Text
+}
+
+// LyricsDiff is the difference between the current lyrics of a song and their previous version.
+type LyricsDiff struct {
+	SongID int
+	// From is when the previous version was fetched, zero if the song has a single version.
+	From time.Time
+	// To is when the current version was fetched.
+	To      time.Time
+	Changes []LineChange
+}
+
+// Diff returns the lines of the lyrics of the song that changed since their previous version. Lyrics with a single
+// version are compared to empty lyrics, so all their lines are added.
+func Diff(ctx context.Context, s Store, songID int) (*LyricsDiff, error) {
+	versions, err := s.LyricsVersions(ctx, songID)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, ErrNotFound
+	}
+
+	current := versions[len(versions)-1]
+	diff := &LyricsDiff{SongID: songID, To: current.FetchedAt}
+
+	var previous string
+	if len(versions) > 1 {
+		diff.From = versions[len(versions)-2].FetchedAt
+		previous = versions[len(versions)-2].Lyrics
+	}
+	diff.Changes = DiffLines(previous, current.Lyrics)
+
+	return diff, nil
+}
+
+// DiffLines returns the lines removed from and added to previous to get current. Removed lines come before the lines
+// added in their place.
+func DiffLines(previous string, current string) []LineChange {
+	a, b := lines(previous), lines(current)
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var changes []LineChange
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			changes = append(changes, LineChange{Op: Removed, Line: i + 1, Text: a[i]})
+			i++
+		default:
+			changes = append(changes, LineChange{Op: Added, Line: j + 1, Text: b[j]})
+			j++
+		}
+	}
+
+	return changes
+}
+
+func lines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
@@ -0,0 +1,74 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/natecham/genius/store"
+	"github.com/natecham/genius/store/bolt"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous string
+		current  string
+		want     []store.LineChange
+	}{
+		{"Identical", "a\nb", "a\nb", nil},
+		{"FromEmpty", "", "a\nb", []store.LineChange{
+			{Op: store.Added, Line: 1, Text: "a"},
+			{Op: store.Added, Line: 2, Text: "b"},
+		}},
+		{"Changed", "[Verse 1]\nSit down\nBe humble", "[Verse 1]\nSit down, be humble\nBe humble\nHol' up", []store.LineChange{
+			{Op: store.Removed, Line: 2, Text: "Sit down"},
+			{Op: store.Added, Line: 2, Text: "Sit down, be humble"},
+			{Op: store.Added, Line: 4, Text: "Hol' up"},
+		}},
+		{"Removed", "a\nb\nc", "a\nc", []store.LineChange{
+			{Op: store.Removed, Line: 2, Text: "b"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := store.DiffLines(tt.previous, tt.current); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	ctx := context.Background()
+
+	s, err := bolt.Open(filepath.Join(t.TempDir(), "genius.bolt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	if _, err = store.Diff(ctx, s, 1); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	for _, lyrics := range []string{"Sit down", "Sit down\nBe humble"} {
+		if err = s.SaveLyrics(ctx, 1, lyrics); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	diff, err := store.Diff(ctx, s, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.SongID != 1 || diff.From.IsZero() || diff.To.Before(diff.From) {
+		t.Errorf("unexpected diff %+v", diff)
+	}
+	if want := []store.LineChange{{Op: store.Added, Line: 2, Text: "Be humble"}}; !reflect.DeepEqual(diff.Changes, want) {
+		t.Errorf("got changes %v, want %v", diff.Changes, want)
+	}
+}
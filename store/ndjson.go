@@ -0,0 +1,131 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/natecham/genius"
+)
+
+// record is a line of an export. Entities are exported with all fields Genius returned in Data, lyrics with all
+// their versions.
+type record struct {
+	Type     Kind            `json:"type"`
+	Data     json.RawMessage `json:"data,omitempty"`
+	SongID   int             `json:"song_id,omitempty"`
+	Versions []LyricsVersion `json:"versions,omitempty"`
+}
+
+// Export writes the contents of s to w as newline delimited JSON, one artist, album, song or song's lyrics per line.
+// The lines look like:
+//
+//	{"type":"artist","data":{"id":1421,"name":"Kendrick Lamar",...}}
+//	{"type":"song","data":{"id":3039923,"title":"HUMBLE.",...}}
+//	{"type":"lyrics","song_id":3039923,"versions":[{"lyrics":"...","fetched_at":"2017-03-30T20:47:12Z"}]}
+//
+// Entities are written as Genius returned them, so exports don't depend on how a store saves them.
+func Export(ctx context.Context, s Store, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+
+	exports := []struct {
+		kind   Kind
+		lookup func(ctx context.Context, id int) (any, error)
+	}{
+		{KindArtist, func(ctx context.Context, id int) (any, error) { return s.Artist(ctx, id) }},
+		{KindAlbum, func(ctx context.Context, id int) (any, error) { return s.Album(ctx, id) }},
+		{KindSong, func(ctx context.Context, id int) (any, error) { return s.Song(ctx, id) }},
+	}
+
+	for _, export := range exports {
+		ids, err := s.IDs(ctx, export.kind)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			entity, err := export.lookup(ctx, id)
+			if err != nil {
+				return fmt.Errorf("exporting %s %d: %w", export.kind, id, err)
+			}
+			data, err := json.Marshal(entity)
+			if err != nil {
+				return fmt.Errorf("exporting %s %d: %w", export.kind, id, err)
+			}
+			if err = encoder.Encode(record{Type: export.kind, Data: data}); err != nil {
+				return err
+			}
+		}
+	}
+
+	songIDs, err := s.IDs(ctx, KindLyrics)
+	if err != nil {
+		return err
+	}
+	for _, songID := range songIDs {
+		versions, err := s.LyricsVersions(ctx, songID)
+		if err != nil {
+			return fmt.Errorf("exporting lyrics of song %d: %w", songID, err)
+		}
+		if err = encoder.Encode(record{Type: KindLyrics, SongID: songID, Versions: versions}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Import saves the contents of an export read from r to s, replacing the stored entities with the same IDs.
+//
+// Lyrics versions are saved oldest first, their fetch times become the time of the import.
+func Import(ctx context.Context, s Store, r io.Reader) error {
+	decoder := json.NewDecoder(r)
+
+	for n := 1; ; n++ {
+		var rec record
+		if err := decoder.Decode(&rec); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("importing record %d: %w", n, err)
+		}
+
+		if err := importRecord(ctx, s, rec); err != nil {
+			return fmt.Errorf("importing record %d: %w", n, err)
+		}
+	}
+}
+
+func importRecord(ctx context.Context, s Store, rec record) error {
+	switch rec.Type {
+	case KindArtist:
+		var artist genius.Artist
+		if err := json.Unmarshal(rec.Data, &artist); err != nil {
+			return err
+		}
+		return s.SaveArtist(ctx, &artist)
+	case KindAlbum:
+		var album genius.Album
+		if err := json.Unmarshal(rec.Data, &album); err != nil {
+			return err
+		}
+		return s.SaveAlbum(ctx, &album)
+	case KindSong:
+		var song genius.Song
+		if err := json.Unmarshal(rec.Data, &song); err != nil {
+			return err
+		}
+		return s.SaveSong(ctx, &song)
+	case KindLyrics:
+		for _, version := range rec.Versions {
+			if err := s.SaveLyrics(ctx, rec.SongID, version.Lyrics); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w %q", ErrUnknownKind, rec.Type)
+	}
+}
@@ -0,0 +1,100 @@
+package store_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/store"
+	"github.com/natecham/genius/store/bolt"
+	"github.com/natecham/genius/store/sqlite"
+	"github.com/natecham/genius/store/storetest"
+)
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+
+	from, err := bolt.Open(filepath.Join(t.TempDir(), "genius.bolt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { from.Close() })
+
+	if err = from.SaveArtist(ctx, &genius.Artist{ID: 1421, Name: "Kendrick Lamar"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = from.SaveAlbum(ctx, storetest.Album(t, 2, "DAMN.", 1421)); err != nil {
+		t.Fatal(err)
+	}
+	if err = from.SaveSong(ctx, storetest.Song(t, 1, "HUMBLE.", 1421)); err != nil {
+		t.Fatal(err)
+	}
+	for _, lyrics := range []string{"Sit down", "Sit down\nBe humble"} {
+		if err = from.SaveLyrics(ctx, 1, lyrics); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var export bytes.Buffer
+	if err = store.Export(ctx, from, &export); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(export.String(), "\n"); lines != 4 {
+		t.Errorf("expected 4 records, got %d:\n%s", lines, export.String())
+	}
+
+	to, err := sqlite.Open(filepath.Join(t.TempDir(), "genius.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { to.Close() })
+
+	if err = store.Import(ctx, to, &export); err != nil {
+		t.Fatal(err)
+	}
+
+	song, err := to.Song(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if song.Title != "HUMBLE." || !strings.Contains(string(song.Raw()), "a_field_added_later") {
+		t.Errorf("unexpected song %s", song.Raw())
+	}
+	if album, err := to.Album(ctx, 2); err != nil || album.Name != "DAMN." {
+		t.Errorf("unexpected album %+v, %v", album, err)
+	}
+	if artist, err := to.Artist(ctx, 1421); err != nil || artist.Name != "Kendrick Lamar" {
+		t.Errorf("unexpected artist %+v, %v", artist, err)
+	}
+
+	versions, err := to.LyricsVersions(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lyrics []string
+	for _, version := range versions {
+		lyrics = append(lyrics, version.Lyrics)
+	}
+	if want := []string{"Sit down", "Sit down\nBe humble"}; !slices.Equal(lyrics, want) {
+		t.Errorf("got lyrics versions %q, want %q", lyrics, want)
+	}
+}
+
+func TestImportUnknownType(t *testing.T) {
+	s, err := bolt.Open(filepath.Join(t.TempDir(), "genius.bolt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	export := `{"type":"artist","data":{"id":1}}` + "\n" + `{"type":"playlist","data":{"id":1}}` + "\n"
+	err = store.Import(context.Background(), s, strings.NewReader(export))
+	if !errors.Is(err, store.ErrUnknownKind) || !strings.Contains(err.Error(), "record 2") {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package store
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// LyricsMatch is a song whose lyrics contain a searched phrase.
+type LyricsMatch struct {
+	SongID int
+	// Line is the first line of the lyrics containing the phrase.
+	Line string
+}
+
+var apostrophes = strings.NewReplacer("'", "", "’", "", "‘", "", "`", "", "´", "")
+
+// Terms splits text into the terms lyrics are indexed by: lower case words without apostrophes, split on
+// punctuation and spaces. "Don’t stop me now!" has the terms "dont", "stop", "me" and "now".
+func Terms(text string) []string {
+	text = apostrophes.Replace(strings.ToLower(text))
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// MatchLine returns the first line of lyrics containing the terms of phrase in a row. Phrases don't span lines and
+// an empty phrase matches nothing.
+func MatchLine(lyrics string, phrase string) (string, bool) {
+	terms := Terms(phrase)
+	if len(terms) == 0 {
+		return "", false
+	}
+
+	for _, line := range strings.Split(lyrics, "\n") {
+		lineTerms := Terms(line)
+		for i := 0; i+len(terms) <= len(lineTerms); i++ {
+			if slices.Equal(lineTerms[i:i+len(terms)], terms) {
+				return strings.TrimSuffix(line, "\r"), true
+			}
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,40 @@
+package store_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/natecham/genius/store"
+)
+
+func TestTerms(t *testing.T) {
+	got := store.Terms("Don’t stop me now! (I'm having 1 good time)")
+	want := []string{"dont", "stop", "me", "now", "im", "having", "1", "good", "time"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestMatchLine(t *testing.T) {
+	lyrics := "[Verse 1]\nTonight I'm gonna have myself a real good time\nI feel alive"
+
+	tests := []struct {
+		phrase string
+		line   string
+		ok     bool
+	}{
+		{"real good time", "Tonight I'm gonna have myself a real good time", true},
+		{"IM GONNA", "Tonight I'm gonna have myself a real good time", true},
+		{"verse", "[Verse 1]", true},
+		{"time i feel", "", false},
+		{"good real", "", false},
+		{"...", "", false},
+	}
+
+	for _, tt := range tests {
+		line, ok := store.MatchLine(lyrics, tt.phrase)
+		if line != tt.line || ok != tt.ok {
+			t.Errorf("%q: got %q, %v, want %q, %v", tt.phrase, line, ok, tt.line, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,443 @@
+// Package sqlite implements store.Store on an SQLite database.
+//
+// It uses github.com/mattn/go-sqlite3 and requires cgo, see store/bolt for a pure Go alternative.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/store"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// schema creates the tables, entities are stored as the JSON Genius returned next to the columns they are looked up
+// by.
+const schema = `
+CREATE TABLE IF NOT EXISTS songs (
+	id         INTEGER PRIMARY KEY,
+	title      TEXT NOT NULL,
+	data       TEXT NOT NULL,
+	updated_at TIMESTAMP NOT NULL
+);
+CREATE TABLE IF NOT EXISTS song_artists (
+	song_id   INTEGER NOT NULL,
+	artist_id INTEGER NOT NULL,
+	PRIMARY KEY (song_id, artist_id)
+);
+CREATE INDEX IF NOT EXISTS song_artists_artist_id ON song_artists (artist_id);
+CREATE TABLE IF NOT EXISTS albums (
+	id         INTEGER PRIMARY KEY,
+	artist_id  INTEGER NOT NULL,
+	name       TEXT NOT NULL,
+	data       TEXT NOT NULL,
+	updated_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS albums_artist_id ON albums (artist_id);
+CREATE TABLE IF NOT EXISTS artists (
+	id         INTEGER PRIMARY KEY,
+	name       TEXT NOT NULL,
+	data       TEXT NOT NULL,
+	updated_at TIMESTAMP NOT NULL
+);
+CREATE TABLE IF NOT EXISTS lyrics (
+	song_id    INTEGER PRIMARY KEY,
+	lyrics     TEXT NOT NULL,
+	fetched_at TIMESTAMP NOT NULL
+);
+CREATE TABLE IF NOT EXISTS lyrics_history (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	song_id    INTEGER NOT NULL,
+	lyrics     TEXT NOT NULL,
+	fetched_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS lyrics_history_song_id ON lyrics_history (song_id);
+CREATE VIRTUAL TABLE IF NOT EXISTS lyrics_index USING fts4 (terms);
+CREATE TABLE IF NOT EXISTS canonical_songs (
+	song_id      INTEGER PRIMARY KEY,
+	canonical_id INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS canonical_songs_canonical_id ON canonical_songs (canonical_id);
+`
+
+// Store is a store.Store backed by an SQLite database.
+type Store struct {
+	db *sql.DB
+}
+
+var _ store.Store = (*Store)(nil)
+
+// Open opens the SQLite database at path, creating it and its tables if needed.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite3", path+"?_foreign_keys=on&_busy_timeout=5000")
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite allows one writer at a time, a single connection avoids "database is locked" errors.
+	db.SetMaxOpenConns(1)
+
+	if _, err = db.Exec(schema); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	s := &Store{db: db}
+	if err = s.indexLyrics(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// indexLyrics adds the lyrics missing from the full-text index, stored before it existed.
+func (s *Store) indexLyrics(ctx context.Context) error {
+	rows, err := s.db.QueryContext(ctx, `SELECT song_id, lyrics FROM lyrics WHERE song_id NOT IN (SELECT docid FROM lyrics_index)`)
+	if err != nil {
+		return err
+	}
+
+	missing := make(map[int]string)
+	for rows.Next() {
+		var songID int
+		var lyrics string
+		if err = rows.Scan(&songID, &lyrics); err != nil {
+			rows.Close()
+			return err
+		}
+		missing[songID] = lyrics
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		for songID, lyrics := range missing {
+			if err := index(ctx, tx, songID, lyrics); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// index replaces the terms of the song's lyrics in the full-text index.
+func index(ctx context.Context, tx *sql.Tx, songID int, lyrics string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM lyrics_index WHERE docid = ?`, songID); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, `INSERT INTO lyrics_index (docid, terms) VALUES (?, ?)`,
+		songID, strings.Join(store.Terms(lyrics), " "))
+	return err
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// SaveSong stores song and the IDs of its primary artists.
+func (s *Store) SaveSong(ctx context.Context, song *genius.Song) error {
+	data, err := json.Marshal(song)
+	if err != nil {
+		return err
+	}
+
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO songs (id, title, data, updated_at) VALUES (?, ?, ?, ?)`,
+			song.ID, song.Title, data, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+
+		if _, err = tx.ExecContext(ctx, `DELETE FROM song_artists WHERE song_id = ?`, song.ID); err != nil {
+			return err
+		}
+		for _, artistID := range store.SongArtistIDs(song) {
+			_, err = tx.ExecContext(ctx, `INSERT INTO song_artists (song_id, artist_id) VALUES (?, ?)`, song.ID, artistID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// SaveAlbum stores album.
+func (s *Store) SaveAlbum(ctx context.Context, album *genius.Album) error {
+	data, err := json.Marshal(album)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, `INSERT OR REPLACE INTO albums (id, artist_id, name, data, updated_at) VALUES (?, ?, ?, ?, ?)`,
+		album.ID, store.AlbumArtistID(album), album.Name, data, time.Now().UTC())
+	return err
+}
+
+// SaveArtist stores artist.
+func (s *Store) SaveArtist(ctx context.Context, artist *genius.Artist) error {
+	data, err := json.Marshal(artist)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.ExecContext(ctx, `INSERT OR REPLACE INTO artists (id, name, data, updated_at) VALUES (?, ?, ?, ?)`,
+		artist.ID, artist.Name, data, time.Now().UTC())
+	return err
+}
+
+// SaveLyrics stores the lyrics of a song, moving different stored lyrics to its history.
+func (s *Store) SaveLyrics(ctx context.Context, songID int, lyrics string) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO lyrics_history (song_id, lyrics, fetched_at)
+			SELECT song_id, lyrics, fetched_at FROM lyrics WHERE song_id = ? AND lyrics != ?`, songID, lyrics)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `INSERT OR REPLACE INTO lyrics (song_id, lyrics, fetched_at) VALUES (?, ?, ?)`,
+			songID, lyrics, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+
+		return index(ctx, tx, songID, lyrics)
+	})
+}
+
+// Song returns the song with ID id.
+func (s *Store) Song(ctx context.Context, id int) (*genius.Song, error) {
+	return get[genius.Song](ctx, s.db, `SELECT data FROM songs WHERE id = ?`, id)
+}
+
+// Album returns the album with ID id.
+func (s *Store) Album(ctx context.Context, id int) (*genius.Album, error) {
+	return get[genius.Album](ctx, s.db, `SELECT data FROM albums WHERE id = ?`, id)
+}
+
+// Artist returns the artist with ID id.
+func (s *Store) Artist(ctx context.Context, id int) (*genius.Artist, error) {
+	return get[genius.Artist](ctx, s.db, `SELECT data FROM artists WHERE id = ?`, id)
+}
+
+// Lyrics returns the lyrics of the song with ID songID.
+func (s *Store) Lyrics(ctx context.Context, songID int) (string, error) {
+	var lyrics string
+	err := s.db.QueryRowContext(ctx, `SELECT lyrics FROM lyrics WHERE song_id = ?`, songID).Scan(&lyrics)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", store.ErrNotFound
+	}
+	return lyrics, err
+}
+
+// LyricsVersions returns the versions of the lyrics of the song with ID songID, oldest first.
+func (s *Store) LyricsVersions(ctx context.Context, songID int) ([]store.LyricsVersion, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT lyrics, fetched_at FROM (
+			SELECT id, lyrics, fetched_at FROM lyrics_history WHERE song_id = ?
+			UNION ALL
+			SELECT NULL, lyrics, fetched_at FROM lyrics WHERE song_id = ?
+		) ORDER BY id IS NULL, id`, songID, songID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []store.LyricsVersion
+	for rows.Next() {
+		var version store.LyricsVersion
+		if err = rows.Scan(&version.Lyrics, &version.FetchedAt); err != nil {
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// History is only added with current lyrics, no versions means the song has no lyrics.
+	if len(versions) == 0 {
+		return nil, store.ErrNotFound
+	}
+	return versions, nil
+}
+
+// SearchLyrics returns the songs whose lyrics contain phrase. The full-text index finds the lyrics containing the
+// phrase's terms in a row, which are then checked to contain them on a single line.
+func (s *Store) SearchLyrics(ctx context.Context, phrase string) ([]store.LyricsMatch, error) {
+	terms := store.Terms(phrase)
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	// Terms are only made of letters and numbers, they don't need escaping in the quoted phrase.
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT lyrics.song_id, lyrics.lyrics FROM lyrics_index JOIN lyrics ON lyrics.song_id = lyrics_index.docid
+		WHERE lyrics_index.terms MATCH ? ORDER BY lyrics.song_id`, `"`+strings.Join(terms, " ")+`"`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var matches []store.LyricsMatch
+	for rows.Next() {
+		var songID int
+		var lyrics string
+		if err = rows.Scan(&songID, &lyrics); err != nil {
+			return nil, err
+		}
+
+		if line, ok := store.MatchLine(lyrics, phrase); ok {
+			matches = append(matches, store.LyricsMatch{SongID: songID, Line: line})
+		}
+	}
+
+	return matches, rows.Err()
+}
+
+// SetCanonical records the canonical song of a song.
+func (s *Store) SetCanonical(ctx context.Context, songID int, canonicalID int) error {
+	if songID == canonicalID {
+		_, err := s.db.ExecContext(ctx, `DELETE FROM canonical_songs WHERE song_id = ?`, songID)
+		return err
+	}
+
+	_, err := s.db.ExecContext(ctx, `INSERT OR REPLACE INTO canonical_songs (song_id, canonical_id) VALUES (?, ?)`,
+		songID, canonicalID)
+	return err
+}
+
+// Canonical returns the ID of the canonical song of a song.
+func (s *Store) Canonical(ctx context.Context, songID int) (int, error) {
+	var canonicalID int
+	err := s.db.QueryRowContext(ctx, `SELECT canonical_id FROM canonical_songs WHERE song_id = ?`, songID).Scan(&canonicalID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return songID, nil
+	}
+	return canonicalID, err
+}
+
+// Duplicates returns the IDs of the songs duplicating a song.
+func (s *Store) Duplicates(ctx context.Context, canonicalID int) ([]int, error) {
+	return s.ids(ctx, `SELECT song_id FROM canonical_songs WHERE canonical_id = ? ORDER BY song_id`, canonicalID)
+}
+
+// IDs returns the IDs of the stored entities of kind.
+func (s *Store) IDs(ctx context.Context, kind store.Kind) ([]int, error) {
+	queries := map[store.Kind]string{
+		store.KindSong:   `SELECT id FROM songs ORDER BY id`,
+		store.KindAlbum:  `SELECT id FROM albums ORDER BY id`,
+		store.KindArtist: `SELECT id FROM artists ORDER BY id`,
+		store.KindLyrics: `SELECT song_id FROM lyrics ORDER BY song_id`,
+	}
+	query, ok := queries[kind]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", store.ErrUnknownKind, kind)
+	}
+
+	return s.ids(ctx, query)
+}
+
+// ids returns the IDs in the single column of the rows selected by query.
+func (s *Store) ids(ctx context.Context, query string, args ...any) ([]int, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// SongsByArtist returns the songs with the artist as a primary artist.
+func (s *Store) SongsByArtist(ctx context.Context, artistID int) ([]*genius.Song, error) {
+	return list[genius.Song](ctx, s.db, `
+		SELECT songs.data FROM songs JOIN song_artists ON song_artists.song_id = songs.id
+		WHERE song_artists.artist_id = ? ORDER BY songs.id`, artistID)
+}
+
+// AlbumsByArtist returns the albums of the artist.
+func (s *Store) AlbumsByArtist(ctx context.Context, artistID int) ([]*genius.Album, error) {
+	return list[genius.Album](ctx, s.db, `SELECT data FROM albums WHERE artist_id = ? ORDER BY id`, artistID)
+}
+
+func (s *Store) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err = fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// get decodes the JSON in the single column of the row selected by query.
+func get[T any](ctx context.Context, db *sql.DB, query string, args ...any) (*T, error) {
+	var data []byte
+	err := db.QueryRowContext(ctx, query, args...).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var v T
+	if err = json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// list decodes the JSON in the single column of the rows selected by query.
+func list[T any](ctx context.Context, db *sql.DB, query string, args ...any) ([]*T, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []*T
+	for rows.Next() {
+		var data []byte
+		if err = rows.Scan(&data); err != nil {
+			return nil, err
+		}
+
+		var v T
+		if err = json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		values = append(values, &v)
+	}
+
+	return values, rows.Err()
+}
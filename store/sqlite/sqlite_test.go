@@ -0,0 +1,20 @@
+package sqlite_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/natecham/genius/store"
+	"github.com/natecham/genius/store/sqlite"
+	"github.com/natecham/genius/store/storetest"
+)
+
+func TestStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.Store {
+		s, err := sqlite.Open(filepath.Join(t.TempDir(), "genius.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	})
+}
@@ -0,0 +1,108 @@
+// Package store persists data fetched from Genius, so archival tools don't need to define their own schema.
+//
+// Store is implemented by the subpackages, e.g. store/sqlite.
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/natecham/genius"
+)
+
+// ErrNotFound is returned by lookups of entities that aren't stored.
+var ErrNotFound = errors.New("store: not found")
+
+// Store persists songs, albums, artists and lyrics. Entities are stored with all fields Genius returned, see
+// genius.Song.Raw, and saving an entity again replaces it.
+//
+// A Store is a genius.Cache, clients created with genius.WithCache(s) serve stored entities without requests.
+type Store interface {
+	SaveSong(ctx context.Context, song *genius.Song) error
+	SaveAlbum(ctx context.Context, album *genius.Album) error
+	SaveArtist(ctx context.Context, artist *genius.Artist) error
+	// SaveLyrics stores the lyrics of the song with ID songID, which doesn't need to be stored itself. Lyrics that
+	// differ from the stored ones replace them, the previous version is kept in the song's LyricsVersions.
+	SaveLyrics(ctx context.Context, songID int, lyrics string) error
+
+	Song(ctx context.Context, id int) (*genius.Song, error)
+	Album(ctx context.Context, id int) (*genius.Album, error)
+	Artist(ctx context.Context, id int) (*genius.Artist, error)
+	Lyrics(ctx context.Context, songID int) (string, error)
+	// LyricsVersions returns the versions of the lyrics of the song, oldest first and ending with the current one.
+	LyricsVersions(ctx context.Context, songID int) ([]LyricsVersion, error)
+
+	// SearchLyrics returns the songs whose current lyrics contain phrase on one line, ordered by song ID. Case,
+	// apostrophes and punctuation are ignored, see Terms and MatchLine.
+	SearchLyrics(ctx context.Context, phrase string) ([]LyricsMatch, error)
+
+	// SetCanonical records that the song with ID songID duplicates the song with ID canonicalID, e.g. as its
+	// translation or remaster. Setting a song as its own canonical song removes the record.
+	SetCanonical(ctx context.Context, songID int, canonicalID int) error
+	// Canonical returns the ID of the song's canonical song, its own ID if it doesn't duplicate another song.
+	Canonical(ctx context.Context, songID int) (int, error)
+	// Duplicates returns the IDs of the songs with the song as canonical song, ordered by ID.
+	Duplicates(ctx context.Context, canonicalID int) ([]int, error)
+
+	// IDs returns the IDs of the stored entities of kind ordered by ID, the IDs of the songs with lyrics for
+	// KindLyrics.
+	IDs(ctx context.Context, kind Kind) ([]int, error)
+
+	// SongsByArtist returns the stored songs with the artist as a primary artist, ordered by ID.
+	SongsByArtist(ctx context.Context, artistID int) ([]*genius.Song, error)
+	// AlbumsByArtist returns the stored albums of the artist, ordered by ID.
+	AlbumsByArtist(ctx context.Context, artistID int) ([]*genius.Album, error)
+
+	Close() error
+}
+
+var _ genius.Cache = Store(nil)
+
+// LyricsVersion is lyrics as they were fetched at a point in time.
+type LyricsVersion struct {
+	Lyrics string `json:"lyrics"`
+	// FetchedAt is when the lyrics were last fetched, re-fetching identical lyrics doesn't add a version.
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// Kind is a kind of stored entity.
+type Kind string
+
+// Kinds of stored entities.
+const (
+	KindSong   Kind = "song"
+	KindAlbum  Kind = "album"
+	KindArtist Kind = "artist"
+	KindLyrics Kind = "lyrics"
+)
+
+// ErrUnknownKind is returned for kinds other than the Kind constants.
+var ErrUnknownKind = errors.New("store: unknown kind")
+
+// SongArtistIDs returns the IDs of the primary artists of song, which it is found by in SongsByArtist.
+func SongArtistIDs(song *genius.Song) []int {
+	var ids []int
+	seen := make(map[int]bool)
+	add := func(artist *genius.Artist) {
+		if artist != nil && artist.ID != 0 && !seen[artist.ID] {
+			seen[artist.ID] = true
+			ids = append(ids, artist.ID)
+		}
+	}
+
+	add(song.PrimaryArtist)
+	for _, artist := range song.PrimaryArtists {
+		add(artist)
+	}
+
+	return ids
+}
+
+// AlbumArtistID returns the ID of the artist of album, which it is found by in AlbumsByArtist.
+func AlbumArtistID(album *genius.Album) int {
+	if album.Artist == nil {
+		return 0
+	}
+	return album.Artist.ID
+}
@@ -0,0 +1,396 @@
+// Package storetest provides tests every store.Store implementation must pass.
+package storetest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/store"
+)
+
+// Run runs the store test suite, newStore must return an empty store. Stores are closed by the suite.
+func Run(t *testing.T, newStore func(t *testing.T) store.Store) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, s store.Store)
+	}{
+		{"Song", testSong},
+		{"Album", testAlbum},
+		{"Artist", testArtist},
+		{"Lyrics", testLyrics},
+		{"LyricsVersions", testLyricsVersions},
+		{"SearchLyrics", testSearchLyrics},
+		{"NotFound", testNotFound},
+		{"IDs", testIDs},
+		{"Canonical", testCanonical},
+		{"SongsByArtist", testSongsByArtist},
+		{"AlbumsByArtist", testAlbumsByArtist},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newStore(t)
+			t.Cleanup(func() {
+				if err := s.Close(); err != nil {
+					t.Error(err)
+				}
+			})
+			tt.fn(t, s)
+		})
+	}
+}
+
+// Song returns a song decoded from JSON, so that it has raw data with a field unknown to genius.Song.
+func Song(t *testing.T, id int, title string, artistIDs ...int) *genius.Song {
+	t.Helper()
+
+	data := map[string]any{"id": id, "title": title, "a_field_added_later": "kept"}
+	if len(artistIDs) > 0 {
+		data["primary_artist"] = map[string]any{"id": artistIDs[0], "name": "Artist"}
+	}
+	var primaryArtists []map[string]any
+	for _, artistID := range artistIDs {
+		primaryArtists = append(primaryArtists, map[string]any{"id": artistID, "name": "Artist"})
+	}
+	data["primary_artists"] = primaryArtists
+
+	return decode[genius.Song](t, data)
+}
+
+// Album returns an album of the artist decoded from JSON.
+func Album(t *testing.T, id int, name string, artistID int) *genius.Album {
+	t.Helper()
+
+	return decode[genius.Album](t, map[string]any{
+		"id":     id,
+		"name":   name,
+		"artist": map[string]any{"id": artistID, "name": "Artist"},
+	})
+}
+
+func decode[T any](t *testing.T, data map[string]any) *T {
+	t.Helper()
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var v T
+	if err = json.Unmarshal(raw, &v); err != nil {
+		t.Fatal(err)
+	}
+	return &v
+}
+
+func testSong(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	if err := s.SaveSong(ctx, Song(t, 1, "HUMBLE.", 1421)); err != nil {
+		t.Fatal(err)
+	}
+	// Saving again replaces the song.
+	if err := s.SaveSong(ctx, Song(t, 1, "HUMBLE. (Remix)", 1421)); err != nil {
+		t.Fatal(err)
+	}
+
+	song, err := s.Song(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if song.ID != 1 || song.Title != "HUMBLE. (Remix)" || song.PrimaryArtist.ID != 1421 {
+		t.Errorf("unexpected song %+v", song)
+	}
+
+	var raw map[string]any
+	if err = json.Unmarshal(song.Raw(), &raw); err != nil {
+		t.Fatal(err)
+	}
+	if raw["a_field_added_later"] != "kept" {
+		t.Errorf("unknown field not stored: %s", song.Raw())
+	}
+}
+
+func testAlbum(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	if err := s.SaveAlbum(ctx, Album(t, 2, "DAMN.", 1421)); err != nil {
+		t.Fatal(err)
+	}
+
+	album, err := s.Album(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if album.ID != 2 || album.Name != "DAMN." || album.Artist.ID != 1421 {
+		t.Errorf("unexpected album %+v", album)
+	}
+}
+
+func testArtist(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	if err := s.SaveArtist(ctx, &genius.Artist{ID: 1421, Name: "Kendrick Lamar"}); err != nil {
+		t.Fatal(err)
+	}
+
+	artist, err := s.Artist(ctx, 1421)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if artist.ID != 1421 || artist.Name != "Kendrick Lamar" {
+		t.Errorf("unexpected artist %+v", artist)
+	}
+}
+
+func testLyrics(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	if err := s.SaveLyrics(ctx, 1, "Sit down\nBe humble"); err != nil {
+		t.Fatal(err)
+	}
+
+	lyrics, err := s.Lyrics(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lyrics != "Sit down\nBe humble" {
+		t.Errorf("unexpected lyrics %q", lyrics)
+	}
+}
+
+func testLyricsVersions(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	// Identical lyrics don't add a version.
+	for _, lyrics := range []string{"Sit down", "Sit down", "Sit down\nBe humble", "Be humble"} {
+		if err := s.SaveLyrics(ctx, 1, lyrics); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.SaveLyrics(ctx, 2, "Other song"); err != nil {
+		t.Fatal(err)
+	}
+
+	versions, err := s.LyricsVersions(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for i, version := range versions {
+		got = append(got, version.Lyrics)
+		if version.FetchedAt.IsZero() || (i > 0 && version.FetchedAt.Before(versions[i-1].FetchedAt)) {
+			t.Errorf("version %d: unexpected fetch time %v", i, version.FetchedAt)
+		}
+	}
+	if want := []string{"Sit down", "Sit down\nBe humble", "Be humble"}; !slices.Equal(got, want) {
+		t.Errorf("got versions %q, want %q", got, want)
+	}
+
+	lyrics, err := s.Lyrics(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lyrics != "Be humble" {
+		t.Errorf("unexpected current lyrics %q", lyrics)
+	}
+}
+
+func testSearchLyrics(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	for songID, lyrics := range map[int]string{
+		1: "[Chorus]\nBe humble, sit down\nSit down",
+		2: "Don't stop me now\nI'm having such a good time",
+		3: "Humble beginnings",
+		4: "Sit\ndown",
+	} {
+		if err := s.SaveLyrics(ctx, songID, lyrics); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Replaced lyrics are no longer found.
+	if err := s.SaveLyrics(ctx, 3, "Sit down, be humble"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		phrase string
+		want   []store.LyricsMatch
+	}{
+		{"SIT DOWN", []store.LyricsMatch{{SongID: 1, Line: "Be humble, sit down"}, {SongID: 3, Line: "Sit down, be humble"}}},
+		{"dont stop", []store.LyricsMatch{{SongID: 2, Line: "Don't stop me now"}}},
+		{"beginnings", nil},
+		{"down sit", nil},
+		{"", nil},
+	}
+
+	for _, tt := range tests {
+		got, err := s.SearchLyrics(ctx, tt.phrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.phrase, got, tt.want)
+		}
+	}
+}
+
+func testNotFound(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	if _, err := s.Song(ctx, 1); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Song: expected ErrNotFound, got %v", err)
+	}
+	if _, err := s.Album(ctx, 1); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Album: expected ErrNotFound, got %v", err)
+	}
+	if _, err := s.Artist(ctx, 1); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Artist: expected ErrNotFound, got %v", err)
+	}
+	if _, err := s.Lyrics(ctx, 1); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Lyrics: expected ErrNotFound, got %v", err)
+	}
+	if _, err := s.LyricsVersions(ctx, 1); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("LyricsVersions: expected ErrNotFound, got %v", err)
+	}
+}
+
+func testIDs(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	for _, id := range []int{3, 1, 2} {
+		if err := s.SaveSong(ctx, Song(t, id, "Song")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.SaveAlbum(ctx, Album(t, 4, "Album", 1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SaveLyrics(ctx, 2, "Lyrics"); err != nil {
+		t.Fatal(err)
+	}
+
+	for kind, want := range map[store.Kind][]int{
+		store.KindSong:   {1, 2, 3},
+		store.KindAlbum:  {4},
+		store.KindArtist: nil,
+		store.KindLyrics: {2},
+	} {
+		ids, err := s.IDs(ctx, kind)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(ids, want) {
+			t.Errorf("%s: got IDs %v, want %v", kind, ids, want)
+		}
+	}
+
+	if _, err := s.IDs(ctx, "playlist"); !errors.Is(err, store.ErrUnknownKind) {
+		t.Errorf("expected ErrUnknownKind, got %v", err)
+	}
+}
+
+func testCanonical(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	for songID, canonicalID := range map[int]int{2: 1, 3: 1, 4: 4} {
+		if err := s.SetCanonical(ctx, songID, canonicalID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Songs can move to another canonical song, or become canonical again.
+	if err := s.SetCanonical(ctx, 3, 4); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetCanonical(ctx, 2, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	for songID, want := range map[int]int{1: 1, 2: 2, 3: 4, 4: 4, 5: 5} {
+		canonicalID, err := s.Canonical(ctx, songID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if canonicalID != want {
+			t.Errorf("song %d: got canonical song %d, want %d", songID, canonicalID, want)
+		}
+	}
+
+	for canonicalID, want := range map[int][]int{1: nil, 2: nil, 4: {3}} {
+		duplicates, err := s.Duplicates(ctx, canonicalID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(duplicates, want) {
+			t.Errorf("song %d: got duplicates %v, want %v", canonicalID, duplicates, want)
+		}
+	}
+}
+
+func testSongsByArtist(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	for _, song := range []*genius.Song{
+		Song(t, 3, "Three", 10),
+		Song(t, 1, "One", 10, 20),
+		Song(t, 2, "Two", 20),
+	} {
+		if err := s.SaveSong(ctx, song); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The song no longer credits artist 20 once it is saved again.
+	if err := s.SaveSong(ctx, Song(t, 2, "Two", 30)); err != nil {
+		t.Fatal(err)
+	}
+
+	assertIDs(t, s.SongsByArtist, 10, []int{1, 3})
+	assertIDs(t, s.SongsByArtist, 20, []int{1})
+	assertIDs(t, s.SongsByArtist, 30, []int{2})
+	assertIDs(t, s.SongsByArtist, 40, nil)
+}
+
+func testAlbumsByArtist(t *testing.T, s store.Store) {
+	ctx := context.Background()
+
+	for _, album := range []*genius.Album{Album(t, 2, "Two", 10), Album(t, 1, "One", 10), Album(t, 3, "Three", 20)} {
+		if err := s.SaveAlbum(ctx, album); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assertIDs(t, s.AlbumsByArtist, 10, []int{1, 2})
+	assertIDs(t, s.AlbumsByArtist, 20, []int{3})
+}
+
+// assertIDs looks up the songs or albums of the artist and compares their IDs to want.
+func assertIDs[T any](t *testing.T, lookup func(ctx context.Context, artistID int) ([]*T, error), artistID int, want []int) {
+	t.Helper()
+
+	values, err := lookup(context.Background(), artistID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []int
+	for _, v := range values {
+		switch v := any(v).(type) {
+		case *genius.Song:
+			got = append(got, v.ID)
+		case *genius.Album:
+			got = append(got, v.ID)
+		}
+	}
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("artist %d: got IDs %v, want %v", artistID, got, want)
+	}
+}
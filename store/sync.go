@@ -0,0 +1,137 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/natecham/genius"
+)
+
+// SyncReport describes what SyncArtist changed in the store.
+type SyncReport struct {
+	ArtistID int
+	// Listed is the number of songs of the artist's catalog that were compared, see SyncArtist.
+	Listed int
+	// New are the IDs of songs that weren't stored before.
+	New []int
+	// Updated are the IDs of stored songs whose lyrics or metadata changed on Genius.
+	Updated []int
+	// Unchanged is the number of songs that were up to date.
+	Unchanged int
+}
+
+// SyncArtist brings the artist and its songs in s up to date with Genius. The artist's catalog is listed by release
+// date and compared to the stored songs: only songs that are new, or whose lyrics or metadata were edited since they
+// were stored, are fetched in full together with their lyrics.
+//
+// Once the catalog is seen to be listed newest first, listing stops after a page's worth of consecutive unchanged
+// songs, so a sync of an artist without new songs costs a single page. Edits to songs released before those aren't
+// picked up then.
+//
+// The artist and songs are requested with genius.WithRefresh, so that clients memoizing or caching them, e.g. in s,
+// don't serve the stored versions.
+//
+// On error the report lists the songs synced so far.
+func SyncArtist(ctx context.Context, client genius.GeniusAPI, s Store, artistID int) (*SyncReport, error) {
+	report := &SyncReport{ArtistID: artistID}
+
+	artist, err := client.GetArtist(ctx, artistID, genius.WithRefresh())
+	if err != nil {
+		return report, err
+	}
+	if artist.Response.Artist != nil {
+		if err = s.SaveArtist(ctx, artist.Response.Artist); err != nil {
+			return report, err
+		}
+	}
+
+	opts := &genius.ArtistSongsOptions{Sort: genius.SortReleaseDate}
+	opts.PerPage = syncPerPage
+	var order releaseOrder
+	unchanged := 0
+	for listed, err := range client.ArtistSongs(ctx, artistID, opts) {
+		if err != nil {
+			return report, err
+		}
+		report.Listed++
+		order.add(listed)
+
+		stored, err := s.Song(ctx, listed.ID)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			report.New = append(report.New, listed.ID)
+		case err != nil:
+			return report, err
+		case changed(stored, listed):
+			report.Updated = append(report.Updated, listed.ID)
+		default:
+			report.Unchanged++
+			if unchanged++; unchanged >= syncPerPage && order.newestFirst() {
+				return report, nil
+			}
+			continue
+		}
+		unchanged = 0
+
+		if err = syncSong(ctx, client, s, listed.ID); err != nil {
+			return report, fmt.Errorf("syncing song %d: %w", listed.ID, err)
+		}
+	}
+
+	return report, nil
+}
+
+// syncPerPage is the page size SyncArtist lists the catalog with.
+const syncPerPage = 50
+
+// releaseOrder tells the direction of the release date order from the songs listed in it, Genius doesn't document
+// it.
+type releaseOrder struct {
+	last       time.Time
+	descending *bool
+}
+
+// add records the release date of the next listed song.
+func (o *releaseOrder) add(song *genius.Song) {
+	released, ok := song.Released()
+	if !ok {
+		return
+	}
+	if o.descending == nil && !o.last.IsZero() && !released.Equal(o.last) {
+		descending := released.Before(o.last)
+		o.descending = &descending
+	}
+	o.last = released
+}
+
+// newestFirst reports whether the songs were seen to be listed newest first.
+func (o *releaseOrder) newestFirst() bool {
+	return o.descending != nil && *o.descending
+}
+
+// changed reports whether the song listed in the artist's catalog was edited after the stored one.
+func changed(stored *genius.Song, listed *genius.Song) bool {
+	return listed.LyricsUpdatedAt > stored.LyricsUpdatedAt ||
+		listed.UpdatedByHumanAt > stored.UpdatedByHumanAt ||
+		(listed.LyricsState != "" && listed.LyricsState != stored.LyricsState)
+}
+
+func syncSong(ctx context.Context, client genius.GeniusAPI, s Store, id int) error {
+	song, err := client.GetSong(ctx, id, genius.WithRefresh())
+	if err != nil {
+		return err
+	}
+
+	lyrics, err := client.GetLyrics(ctx, song.URL)
+	if err != nil {
+		return err
+	}
+
+	// Lyrics are saved first, a song is only considered synced once it is stored.
+	if err = s.SaveLyrics(ctx, id, lyrics); err != nil {
+		return err
+	}
+	return s.SaveSong(ctx, song)
+}
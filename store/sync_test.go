@@ -0,0 +1,249 @@
+package store_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/store"
+	"github.com/natecham/genius/store/bolt"
+)
+
+// catalog is a fake Genius serving an artist's songs in the order of their IDs, their lyrics pages and counting the
+// pages listed and the songs fetched in full.
+type catalog struct {
+	mu      sync.Mutex
+	songs   map[int]map[string]any
+	pages   int
+	fetched []int
+}
+
+func newCatalogServer(t *testing.T, c *catalog) *httptest.Server {
+	t.Helper()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		switch {
+		case len(path) == 2 && path[0] == "artists":
+			writeResponse(w, map[string]any{"artist": map[string]any{"id": 1, "name": "Artist"}})
+		case len(path) == 3 && path[0] == "artists" && path[2] == "songs":
+			c.pages++
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+			ids := slices.Sorted(maps.Keys(c.songs))
+			songs := []map[string]any{}
+			for i := (page - 1) * perPage; i < page*perPage && i < len(ids); i++ {
+				songs = append(songs, c.songs[ids[i]])
+			}
+			var next any
+			if page*perPage < len(ids) {
+				next = page + 1
+			}
+			writeResponse(w, map[string]any{"songs": songs, "next_page": next})
+		case len(path) == 2 && path[0] == "songs":
+			id, _ := strconv.Atoi(path[1])
+			c.fetched = append(c.fetched, id)
+			song := map[string]any{"url": fmt.Sprintf("%s/lyrics/%d", server.URL, id)}
+			for k, v := range c.songs[id] {
+				song[k] = v
+			}
+			writeResponse(w, map[string]any{"song": song})
+		case len(path) == 2 && path[0] == "lyrics":
+			fmt.Fprintf(w, `<div id="lyrics-root"><div data-lyrics-container="true">Lyrics of song %s</div></div>`, path[1])
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func writeResponse(w http.ResponseWriter, response map[string]any) {
+	_ = json.NewEncoder(w).Encode(map[string]any{"meta": map[string]any{"status": 200}, "response": response})
+}
+
+func TestSyncArtist(t *testing.T) {
+	ctx := context.Background()
+	c := &catalog{songs: map[int]map[string]any{
+		1: {"id": 1, "title": "One", "updated_by_human_at": 100},
+		2: {"id": 2, "title": "Two", "updated_by_human_at": 100},
+	}}
+	server := newCatalogServer(t, c)
+
+	s, err := bolt.Open(filepath.Join(t.TempDir(), "genius.bolt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	syncArtist := func() *store.SyncReport {
+		t.Helper()
+
+		// Songs are memoized by clients, every sync gets a new one.
+		client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+		report, err := store.SyncArtist(ctx, client, s, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return report
+	}
+
+	report := syncArtist()
+	if report.Listed != 2 || !slices.Equal(report.New, []int{1, 2}) || report.Updated != nil || report.Unchanged != 0 {
+		t.Fatalf("first sync: unexpected report %+v", report)
+	}
+	lyrics, err := s.Lyrics(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lyrics != "Lyrics of song 2" {
+		t.Errorf("unexpected lyrics %q", lyrics)
+	}
+	if _, err = s.Artist(ctx, 1); err != nil {
+		t.Errorf("artist not stored: %v", err)
+	}
+
+	c.mu.Lock()
+	c.fetched = nil
+	c.songs[2]["updated_by_human_at"] = 200
+	c.songs[3] = map[string]any{"id": 3, "title": "Three", "updated_by_human_at": 100}
+	c.mu.Unlock()
+
+	report = syncArtist()
+	if report.Listed != 3 || !slices.Equal(report.New, []int{3}) || !slices.Equal(report.Updated, []int{2}) || report.Unchanged != 1 {
+		t.Fatalf("second sync: unexpected report %+v", report)
+	}
+	if !slices.Equal(c.fetched, []int{2, 3}) {
+		t.Errorf("fetched songs %v, want only the new and updated ones", c.fetched)
+	}
+
+	report = syncArtist()
+	if report.New != nil || report.Updated != nil || report.Unchanged != 3 {
+		t.Fatalf("third sync: unexpected report %+v", report)
+	}
+}
+
+func TestSyncArtistCachingClient(t *testing.T) {
+	ctx := context.Background()
+	c := &catalog{songs: map[int]map[string]any{
+		1: {"id": 1, "title": "One", "updated_by_human_at": 100},
+		2: {"id": 2, "title": "Two", "updated_by_human_at": 100},
+	}}
+	server := newCatalogServer(t, c)
+
+	s, err := bolt.Open(filepath.Join(t.TempDir(), "genius.bolt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	// The client memoizes songs and serves them from s, like the CLI's with a cache_dir.
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL), genius.WithCache(s))
+	if _, err = store.SyncArtist(ctx, client, s, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	c.mu.Lock()
+	c.songs[2]["updated_by_human_at"] = 200
+	c.mu.Unlock()
+
+	report, err := store.SyncArtist(ctx, client, s, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(report.Updated, []int{2}) {
+		t.Fatalf("second sync: unexpected report %+v", report)
+	}
+	song, err := s.Song(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if song.UpdatedByHumanAt != 200 {
+		t.Errorf("stored song was updated at %d, want the upstream 200", song.UpdatedByHumanAt)
+	}
+
+	if report, err = store.SyncArtist(ctx, client, s, 1); err != nil || report.Updated != nil {
+		t.Errorf("third sync: got %+v, %v, want nothing updated", report, err)
+	}
+}
+
+func TestSyncArtistStopsAtUnchangedPage(t *testing.T) {
+	tests := []struct {
+		name        string
+		newestFirst bool
+		pages       int
+	}{
+		{"newest first", true, 1},
+		{"oldest first", false, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := &catalog{songs: map[int]map[string]any{}}
+			released := func(id int) string {
+				day := id
+				if tt.newestFirst {
+					day = 1000 - id
+				}
+				return time.Date(2000, 1, day, 0, 0, 0, 0, time.UTC).Format(time.DateOnly)
+			}
+			for id := 100; id < 220; id++ {
+				c.songs[id] = map[string]any{"id": id, "title": fmt.Sprint(id), "release_date": released(id)}
+			}
+			server := newCatalogServer(t, c)
+
+			s, err := bolt.Open(filepath.Join(t.TempDir(), "genius.bolt"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { s.Close() })
+
+			syncArtist := func() *store.SyncReport {
+				t.Helper()
+
+				c.mu.Lock()
+				c.pages = 0
+				c.mu.Unlock()
+				report, err := store.SyncArtist(ctx, genius.NewClient(nil, "token", genius.WithBaseURL(server.URL)), s, 1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return report
+			}
+
+			if report := syncArtist(); len(report.New) != 120 || c.pages != 3 {
+				t.Fatalf("first sync: got %d new songs listing %d pages, want 120 listing 3", len(report.New), c.pages)
+			}
+
+			report := syncArtist()
+			if report.New != nil || report.Updated != nil || c.pages != tt.pages {
+				t.Errorf("second sync: unexpected report %+v listing %d pages, want %d", report, c.pages, tt.pages)
+			}
+
+			// A new song listed first is still found.
+			c.mu.Lock()
+			c.songs[99] = map[string]any{"id": 99, "title": "99", "release_date": released(99)}
+			c.mu.Unlock()
+			if report = syncArtist(); !slices.Equal(report.New, []int{99}) {
+				t.Errorf("third sync: got new songs %v, want [99]", report.New)
+			}
+		})
+	}
+}
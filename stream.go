@@ -0,0 +1,150 @@
+package genius
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// SongResult is a single item of a song stream, either a Song or the error that ended the stream.
+type SongResult struct {
+	Song *Song
+	Err  error
+	// Cursor resumes the stream after Song, see ResumeArtistSongs.
+	Cursor Cursor
+}
+
+// Cursor is an opaque position in a song stream. It can be persisted and passed to ResumeArtistSongs to continue a
+// partially completed crawl, e.g. after a crash or deploy.
+type Cursor string
+
+// ErrInvalidCursor is returned when resuming from a malformed Cursor.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cursorState is the content of a Cursor.
+type cursorState struct {
+	ArtistID int  `json:"artist_id"`
+	Sort     Sort `json:"sort,omitempty"`
+	PerPage  int  `json:"per_page"`
+	Page     int  `json:"page"`
+	Skip     int  `json:"skip,omitempty"`
+	Fetched  int  `json:"fetched"`
+}
+
+func (s cursorState) encode() Cursor {
+	raw, _ := json.Marshal(s)
+	return Cursor(base64.RawURLEncoding.EncodeToString(raw))
+}
+
+func (c Cursor) decode() (cursorState, error) {
+	var state cursorState
+
+	raw, err := base64.RawURLEncoding.DecodeString(string(c))
+	if err != nil {
+		return state, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	if err = json.Unmarshal(raw, &state); err != nil {
+		return state, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	return state, nil
+}
+
+// Done reports whether the stream the cursor belongs to was completely consumed.
+func (c Cursor) Done() bool {
+	state, err := c.decode()
+	return err == nil && state.Page == 0
+}
+
+// StreamArtistSongs fetches the songs of an artist in the background and delivers them on the returned channel.
+//
+// Up to one page of songs is buffered, so the next page is downloaded while the consumer processes the current one,
+// and fetching pauses when the consumer falls behind. The channel is closed when all songs were delivered, after an
+// error (delivered as the last result) or when ctx is cancelled.
+//
+// Songs filtered by opts aren't delivered but, unlike for ArtistSongs, count against MaxItems, so that cursors
+// resume at the same position.
+func (c *Client) StreamArtistSongs(ctx context.Context, id int, opts *ArtistSongsOptions) <-chan SongResult {
+	var listOpts *ListOptions
+	state := cursorState{ArtistID: id, Sort: opts.sort()}
+	if opts != nil {
+		listOpts = &opts.ListOptions
+	}
+	state.PerPage = listOpts.perPage(defaultPerPage)
+	state.Page = listOpts.startPage()
+
+	return c.streamArtistSongs(ctx, state, opts)
+}
+
+// ResumeArtistSongs continues the song stream cursor was taken from, starting with the song following it.
+//
+// The artist, sort order and page size are taken from the cursor, the remaining options apply as for
+// StreamArtistSongs. MaxItems includes the songs delivered before the cursor.
+func (c *Client) ResumeArtistSongs(ctx context.Context, cursor Cursor, opts *ArtistSongsOptions) <-chan SongResult {
+	state, err := cursor.decode()
+	if err != nil {
+		results := make(chan SongResult, 1)
+		results <- SongResult{Err: err, Cursor: cursor}
+		close(results)
+		return results
+	}
+
+	return c.streamArtistSongs(ctx, state, opts)
+}
+
+func (c *Client) streamArtistSongs(ctx context.Context, state cursorState, opts *ArtistSongsOptions) <-chan SongResult {
+	listOpts := ListOptions{}
+	if opts != nil {
+		listOpts = opts.ListOptions
+	}
+	listOpts.PerPage = state.PerPage
+
+	results := make(chan SongResult, state.PerPage)
+
+	go func() {
+		defer close(results)
+
+		if err := state.Sort.validate(); err != nil {
+			results <- SongResult{Err: err, Cursor: state.encode()}
+			return
+		}
+
+		var filter *songFilter
+		if opts.filtered() {
+			filter = newSongFilter(opts, state.Sort, state.PerPage)
+		}
+
+		start := position{page: state.Page, skip: state.Skip, fetched: state.Fetched}
+		fetch := func(ctx context.Context, page int, perPage int) (*Page[*Song], error) {
+			return c.getArtistSongsPage(ctx, state.ArtistID, state.Sort, perPage, page)
+		}
+
+		for item, err := range walkPages(ctx, c.listOptions(&listOpts), defaultPerPage, start, fetch) {
+			result := SongResult{Err: err, Cursor: state.encode()}
+			if err == nil {
+				state.Page, state.Skip, state.Fetched = item.next.page, item.next.skip, item.next.fetched
+				result.Song, result.Cursor = item.value, state.encode()
+
+				if filter != nil {
+					keep, done := filter.check(item.value)
+					if done {
+						return
+					}
+					if !keep {
+						continue
+					}
+				}
+			}
+
+			select {
+			case results <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return results
+}
@@ -0,0 +1,174 @@
+package genius_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/natecham/genius"
+)
+
+// streamUntil consumes results of the stream of artist 1 until stop songs were received and returns their IDs and
+// the cursor of the last one. The stream is cancelled and drained.
+func streamUntil(t *testing.T, client *genius.Client, stop int) ([]int, genius.Cursor) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results := client.StreamArtistSongs(ctx, 1, nil)
+
+	var ids []int
+	var cursor genius.Cursor
+	for result := range results {
+		if result.Err != nil {
+			t.Fatal(result.Err)
+		}
+		ids, cursor = append(ids, result.Song.ID), result.Cursor
+		if len(ids) == stop {
+			break
+		}
+	}
+	cancel()
+	for range results {
+	}
+
+	return ids, cursor
+}
+
+func TestResumeArtistSongs(t *testing.T) {
+	tests := []struct {
+		name     string
+		stop     int
+		requests int32
+	}{
+		{"first song", 1, 5},
+		{"mid-page", 25, 5},
+		{"before a page boundary", 49, 5},
+		{"at a page boundary", 50, 4},
+		{"after a page boundary", 51, 4},
+		{"at the last page", 200, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newArtistSongsServer(t, 230, false)
+			ids, cursor := streamUntil(t, genius.NewClient(nil, "token", genius.WithBaseURL(server.URL)), tt.stop)
+			if cursor.Done() {
+				t.Fatalf("cursor after %d of 230 songs is done", tt.stop)
+			}
+
+			// The stream is resumed from another server, which doesn't see requests of the first stream that
+			// were cancelled in flight.
+			server, requests := newArtistSongsServer(t, 230, false)
+			client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+			var last genius.Cursor
+			for result := range client.ResumeArtistSongs(context.Background(), cursor, nil) {
+				if result.Err != nil {
+					t.Fatal(result.Err)
+				}
+				ids, last = append(ids, result.Song.ID), result.Cursor
+			}
+
+			if len(ids) != 230 {
+				t.Fatalf("got %d songs, want 230", len(ids))
+			}
+			for i, id := range ids {
+				if id != i+1 {
+					t.Fatalf("song %d has ID %d, songs were duplicated or skipped", i, id)
+				}
+			}
+			if !last.Done() {
+				t.Error("expected the cursor of the last song to be done")
+			}
+			if n := atomic.LoadInt32(requests); n != tt.requests {
+				t.Errorf("resuming made %d requests, want %d", n, tt.requests)
+			}
+		})
+	}
+}
+
+func TestResumeArtistSongsInvalidCursor(t *testing.T) {
+	server, requests := newArtistSongsServer(t, 230, false)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	for _, cursor := range []genius.Cursor{"not a cursor!", genius.Cursor(base64.RawURLEncoding.EncodeToString([]byte("{")))} {
+		var results []genius.SongResult
+		for result := range client.ResumeArtistSongs(context.Background(), cursor, nil) {
+			results = append(results, result)
+		}
+		if len(results) != 1 || !errors.Is(results[0].Err, genius.ErrInvalidCursor) || results[0].Song != nil {
+			t.Errorf("resuming %q: got %+v, want a single ErrInvalidCursor", cursor, results)
+		}
+		if cursor.Done() {
+			t.Errorf("invalid cursor %q is done", cursor)
+		}
+	}
+	if n := atomic.LoadInt32(requests); n != 0 {
+		t.Errorf("got %d requests for invalid cursors", n)
+	}
+}
+
+func TestStreamArtistSongs(t *testing.T) {
+	server, _ := newArtistSongsServer(t, 120, false)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	id := 0
+	for result := range client.StreamArtistSongs(context.Background(), 1, nil) {
+		if result.Err != nil {
+			t.Fatal(result.Err)
+		}
+		if id++; result.Song.ID != id {
+			t.Fatalf("song %d has ID %d, songs are out of order", id, result.Song.ID)
+		}
+	}
+	if id != 120 {
+		t.Errorf("got %d songs, want 120", id)
+	}
+}
+
+func TestStreamArtistSongsError(t *testing.T) {
+	songs := artistSongsHandler(120, false)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			http.Error(w, "bad page", http.StatusBadRequest)
+			return
+		}
+		songs(w, r)
+	}))
+	t.Cleanup(server.Close)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	var results []genius.SongResult
+	for result := range client.StreamArtistSongs(context.Background(), 1, nil) {
+		results = append(results, result)
+	}
+	if len(results) != 51 {
+		t.Fatalf("got %d results, want the 50 songs of page 1 and an error", len(results))
+	}
+	if last := results[50]; last.Err == nil || last.Song != nil {
+		t.Errorf("got last result %+v, want the error of page 2", last)
+	}
+}
+
+func TestStreamArtistSongsBackpressure(t *testing.T) {
+	server, requests := newArtistSongsServer(t, 500, false)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results := client.StreamArtistSongs(ctx, 1, nil)
+	<-results
+	time.Sleep(100 * time.Millisecond)
+
+	// Page 1 is buffered and page 2 waits to be delivered.
+	if n := atomic.LoadInt32(requests); n != 2 {
+		t.Errorf("got %d requests while the consumer is behind, want 2", n)
+	}
+
+	cancel()
+	for range results {
+	}
+}
@@ -0,0 +1,132 @@
+// Package flac implements tag.Tagger for the Vorbis comments of FLAC files with github.com/go-flac/go-flac.
+package flac
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-flac/flacvorbis"
+	goflac "github.com/go-flac/go-flac"
+	"github.com/natecham/genius/tag"
+)
+
+// lyricsField is the Vorbis comment lyrics are written to, the one most players read.
+const lyricsField = "LYRICS"
+
+// Tagger reads and writes the Vorbis comments of FLAC files, lyrics are written to the LYRICS comment.
+type Tagger struct{}
+
+var _ tag.Tagger = Tagger{}
+
+// ReadTags returns the title, artist and album of the file.
+func (Tagger) ReadTags(path string) (*tag.Tags, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	file, err := goflac.ParseMetadata(f)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := &tag.Tags{}
+	comments, _, err := vorbisComments(file)
+	if err != nil || comments == nil {
+		return tags, err
+	}
+
+	for field, value := range map[string]*string{
+		flacvorbis.FIELD_TITLE:  &tags.Title,
+		flacvorbis.FIELD_ARTIST: &tags.Artist,
+		flacvorbis.FIELD_ALBUM:  &tags.Album,
+	} {
+		values, err := comments.Get(field)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) > 0 {
+			*value = values[0]
+		}
+	}
+
+	return tags, nil
+}
+
+// WriteLyrics replaces the LYRICS comments of the file with lyrics. The file is rewritten to a temporary file that
+// replaces it, so it isn't left half written.
+func (Tagger) WriteLyrics(path string, lyrics string) error {
+	file, err := goflac.ParseFile(path)
+	if err != nil {
+		return err
+	}
+
+	comments, index, err := vorbisComments(file)
+	if err != nil {
+		return err
+	}
+	if comments == nil {
+		comments = flacvorbis.New()
+	}
+
+	kept := comments.Comments[:0]
+	for _, comment := range comments.Comments {
+		field, _, _ := strings.Cut(comment, "=")
+		if !strings.EqualFold(field, lyricsField) {
+			kept = append(kept, comment)
+		}
+	}
+	comments.Comments = kept
+	if err = comments.Add(lyricsField, lyrics); err != nil {
+		return err
+	}
+
+	block := comments.Marshal()
+	if index < 0 {
+		file.Meta = append(file.Meta, &block)
+	} else {
+		file.Meta[index] = &block
+	}
+
+	return replace(path, file.Marshal())
+}
+
+// vorbisComments returns the Vorbis comment block of file and its index, nil and -1 if it has none.
+func vorbisComments(file *goflac.File) (*flacvorbis.MetaDataBlockVorbisComment, int, error) {
+	for i, meta := range file.Meta {
+		if meta.Type == goflac.VorbisComment {
+			comments, err := flacvorbis.ParseFromMetaDataBlock(*meta)
+			return comments, i, err
+		}
+	}
+	return nil, -1, nil
+}
+
+// replace atomically replaces the file at path with data, keeping its permissions.
+func replace(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
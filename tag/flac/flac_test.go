@@ -0,0 +1,108 @@
+package flac_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-flac/flacvorbis"
+	goflac "github.com/go-flac/go-flac"
+	"github.com/natecham/genius/tag/flac"
+)
+
+// writeFile writes a FLAC file whose audio is a bare frame sync code, with comments if any are given.
+func writeFile(t *testing.T, comments ...[2]string) string {
+	t.Helper()
+
+	file := &goflac.File{
+		Meta:   []*goflac.MetaDataBlock{{Type: goflac.StreamInfo, Data: make([]byte, 34)}},
+		Frames: []byte{0xff, 0xf8},
+	}
+	if len(comments) > 0 {
+		block := flacvorbis.New()
+		for _, comment := range comments {
+			if err := block.Add(comment[0], comment[1]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		meta := block.Marshal()
+		file.Meta = append(file.Meta, &meta)
+	}
+
+	path := filepath.Join(t.TempDir(), "song.flac")
+	if err := os.WriteFile(path, file.Marshal(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func readComments(t *testing.T, path string, field string) []string {
+	t.Helper()
+
+	file, err := goflac.ParseFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, meta := range file.Meta {
+		if meta.Type == goflac.VorbisComment {
+			comments, err := flacvorbis.ParseFromMetaDataBlock(*meta)
+			if err != nil {
+				t.Fatal(err)
+			}
+			values, err := comments.Get(field)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return values
+		}
+	}
+	return nil
+}
+
+func TestTagger(t *testing.T) {
+	path := writeFile(t,
+		[2]string{"TITLE", "HUMBLE."},
+		[2]string{"ARTIST", "Kendrick Lamar"},
+		[2]string{"ALBUM", "DAMN."},
+		[2]string{"LYRICS", "Old lyrics"},
+	)
+
+	tagger := flac.Tagger{}
+	tags, err := tagger.ReadTags(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tags.Title != "HUMBLE." || tags.Artist != "Kendrick Lamar" || tags.Album != "DAMN." {
+		t.Errorf("unexpected tags %+v", tags)
+	}
+
+	if err = tagger.WriteLyrics(path, "Sit down\nBe humble"); err != nil {
+		t.Fatal(err)
+	}
+	if lyrics := readComments(t, path, "LYRICS"); len(lyrics) != 1 || lyrics[0] != "Sit down\nBe humble" {
+		t.Errorf("unexpected lyrics %q", lyrics)
+	}
+	if title := readComments(t, path, "TITLE"); len(title) != 1 || title[0] != "HUMBLE." {
+		t.Errorf("expected the title to be kept, got %q", title)
+	}
+}
+
+func TestTaggerWithoutComments(t *testing.T) {
+	path := writeFile(t)
+
+	tagger := flac.Tagger{}
+	tags, err := tagger.ReadTags(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tags.Title != "" || tags.Artist != "" {
+		t.Errorf("expected no tags, got %+v", tags)
+	}
+
+	if err = tagger.WriteLyrics(path, "Lyrics"); err != nil {
+		t.Fatal(err)
+	}
+	if lyrics := readComments(t, path, "LYRICS"); len(lyrics) != 1 || lyrics[0] != "Lyrics" {
+		t.Errorf("unexpected lyrics %q", lyrics)
+	}
+}
@@ -0,0 +1,49 @@
+// Package id3 implements tag.Tagger for ID3v2 tags, as used by MP3 files, with github.com/bogem/id3v2.
+package id3
+
+import (
+	"github.com/bogem/id3v2/v2"
+	"github.com/natecham/genius/tag"
+)
+
+// Tagger reads and writes ID3v2 tags, lyrics are written as a USLT frame.
+type Tagger struct {
+	// Language is the ISO 639-2 code of the lyrics language, e.g. "eng". "XXX", unknown, is written when empty.
+	Language string
+}
+
+var _ tag.Tagger = Tagger{}
+
+// ReadTags returns the title, artist and album of the file.
+func (t Tagger) ReadTags(path string) (*tag.Tags, error) {
+	file, err := id3v2.Open(path, id3v2.Options{Parse: true, ParseFrames: []string{"Title", "Artist", "Album/Movie/Show title"}})
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return &tag.Tags{Title: file.Title(), Artist: file.Artist(), Album: file.Album()}, nil
+}
+
+// WriteLyrics replaces the USLT frames of the file with a frame of lyrics.
+func (t Tagger) WriteLyrics(path string, lyrics string) error {
+	file, err := id3v2.Open(path, id3v2.Options{Parse: true})
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	language := t.Language
+	if language == "" {
+		language = "XXX"
+	}
+
+	file.DeleteFrames(file.CommonID("Unsynchronised lyrics/text transcription"))
+	file.AddUnsynchronisedLyricsFrame(id3v2.UnsynchronisedLyricsFrame{
+		Encoding: id3v2.EncodingUTF8,
+		Language: language,
+		Lyrics:   lyrics,
+	})
+
+	return file.Save()
+}
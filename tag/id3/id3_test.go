@@ -0,0 +1,62 @@
+package id3_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bogem/id3v2/v2"
+	"github.com/natecham/genius/tag/id3"
+)
+
+func TestTagger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "humble.mp3")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := id3v2.Open(path, id3v2.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.SetTitle("HUMBLE.")
+	file.SetArtist("Kendrick Lamar")
+	file.SetAlbum("DAMN.")
+	file.AddUnsynchronisedLyricsFrame(id3v2.UnsynchronisedLyricsFrame{
+		Encoding: id3v2.EncodingUTF8, Language: "eng", Lyrics: "Old lyrics",
+	})
+	if err = file.Save(); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	tagger := id3.Tagger{Language: "eng"}
+	tags, err := tagger.ReadTags(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tags.Title != "HUMBLE." || tags.Artist != "Kendrick Lamar" || tags.Album != "DAMN." {
+		t.Errorf("unexpected tags %+v", tags)
+	}
+
+	if err = tagger.WriteLyrics(path, "Sit down\nBe humble"); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err = id3v2.Open(path, id3v2.Options{Parse: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	frames := file.GetFrames(file.CommonID("Unsynchronised lyrics/text transcription"))
+	if len(frames) != 1 {
+		t.Fatalf("expected 1 lyrics frame, got %d", len(frames))
+	}
+	if uslt := frames[0].(id3v2.UnsynchronisedLyricsFrame); uslt.Lyrics != "Sit down\nBe humble" || uslt.Language != "eng" {
+		t.Errorf("unexpected lyrics frame %+v", uslt)
+	}
+	if file.Title() != "HUMBLE." {
+		t.Errorf("expected the title to be kept, got %q", file.Title())
+	}
+}
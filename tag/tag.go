@@ -0,0 +1,102 @@
+// Package tag writes lyrics fetched from Genius into the tags of audio files.
+//
+// Reading and writing tags is left to a Tagger, so that programs only depend on the tagging libraries they use:
+// tag/id3 writes ID3 USLT frames of MP3 files and tag/flac LYRICS comments of FLAC files.
+package tag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/natecham/genius"
+)
+
+var (
+	// ErrUnsupported is returned for files a Tagger can't read or write.
+	ErrUnsupported = errors.New("tag: unsupported file")
+	// ErrMissingTags is returned for files without the title or artist tags songs are matched by.
+	ErrMissingTags = errors.New("tag: missing title or artist")
+)
+
+// Tags are the tags of an audio file songs are matched by.
+type Tags struct {
+	Title  string
+	Artist string
+	Album  string
+}
+
+// Tagger reads and writes the tags of audio files.
+type Tagger interface {
+	// ReadTags returns the tags of the file at path.
+	ReadTags(path string) (*Tags, error)
+	// WriteLyrics replaces the lyrics tag of the file at path, keeping its other tags.
+	WriteLyrics(path string, lyrics string) error
+}
+
+// Mux is a Tagger dispatching to the Tagger for the extension of a file, e.g.
+//
+//	tag.Mux{".mp3": id3.Tagger{}, ".flac": flac.Tagger{}}
+//
+// Extensions are matched in lower case, files with other extensions are ErrUnsupported.
+type Mux map[string]Tagger
+
+// ReadTags reads the tags of the file with the Tagger for its extension.
+func (m Mux) ReadTags(path string) (*Tags, error) {
+	tagger, err := m.tagger(path)
+	if err != nil {
+		return nil, err
+	}
+	return tagger.ReadTags(path)
+}
+
+// WriteLyrics writes the lyrics of the file with the Tagger for its extension.
+func (m Mux) WriteLyrics(path string, lyrics string) error {
+	tagger, err := m.tagger(path)
+	if err != nil {
+		return err
+	}
+	return tagger.WriteLyrics(path, lyrics)
+}
+
+func (m Mux) tagger(path string) (Tagger, error) {
+	tagger, ok := m[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", ErrUnsupported, path)
+	}
+	return tagger, nil
+}
+
+// Lyrics matches the file at path to a Genius song by its title and artist tags, see Client.MatchTrack, and writes
+// the song's lyrics into the file's tags with tagger. The matched song is returned with its lyrics.
+func Lyrics(ctx context.Context, client genius.GeniusAPI, tagger Tagger, path string) (*genius.Song, error) {
+	song, err := Match(ctx, client, tagger, path)
+	if err != nil {
+		return nil, err
+	}
+
+	song.Lyrics, err = client.GetLyrics(ctx, song.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tagger.WriteLyrics(path, song.Lyrics); err != nil {
+		return nil, err
+	}
+	return song, nil
+}
+
+// Match returns the Genius song matching the title and artist tags of the file at path.
+func Match(ctx context.Context, client genius.GeniusAPI, tagger Tagger, path string) (*genius.Song, error) {
+	tags, err := tagger.ReadTags(path)
+	if err != nil {
+		return nil, err
+	}
+	if tags.Title == "" || tags.Artist == "" {
+		return nil, fmt.Errorf("%w: %s", ErrMissingTags, path)
+	}
+
+	return client.MatchTrack(ctx, tags.Title, tags.Artist, 0)
+}
@@ -0,0 +1,111 @@
+package tag_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/natecham/genius"
+	"github.com/natecham/genius/tag"
+)
+
+// fakeTagger keeps the tags and lyrics of files in memory.
+type fakeTagger struct {
+	tags   map[string]*tag.Tags
+	lyrics map[string]string
+}
+
+func (f *fakeTagger) ReadTags(path string) (*tag.Tags, error) {
+	tags, ok := f.tags[path]
+	if !ok {
+		return nil, fmt.Errorf("no file %s", path)
+	}
+	return tags, nil
+}
+
+func (f *fakeTagger) WriteLyrics(path string, lyrics string) error {
+	f.lyrics[path] = lyrics
+	return nil
+}
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/search":
+			hit := map[string]any{
+				"id":             1,
+				"title":          "HUMBLE.",
+				"artist_names":   "Kendrick Lamar",
+				"primary_artist": map[string]any{"name": "Kendrick Lamar"},
+				"url":            server.URL + "/lyrics",
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"meta": map[string]any{"status": 200},
+				"response": map[string]any{"hits": []map[string]any{
+					{"index": "song", "type": "song", "result": hit},
+				}},
+			})
+		case "/lyrics":
+			fmt.Fprint(w, `<div id="lyrics-root"><div data-lyrics-container="true">Sit down<br/>Be humble</div></div>`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestLyrics(t *testing.T) {
+	server := newServer(t)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+	tagger := &fakeTagger{
+		tags:   map[string]*tag.Tags{"humble.mp3": {Title: "HUMBLE.", Artist: "Kendrick Lamar"}},
+		lyrics: map[string]string{},
+	}
+
+	song, err := tag.Lyrics(context.Background(), client, tagger, "humble.mp3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if song.ID != 1 {
+		t.Errorf("expected song 1, got %d", song.ID)
+	}
+	if song.Lyrics == "" || tagger.lyrics["humble.mp3"] != song.Lyrics {
+		t.Errorf("expected the song's lyrics %q to be written, got %q", song.Lyrics, tagger.lyrics["humble.mp3"])
+	}
+}
+
+func TestLyricsMissingTags(t *testing.T) {
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(newServer(t).URL))
+	tagger := &fakeTagger{tags: map[string]*tag.Tags{"untitled.mp3": {Artist: "Kendrick Lamar"}}}
+
+	_, err := tag.Lyrics(context.Background(), client, tagger, "untitled.mp3")
+	if !errors.Is(err, tag.ErrMissingTags) {
+		t.Fatalf("expected ErrMissingTags, got %v", err)
+	}
+}
+
+func TestMux(t *testing.T) {
+	mp3 := &fakeTagger{tags: map[string]*tag.Tags{"song.MP3": {Title: "Song"}}, lyrics: map[string]string{}}
+	mux := tag.Mux{".mp3": mp3}
+
+	tags, err := mux.ReadTags("song.MP3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tags.Title != "Song" {
+		t.Errorf("expected title Song, got %q", tags.Title)
+	}
+
+	if err = mux.WriteLyrics("song.ogg", "lyrics"); !errors.Is(err, tag.ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported, got %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package genius
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// Timeouts of the HTTP client NewClient creates when none is passed.
+const (
+	defaultTimeout               = 60 * time.Second
+	defaultDialTimeout           = 10 * time.Second
+	defaultResponseHeaderTimeout = 30 * time.Second
+)
+
+// newHTTPClient returns an HTTP client for bulk use: it keeps enough idle connections to Genius for concurrent
+// pagination and, unlike http.DefaultClient, doesn't wait forever for a hung connection.
+func newHTTPClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   defaultDialTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   32,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: defaultResponseHeaderTimeout,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	return &http.Client{Transport: transport, Timeout: defaultTimeout}
+}
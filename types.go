@@ -1,45 +1,52 @@
 package genius
 
-// GeniusResponse is an actual response object from Genius API
-// Consist links to possible retrievable objects: Artist, Song, etc.
-type GeniusResponse struct {
-	Meta     *Meta     `json:"meta"`
-	Response *Response `json:"response"`
-}
+import "encoding/json"
 
+// Meta is the status part of every Genius API response.
 type Meta struct {
 	Status  int    `json:"status"`
 	Message string `json:"message"`
 }
 
-type Response struct {
-	Artist      *Artist       `json:"artist"`
-	Album       *Album        `json:"album"`
-	AlbumTracks []*AlbumTrack `json:"tracks"`
-	Albums      []*Album      `json:"albums"`
-	Song        *Song         `json:"song"`
-	Songs       []*Song       `json:"songs"`
-	Annotation  *Annotation   `json:"annotation"`
-	User        *User         `json:"user"`
-	NextPage    int           `json:"next_page"`
-	Hits        []*Hit        `json:"hits"`
-	WebPage     *WebPage      `json:"web_page"`
-	Sections    []Sections    `json:"sections"`
+// PageData is the payload of the unofficial page_data API backing genius.com pages.
+type PageData struct {
+	Song   *Song   `json:"song"`
+	Artist *Artist `json:"artist"`
+	Album  *Album  `json:"album"`
 }
 
 // WithBody is a struct to take care of different formats of field "body"
-// If "textFormat" was either "html" or "plain" Process method will put result string in Body field
-// In case of "dom" use RawBody.
+// If the text format was either FormatHTML or FormatPlain Process method will put result string in Body field
+// In case of FormatDOM Process puts the parsed tree in Dom and its plain text rendering in Body, FormatMarkdown its
+// Markdown rendering.
 type WithBody struct {
 	Body    string                 `json:"-"`
+	Dom     *Dom                   `json:"-"`
 	RawBody map[string]interface{} `json:"body"`
 }
 
-// Process will check the textFormat and put result string in Body field if textFormat was "html" or "plain".
-func (b *WithBody) Process(textFormat string) {
-	if textFormat != "dom" {
+// Process will check the textFormat and put result string in Body field if textFormat was FormatHTML or FormatPlain.
+// For FormatDOM the body is parsed into Dom and rendered as plain text, for FormatMarkdown as Markdown.
+func (b *WithBody) Process(textFormat TextFormat) {
+	if textFormat.apiFormat() != FormatDOM {
 		for _, v := range b.RawBody {
-			b.Body = v.(string)
+			b.Body, _ = v.(string)
+		}
+		return
+	}
+
+	raw, err := json.Marshal(b.RawBody["dom"])
+	if err != nil {
+		return
+	}
+
+	var dom Dom
+	if json.Unmarshal(raw, &dom) == nil {
+		b.Dom = &dom
+		if textFormat == FormatMarkdown {
+			b.Body = dom.RenderMarkdown()
+		} else {
+			b.Body = dom.RenderPlain()
 		}
 	}
 }
@@ -47,25 +54,39 @@ func (b *WithBody) Process(textFormat string) {
 // Annotation is annotation on Genius API.
 type Annotation struct {
 	WithBody
-	APIPath             string        `json:"api_path"`
-	CommentCount        int           `json:"comment_count"`
-	Community           bool          `json:"community"`
-	CustomPreview       string        `json:"custom_preview"`
-	HasVoters           bool          `json:"has_voters"`
-	ID                  int           `json:"id"`
-	Pinned              bool          `json:"pinned"`
-	ShareURL            string        `json:"share_url"`
-	Source              string        `json:"source"`
-	State               string        `json:"state"`
-	URL                 string        `json:"url"`
-	Verified            bool          `json:"verified"`
-	VotesTotal          int           `json:"voters_total"`
+	APIPath       string          `json:"api_path"`
+	CommentCount  int             `json:"comment_count"`
+	Community     bool            `json:"community"`
+	CustomPreview string          `json:"custom_preview"`
+	HasVoters     bool            `json:"has_voters"`
+	ID            int             `json:"id"`
+	Pinned        bool            `json:"pinned"`
+	ShareURL      string          `json:"share_url"`
+	Source        string          `json:"source"`
+	State         AnnotationState `json:"state"`
+	URL           string          `json:"url"`
+	// Verified is set for annotations by the artist or verified by a verified contributor, see VerifiedBy.
+	Verified bool `json:"verified"`
+	// VotesTotal is the number of upvotes minus the number of downvotes.
+	VotesTotal          int           `json:"votes_total"`
 	CurrentUserMetadata *UserMetadata `json:"current_user_metadata"`
 	Authors             []*Author     `json:"authors"`
-	CosignedBy          []*Artist     `json:"cosigned_by"`
-	VerifiedBy          *User         `json:"verified_by"`
+	// CosignedBy are the verified users who cosigned the annotation.
+	CosignedBy []*User `json:"cosigned_by"`
+	VerifiedBy *User   `json:"verified_by"`
+
+	raw json.RawMessage
 }
 
+// AnnotationState is the moderation state of an annotation.
+type AnnotationState string
+
+const (
+	AnnotationAccepted AnnotationState = "accepted"
+	AnnotationPending  AnnotationState = "pending"
+	AnnotationRejected AnnotationState = "rejected"
+)
+
 type Author struct {
 	Attribution float64 `json:"attribution"`
 	PinnedRole  string  `json:"pinned_role"`
@@ -88,11 +109,17 @@ type DescriptionAnnotation struct {
 	Annotatable          *Annotatable  `json:"annotatable"`
 	Annotations          []*Annotation `json:"annotations"`
 
+	raw json.RawMessage
+
 	Range struct {
 		Content string `json:"content"`
 	} `json:"range"`
 }
 
+// Referent is an annotated fragment of a song or web page together with its annotations.
+// Song and artist description annotations are referents as well.
+type Referent = DescriptionAnnotation
+
 type Annotatable struct {
 	APIPath   string `json:"api_path"`
 	Context   string `json:"context"`
@@ -186,6 +213,8 @@ type FactTrack struct {
 	HelpLinkURL  string `json:"help_link_url"`
 }
 
+// Stats are popularity and contribution statistics of a song, album or artist.
+// Genius only reports some of them depending on the endpoint, missing ones are 0.
 type Stats struct {
 	AcceptedAnnotations   int  `json:"accepted_annotations"`
 	Contributors          int  `json:"contributors"`
@@ -199,35 +228,66 @@ type Stats struct {
 }
 
 type Album struct {
-	APIPath              string `json:"api_path"`
-	CommentCount         int    `json:"comment_count"`
-	CoverArtURL          string `json:"cover_art_url"`
-	CustomHeaderImageURL string `json:"custom_header_image_url"`
-	FullTitle            string `json:"full_title"`
-	HeaderImageURL       string `json:"header_image_url"`
-	ID                   int    `json:"id"`
-	LockState            string `json:"lock_state"`
-	Name                 string `json:"name"`
-	PyongsCount          int    `json:"pyongs_count"`
-	ReleaseDate          string `json:"release_date"`
-	//ReleaseDateComponents DateComponents     `json:"release_date_components"`
-	ReleaseDateForDisplay string              `json:"release_date_for_display"`
-	URL                   string              `json:"url"`
-	CurrentUserMetadata   *UserMetadata       `json:"current_user_metadata"`
-	SongPageviews         int                 `json:"song_pageviews"`
-	Artist                *Artist             `json:"artist"`
-	CoverArts             []*CoverArt         `json:"cover_arts"`
-	DescriptionAnnotation *Annotation         `json:"description_annotation"`
-	PerformanceGroups     []*PerformanceGroup `json:"performance_groups"`
-	SongPerformances      []*Performance      `json:"song_performances"`
-	Tracks                []*AlbumTrack       `json:"tracks"`
-}
-
+	APIPath               string                 `json:"api_path"`
+	CommentCount          int                    `json:"comment_count"`
+	CoverArtThumbnailURL  string                 `json:"cover_art_thumbnail_url"`
+	CoverArtURL           string                 `json:"cover_art_url"`
+	CustomHeaderImageURL  string                 `json:"custom_header_image_url"`
+	FullTitle             string                 `json:"full_title"`
+	HeaderImageURL        string                 `json:"header_image_url"`
+	ID                    int                    `json:"id"`
+	LockState             string                 `json:"lock_state"`
+	Name                  string                 `json:"name"`
+	NameWithArtist        string                 `json:"name_with_artist"`
+	PrimaryArtistNames    string                 `json:"primary_artist_names"`
+	PyongsCount           int                    `json:"pyongs_count"`
+	ReleaseDate           string                 `json:"release_date"`
+	ReleaseDateComponents *ReleaseDateComponents `json:"release_date_components"`
+	ReleaseDateForDisplay string                 `json:"release_date_for_display"`
+	URL                   string                 `json:"url"`
+	CurrentUserMetadata   *UserMetadata          `json:"current_user_metadata"`
+	SongPageviews         int                    `json:"song_pageviews"`
+	Stats                 *Stats                 `json:"stats"`
+	Artist                *Artist                `json:"artist"`
+	PrimaryArtists        []*Artist              `json:"primary_artists"`
+	CoverArts             []*CoverArt            `json:"cover_arts"`
+	DescriptionAnnotation *Annotation            `json:"description_annotation"`
+	PerformanceGroups     []*PerformanceGroup    `json:"performance_groups"`
+	SongPerformances      []*Performance         `json:"song_performances"`
+	Tracks                []*AlbumTrack          `json:"tracks"`
+
+	raw json.RawMessage
+}
+
+// AlbumTrack is a song on an album.
+//
+// The official API reports the position as "number", album pages as "track_number"; both end up in Number.
+// Disc is only set for multi-disc albums where Genius reports it.
 type AlbumTrack struct {
 	Number int  `json:"number"`
+	Disc   int  `json:"disc_number"`
 	Song   Song `json:"song"`
 }
 
+// UnmarshalJSON decodes both the official "number" and the unofficial "track_number" track positions.
+func (t *AlbumTrack) UnmarshalJSON(data []byte) error {
+	type albumTrack AlbumTrack
+	aux := struct {
+		*albumTrack
+		TrackNumber int `json:"track_number"`
+	}{albumTrack: (*albumTrack)(t)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if t.Number == 0 {
+		t.Number = aux.TrackNumber
+	}
+
+	return nil
+}
+
+// CoverArt is one of an album's cover art images, ImageURL points at the full size variant.
 type CoverArt struct {
 	Annotated         bool   `json:"annotated"`
 	APIPath           string `json:"api_path"`
@@ -237,6 +297,7 @@ type CoverArt struct {
 	URL               string `json:"url"`
 }
 
+// PerformanceGroup credits artists for a role, e.g. "Producers", across an album.
 type PerformanceGroup struct {
 	Label   string    `json:"label"`
 	Artists []*Artist `json:"artists"`
@@ -264,24 +325,34 @@ type Song struct {
 	Type                                      string                 `json:"_type"`
 	AnnotationCount                           int                    `json:"annotation_count"`
 	APIPath                                   string                 `json:"api_path"`
+	AppleMusicID                              string                 `json:"apple_music_id"`
+	AppleMusicPlayerURL                       string                 `json:"apple_music_player_url"`
 	ArtistNames                               string                 `json:"artist_names"`
-	Description                               *interface{}           `json:"description"`
+	Description                               *Description           `json:"description"`
+	DurationMS                                int                    `json:"duration_ms"`
 	EmbedContent                              string                 `json:"embed_content"`
+	Explicit                                  bool                   `json:"explicit"`
 	FactTrack                                 *FactTrack             `json:"fact_track"`
-	FeaturedVideo                             bool                   `json:"features_video"`
+	FeaturedVideo                             bool                   `json:"featured_video"`
 	FullTitle                                 string                 `json:"full_title"`
 	HeaderImageThumbnailURL                   string                 `json:"header_image_thumbnail_url"`
 	HeaderImageURL                            string                 `json:"header_image_url"`
+	Hidden                                    bool                   `json:"hidden"`
 	ID                                        int                    `json:"id"`
 	Instrumental                              bool                   `json:"instrumental"`
+	IsMusic                                   bool                   `json:"is_music"`
 	Language                                  string                 `json:"language"`
 	Lyrics                                    string                 `json:"lyrics"`
 	LyricsOwnerID                             int                    `json:"lyrics_owner_id"`
+	LyricsPlaceholderReason                   string                 `json:"lyrics_placeholder_reason"`
 	LyricsState                               string                 `json:"lyrics_state"`
 	LyricsUpdatedAt                           int                    `json:"lyrics_updated_at"`
 	Name                                      string                 `json:"name"`
 	Path                                      string                 `json:"path"`
-	PyongsCount                               int                    `json:"pyong_count"`
+	PendingLyricsEditsCount                   int                    `json:"pending_lyrics_edits_count"`
+	PrimaryArtistNames                        string                 `json:"primary_artist_names"`
+	Published                                 bool                   `json:"published"`
+	PyongsCount                               int                    `json:"pyongs_count"`
 	RecordingLocation                         string                 `json:"recording_location"`
 	ReleaseDate                               string                 `json:"release_date"`
 	RelationshipsIndexURL                     string                 `json:"relationships_index_url"`
@@ -290,23 +361,47 @@ type Song struct {
 	ReleaseDateWithAbbreviatedMonthForDisplay string                 `json:"release_date_with_abbreviated_month_for_display"`
 	SongArtImageThumbnailURL                  string                 `json:"song_art_image_thumbnail_url"`
 	SongArtImageURL                           string                 `json:"song_art_image_url"`
+	SongArtPrimaryColor                       string                 `json:"song_art_primary_color"`
+	SongArtSecondaryColor                     string                 `json:"song_art_secondary_color"`
+	SongArtTextColor                          string                 `json:"song_art_text_color"`
 	Stats                                     *Stats                 `json:"stats"`
 	Title                                     string                 `json:"title"`
 	TitleWithFeatured                         string                 `json:"title_with_featured"`
+	UpdatedByHumanAt                          int                    `json:"updated_by_human_at"`
 	URL                                       string                 `json:"url"`
 	CurrentUserMetadata                       *UserMetadata          `json:"current_user_metadata"`
 	Album                                     *Album                 `json:"album"`
+	Albums                                    []*Album               `json:"albums"`
 	CustomPerformances                        []*CustomPerformance   `json:"custom_performances"`
 	DescriptionAnnotation                     *DescriptionAnnotation `json:"description_annotation"`
-	FeaturedArtists                           []*Artist              `json:"featured_artists"`
+	FeaturedArtists                           []ArtistRef            `json:"featured_artists"`
+	LyricsMarkedCompleteBy                    []*User                `json:"lyrics_marked_complete_by"`
+	LyricsMarkedStaffApprovedBy               *User                  `json:"lyrics_marked_staff_approved_by"`
 	Media                                     []*Media               `json:"media"`
 	PrimaryArtist                             *Artist                `json:"primary_artist"`
-	ProducerArtists                           []*Artist              `json:"producer_artists"`
+	PrimaryArtists                            []*Artist              `json:"primary_artists"`
+	ProducerArtists                           []ArtistRef            `json:"producer_artists"`
 	SongRelationships                         []*SongRelationship    `json:"song_relationships"`
+	TranslationSongs                          []*TranslationSong     `json:"translation_songs"`
 	VerifiedAnnotationsBy                     []*User                `json:"verified_annotations_by"`
 	VerifiedContributors                      []*Contributor         `json:"verified_contributors"`
 	VerifiedLyricsBy                          []*User                `json:"verified_lyrics_by"`
-	WriterArtists                             []*Artist              `json:"writer_artists"`
+	WriterArtists                             []ArtistRef            `json:"writer_artists"`
+
+	raw json.RawMessage
+	// descriptions caches the descriptions rendered by FetchDescription, it is created when the song is decoded.
+	descriptions *descriptionCache
+}
+
+// TranslationSong is a translation of a song's lyrics published as a separate song.
+type TranslationSong struct {
+	APIPath     string `json:"api_path"`
+	ID          int    `json:"id"`
+	Language    string `json:"language"`
+	LyricsState string `json:"lyrics_state"`
+	Path        string `json:"path"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
 }
 
 type CustomPerformance struct {
@@ -320,9 +415,12 @@ type Contributor struct {
 	User          *User    `json:"user"`
 }
 
+// SongRelationship lists the songs related to a song in one way, e.g. the songs it samples.
 type SongRelationship struct {
-	Type  string  `json:"type"`
-	Songs []*Song `json:"songs"`
+	RelationshipType RelationshipType `json:"relationship_type"`
+	Type             RelationshipType `json:"type"`
+	URL              string           `json:"url"`
+	Songs            []*Song          `json:"songs"`
 }
 
 // WebPage is web_page on Genius API.
@@ -356,7 +454,7 @@ type ReleaseDateComponents struct {
 type Artist struct {
 	AlternateNames        []string               `json:"alternate_names"`
 	APIPath               string                 `json:"api_path"`
-	Description           *interface{}           `json:"description"`
+	Description           *Description           `json:"description"`
 	FacebookName          string                 `json:"facebook_name"`
 	FollowersCount        int                    `json:"followers_count"`
 	HeaderImageURL        string                 `json:"header_image_url"`
@@ -370,18 +468,21 @@ type Artist struct {
 	URL                   string                 `json:"url"`
 	CurrentUserMetadata   *UserMetadata          `json:"current_user_metadata"`
 	IQ                    int                    `json:"iq"`
+	Stats                 *Stats                 `json:"stats"`
 	DescriptionAnnotation *DescriptionAnnotation `json:"description_annotation"`
 	User                  *User                  `json:"user"`
+
+	raw json.RawMessage
 }
 
 // Hit is a hit on Genius API
-// Used in /search handler
-// Includes song results only.
+// Used in /search and /search/multi handlers
+// Result depends on Type, use the As* accessors to decode it.
 type Hit struct {
-	Highlights []interface{} `json:"highlights"`
-	Index      string        `json:"index"`
-	Type       string        `json:"type"`
-	Result     *Song         `json:"result"`
+	Highlights []interface{}   `json:"highlights"`
+	Index      string          `json:"index"`
+	Type       string          `json:"type"`
+	Result     json.RawMessage `json:"result"`
 }
 
 type Sections struct {
@@ -389,12 +490,10 @@ type Sections struct {
 	Hits []Hit  `json:"hits"`
 }
 
+// Description is a text in any of the formats Genius returns, depending on the requested text_format only one
+// of the fields is set.
 type Description struct {
-	Plain string `json:"plain"`
-	Dom   *Dom   `json:"dom"`
-}
-
-type Dom struct {
-	Tag      string        `json:"tag"`
-	Children []interface{} `json:"children"`
+	Plain string `json:"plain,omitempty"`
+	HTML  string `json:"html,omitempty"`
+	Dom   *Dom   `json:"dom,omitempty"`
 }
@@ -0,0 +1,228 @@
+package genius
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const defaultPollInterval = time.Hour
+
+// Watcher polls artists for songs and albums released since it started watching them, see Client.NewWatcher.
+type Watcher struct {
+	client   *Client
+	artists  []int
+	interval time.Duration
+	onSong   func(ctx context.Context, artistID int, song *Song)
+	onAlbum  func(ctx context.Context, artistID int, album *Album)
+	onLyrics func(ctx context.Context, artistID int, song *Song)
+	onError  func(ctx context.Context, err error)
+	webhooks []webhook
+
+	// mu guards songs and albums, it isn't held during requests, callbacks and webhook deliveries.
+	mu sync.Mutex
+	// songs are the lyrics update times of the songs seen so far and albums the IDs of the albums seen so far, by
+	// artist ID. Artists are missing until their first successful poll.
+	songs  map[int]map[int]int
+	albums map[int]map[int]bool
+}
+
+type WatcherOption func(watcher *Watcher)
+
+// WithPollInterval sets how often Run polls the artists, hourly by default.
+func WithPollInterval(interval time.Duration) WatcherOption {
+	return func(watcher *Watcher) {
+		watcher.interval = interval
+	}
+}
+
+// WithOnNewSong sets a callback for songs added to a watched artist's catalog.
+func WithOnNewSong(fn func(ctx context.Context, artistID int, song *Song)) WatcherOption {
+	return func(watcher *Watcher) {
+		watcher.onSong = fn
+	}
+}
+
+// WithOnNewAlbum sets a callback for albums added to a watched artist's catalog. Albums are only polled when it is
+// set.
+func WithOnNewAlbum(fn func(ctx context.Context, artistID int, album *Album)) WatcherOption {
+	return func(watcher *Watcher) {
+		watcher.onAlbum = fn
+	}
+}
+
+// WithOnLyricsChanged sets a callback for songs of a watched artist whose lyrics changed, detected by the lyrics update
+// time of the songs listed for the artist.
+func WithOnLyricsChanged(fn func(ctx context.Context, artistID int, song *Song)) WatcherOption {
+	return func(watcher *Watcher) {
+		watcher.onLyrics = fn
+	}
+}
+
+// WithOnWatchError sets a callback for errors of the polls made by Run, which keeps polling.
+func WithOnWatchError(fn func(ctx context.Context, err error)) WatcherOption {
+	return func(watcher *Watcher) {
+		watcher.onError = fn
+	}
+}
+
+// NewWatcher returns a Watcher of the artists with the IDs artistIDs.
+//
+// The first poll of an artist records its catalog, callbacks are invoked and webhooks notified for the songs and
+// albums added to it and the lyrics changed in later polls. Requests are made through the client, so they are
+// subject to its rate limit, see WithRateLimit.
+func (c *Client) NewWatcher(artistIDs []int, opts ...WatcherOption) *Watcher {
+	watcher := &Watcher{
+		client:   c,
+		artists:  artistIDs,
+		interval: defaultPollInterval,
+		songs:    make(map[int]map[int]int),
+		albums:   make(map[int]map[int]bool),
+	}
+
+	for _, opt := range opts {
+		opt(watcher)
+	}
+
+	return watcher
+}
+
+// Run polls the artists right away and then at the poll interval until ctx is done, returning its error.
+func (w *Watcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.Poll(ctx); err != nil && w.onError != nil && ctx.Err() == nil {
+			w.onError(ctx, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll checks the artists for new songs and albums and changed lyrics once, invoking the callbacks and notifying the
+// webhooks for them. Artists that fail to be polled are retried by the next poll and don't prevent the others from
+// being polled. Concurrent polls report every change once.
+func (w *Watcher) Poll(ctx context.Context) error {
+	var errs []error
+	for _, artistID := range w.artists {
+		if err := w.pollSongs(ctx, artistID, &errs); err != nil {
+			errs = append(errs, fmt.Errorf("polling songs of artist %d: %w", artistID, err))
+		}
+		if w.onAlbum == nil && len(w.webhooks) == 0 {
+			continue
+		}
+		if err := w.pollAlbums(ctx, artistID, &errs); err != nil {
+			errs = append(errs, fmt.Errorf("polling albums of artist %d: %w", artistID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// pollSongs polls the songs of the artist, appending failed webhook deliveries to errs.
+func (w *Watcher) pollSongs(ctx context.Context, artistID int, errs *[]error) error {
+	var songs []*Song
+	for song, err := range w.client.ArtistSongs(ctx, artistID, &ArtistSongsOptions{Sort: SortReleaseDate}) {
+		if err != nil {
+			return err
+		}
+		songs = append(songs, song)
+	}
+
+	for _, event := range w.songEvents(artistID, songs) {
+		*errs = append(*errs, w.notify(ctx, event)...)
+	}
+	return nil
+}
+
+// songEvents records the songs listed for the artist and returns the events of the songs added or whose lyrics
+// changed since they were last recorded.
+func (w *Watcher) songEvents(artistID int, songs []*Song) []*WatchEvent {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	seen, known := w.songs[artistID]
+	if !known {
+		seen = make(map[int]int, len(songs))
+		w.songs[artistID] = seen
+	}
+
+	var events []*WatchEvent
+	for _, song := range songs {
+		updatedAt, ok := seen[song.ID]
+		seen[song.ID] = song.LyricsUpdatedAt
+		switch {
+		case !known:
+		case !ok:
+			events = append(events, w.newEvent(WatchEventNewSong, artistID, song, nil))
+		case song.LyricsUpdatedAt > updatedAt:
+			events = append(events, w.newEvent(WatchEventLyricsChanged, artistID, song, nil))
+		}
+	}
+
+	return events
+}
+
+// pollAlbums polls the albums of the artist, appending failed webhook deliveries to errs.
+func (w *Watcher) pollAlbums(ctx context.Context, artistID int, errs *[]error) error {
+	albums, err := w.client.GetArtistAlbums(ctx, artistID, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range w.albumEvents(artistID, albums) {
+		*errs = append(*errs, w.notify(ctx, event)...)
+	}
+	return nil
+}
+
+// albumEvents records the albums listed for the artist and returns the events of the albums added since the last
+// time.
+func (w *Watcher) albumEvents(artistID int, albums []*Album) []*WatchEvent {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	seen, known := w.albums[artistID]
+	if !known {
+		seen = make(map[int]bool, len(albums))
+		w.albums[artistID] = seen
+	}
+
+	var events []*WatchEvent
+	for _, album := range albums {
+		if seen[album.ID] {
+			continue
+		}
+		seen[album.ID] = true
+		if known {
+			events = append(events, w.newEvent(WatchEventNewAlbum, artistID, nil, album))
+		}
+	}
+
+	return events
+}
+
+// notify invokes the callback for event and delivers it to the webhooks, returning the failed deliveries.
+func (w *Watcher) notify(ctx context.Context, event *WatchEvent) []error {
+	switch {
+	case event.Type == WatchEventNewSong && w.onSong != nil:
+		w.onSong(ctx, event.ArtistID, event.Song)
+	case event.Type == WatchEventLyricsChanged && w.onLyrics != nil:
+		w.onLyrics(ctx, event.ArtistID, event.Song)
+	case event.Type == WatchEventNewAlbum && w.onAlbum != nil:
+		w.onAlbum(ctx, event.ArtistID, event.Album)
+	}
+	return w.deliver(ctx, event)
+}
+
+func (w *Watcher) newEvent(eventType WatchEventType, artistID int, song *Song, album *Album) *WatchEvent {
+	return &WatchEvent{Type: eventType, ArtistID: artistID, Song: song, Album: album, Time: time.Now().UTC()}
+}
@@ -0,0 +1,186 @@
+package genius_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/natecham/genius"
+)
+
+// newCatalogServer serves the songs and albums of artist 1, which the returned function adds to.
+func newCatalogServer(t *testing.T) (*httptest.Server, func(kind string, id int)) {
+	t.Helper()
+
+	var mu sync.Mutex
+	catalog := map[string][]map[string]any{"songs": {}, "albums": {}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		kind := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		items, ok := catalog[kind]
+		if !ok || !strings.HasPrefix(r.URL.Path, "/artists/1/") {
+			http.NotFound(w, r)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"meta":     map[string]any{"status": 200},
+			"response": map[string]any{kind: items, "next_page": nil},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	add := func(kind string, id int) {
+		mu.Lock()
+		defer mu.Unlock()
+		catalog[kind] = append(catalog[kind], map[string]any{"id": id})
+	}
+
+	return server, add
+}
+
+func TestWatcherPoll(t *testing.T) {
+	ctx := context.Background()
+	server, add := newCatalogServer(t)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL), genius.WithUnofficialURL(server.URL))
+
+	var songs, albums []int
+	watcher := client.NewWatcher([]int{1},
+		genius.WithOnNewSong(func(ctx context.Context, artistID int, song *genius.Song) {
+			songs = append(songs, song.ID)
+		}),
+		genius.WithOnNewAlbum(func(ctx context.Context, artistID int, album *genius.Album) {
+			albums = append(albums, album.ID)
+		}),
+	)
+
+	add("songs", 1)
+	add("albums", 10)
+	// The first poll only records the catalog.
+	if err := watcher.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if songs != nil || albums != nil {
+		t.Fatalf("callbacks invoked for the existing catalog: songs %v, albums %v", songs, albums)
+	}
+
+	add("songs", 2)
+	add("songs", 3)
+	add("albums", 11)
+	if err := watcher.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := watcher.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(songs, []int{2, 3}) {
+		t.Errorf("got new songs %v, want [2 3]", songs)
+	}
+	if !slices.Equal(albums, []int{11}) {
+		t.Errorf("got new albums %v, want [11]", albums)
+	}
+}
+
+func TestWatcherRunReportsErrors(t *testing.T) {
+	server, _ := newCatalogServer(t)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var polls int
+	watcher := client.NewWatcher([]int{2},
+		genius.WithPollInterval(time.Millisecond),
+		genius.WithOnWatchError(func(ctx context.Context, err error) {
+			if !strings.Contains(err.Error(), "artist 2") {
+				t.Errorf("unexpected error %v", err)
+			}
+			// Run keeps polling after errors.
+			if polls++; polls == 2 {
+				cancel()
+			}
+		}),
+	)
+
+	if err := watcher.Run(ctx); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if polls != 2 {
+		t.Errorf("expected 2 failed polls, got %d", polls)
+	}
+}
+
+func TestWatcherConcurrentPolls(t *testing.T) {
+	var mu sync.Mutex
+	songs := []map[string]any{{"id": 1}}
+	var concurrent bool
+	var waiting atomic.Int32
+	together := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		wait := concurrent
+		mu.Unlock()
+		// The requests of concurrent polls are only answered once both polls made theirs.
+		if wait {
+			if waiting.Add(1) == 2 {
+				close(together)
+			}
+			select {
+			case <-together:
+			case <-time.After(5 * time.Second):
+				http.Error(w, "polls were serialized", http.StatusBadRequest)
+				return
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"meta":     map[string]any{"status": 200},
+			"response": map[string]any{"songs": songs, "next_page": nil},
+		})
+	}))
+	t.Cleanup(server.Close)
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(server.URL))
+
+	var reported []int
+	watcher := client.NewWatcher([]int{1}, genius.WithOnNewSong(func(ctx context.Context, artistID int, song *genius.Song) {
+		mu.Lock()
+		defer mu.Unlock()
+		reported = append(reported, song.ID)
+	}))
+	if err := watcher.Poll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	songs = append(songs, map[string]any{"id": 2})
+	concurrent = true
+	mu.Unlock()
+
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := watcher.Poll(context.Background()); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !slices.Equal(reported, []int{2}) {
+		t.Errorf("got new songs %v, want song 2 reported once", reported)
+	}
+}
@@ -0,0 +1,116 @@
+package genius
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// WatchEventType is the type of a change detected by a Watcher.
+type WatchEventType string
+
+const (
+	WatchEventNewSong       WatchEventType = "new_song"
+	WatchEventNewAlbum      WatchEventType = "new_album"
+	WatchEventLyricsChanged WatchEventType = "lyrics_changed"
+)
+
+// Headers of webhook requests.
+const (
+	// WebhookEventHeader is the type of the event.
+	WebhookEventHeader = "X-Genius-Event"
+	// WebhookSignatureHeader is "sha256=" and the hex HMAC-SHA256 of the body keyed with the secret of the webhook.
+	WebhookSignatureHeader = "X-Genius-Signature"
+)
+
+// WatchEvent is a change detected by a Watcher, the JSON payload of its webhooks.
+type WatchEvent struct {
+	Type     WatchEventType `json:"type"`
+	ArtistID int            `json:"artist_id"`
+	// Song is the new song or the song whose lyrics changed, Album the new album.
+	Song  *Song  `json:"song,omitempty"`
+	Album *Album `json:"album,omitempty"`
+	// Time is when the change was detected.
+	Time time.Time `json:"time"`
+}
+
+type webhook struct {
+	url    string
+	secret []byte
+}
+
+// WithWebhook makes the watcher POST a WatchEvent to url for every change it detects, signed with secret in the
+// WebhookSignatureHeader, see VerifyWebhookSignature. It can be set multiple times, events are posted to every
+// webhook. Failed deliveries are errors of the poll that detected the change, and aren't retried.
+func WithWebhook(url string, secret string) WatcherOption {
+	return func(watcher *Watcher) {
+		watcher.webhooks = append(watcher.webhooks, webhook{url: url, secret: []byte(secret)})
+	}
+}
+
+// SignWebhook returns the WebhookSignatureHeader of body for secret.
+func SignWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature reports whether signature, the WebhookSignatureHeader of a webhook request, is the signature
+// of body for secret. Receivers should verify requests before trusting their payload.
+func VerifyWebhookSignature(secret string, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+	return hmac.Equal([]byte(SignWebhook(secret, body)), []byte(signature))
+}
+
+// post delivers event to the webhook with httpClient.
+func (h webhook) post(ctx context.Context, httpClient *http.Client, event *WatchEvent, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, string(event.Type))
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(string(h.secret), body))
+
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook %s: %s", h.url, res.Status)
+	}
+	return nil
+}
+
+// deliver posts event to the webhooks of the watcher, returning the failed deliveries.
+func (w *Watcher) deliver(ctx context.Context, event *WatchEvent) []error {
+	if len(w.webhooks) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return []error{err}
+	}
+
+	var errs []error
+	for _, h := range w.webhooks {
+		if err = h.post(ctx, w.client.client, event, body); err != nil {
+			errs = append(errs, fmt.Errorf("delivering %s event: %w", event.Type, err))
+		}
+	}
+	return errs
+}
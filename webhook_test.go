@@ -0,0 +1,110 @@
+package genius_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/natecham/genius"
+)
+
+func TestWatcherWebhook(t *testing.T) {
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	songs := []map[string]any{{"id": 1, "lyrics_updated_at": 100}}
+	catalog := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		kind := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		items := []map[string]any{}
+		if kind == "songs" {
+			items = songs
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"meta":     map[string]any{"status": 200},
+			"response": map[string]any{kind: items, "next_page": nil},
+		})
+	}))
+	t.Cleanup(catalog.Close)
+
+	var events []genius.WatchEvent
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !genius.VerifyWebhookSignature("secret", body, r.Header.Get(genius.WebhookSignatureHeader)) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+		var event genius.WatchEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.Header.Get(genius.WebhookEventHeader) != string(event.Type) {
+			http.Error(w, "event header doesn't match the payload", http.StatusBadRequest)
+			return
+		}
+		events = append(events, event)
+	}))
+	t.Cleanup(receiver.Close)
+
+	client := genius.NewClient(nil, "token", genius.WithBaseURL(catalog.URL), genius.WithUnofficialURL(catalog.URL))
+	watcher := client.NewWatcher([]int{1}, genius.WithWebhook(receiver.URL, "secret"))
+	if err := watcher.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	songs = append(songs, map[string]any{"id": 2, "lyrics_updated_at": 100})
+	songs[0]["lyrics_updated_at"] = 200
+	mu.Unlock()
+	if err := watcher.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := watcher.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2: %+v", len(events), events)
+	}
+	if events[0].Type != genius.WatchEventLyricsChanged || events[0].Song.ID != 1 || events[0].ArtistID != 1 {
+		t.Errorf("unexpected first event %+v", events[0])
+	}
+	if events[1].Type != genius.WatchEventNewSong || events[1].Song.ID != 2 {
+		t.Errorf("unexpected second event %+v", events[1])
+	}
+
+	// Deliveries rejected by the receiver are errors of the poll.
+	watcher = client.NewWatcher([]int{1}, genius.WithWebhook(receiver.URL, "wrong secret"))
+	if err := watcher.Poll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	songs = append(songs, map[string]any{"id": 3})
+	mu.Unlock()
+	if err := watcher.Poll(ctx); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("expected the rejected delivery to fail the poll, got %v", err)
+	}
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	body := []byte(`{"type":"new_song"}`)
+	signature := genius.SignWebhook("secret", body)
+
+	if !genius.VerifyWebhookSignature("secret", body, signature) {
+		t.Error("expected the signature to verify")
+	}
+	if genius.VerifyWebhookSignature("other", body, signature) {
+		t.Error("expected the signature not to verify with another secret")
+	}
+	if genius.VerifyWebhookSignature("secret", []byte(`{"type":"new_album"}`), signature) {
+		t.Error("expected the signature not to verify for another body")
+	}
+}